@@ -0,0 +1,73 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/biairmal/go-sdk/repository"
+)
+
+// FilterSpec specifies one filter from a request: field, operator, and value(s).
+// Use Value for single-value operators, Values for "in", "not_in" and "between",
+// and neither for "is_null" and "is_not_null" (see repository.FilterCondition).
+type FilterSpec struct {
+	Field    string `json:"field"`            // Column name
+	Operator string `json:"operator"`         // e.g. "eq", "in", "between"
+	Value    any    `json:"value,omitempty"`  // Value for single-value operators
+	Values   []any  `json:"values,omitempty"` // Values for "in", "not_in" and "between"
+}
+
+// FilterRequest is the request DTO for filtered list endpoints.
+// Filters are combined with AND.
+type FilterRequest struct {
+	Filters []FilterSpec `json:"filters"`
+}
+
+// ToFilter converts the request into a repository.Filter. Operators are matched
+// case-insensitively against the repository's supported set. An unknown operator,
+// an empty field, a field outside allowedFields (when given), or the wrong number
+// of values returns an error wrapping repository.ErrInvalidQuery, so bad input is
+// rejected instead of silently dropped.
+func (r *FilterRequest) ToFilter(allowedFields ...string) (repository.Filter, error) {
+	var filter repository.Filter
+	for i, spec := range r.Filters {
+		cond, err := spec.toCondition(allowedFields)
+		if err != nil {
+			return repository.Filter{}, fmt.Errorf("%w: filters[%d]: %w", repository.ErrInvalidQuery, i, err)
+		}
+		filter.Conditions = append(filter.Conditions, cond)
+	}
+	return filter, nil
+}
+
+// toCondition validates s and converts it into a repository.FilterCondition.
+func (s FilterSpec) toCondition(allowedFields []string) (repository.FilterCondition, error) {
+	if s.Field == "" {
+		return repository.FilterCondition{}, errors.New("field is required")
+	}
+	if len(allowedFields) > 0 && !slices.Contains(allowedFields, s.Field) {
+		return repository.FilterCondition{}, fmt.Errorf("field %q is not filterable", s.Field)
+	}
+	op := repository.FilterOperator(strings.ToLower(s.Operator))
+	if !op.Valid() {
+		return repository.FilterCondition{}, fmt.Errorf("unknown operator %q for field %q", s.Operator, s.Field)
+	}
+	switch op {
+	case repository.FilterOperatorIn, repository.FilterOperatorNotIn:
+		if len(s.Values) == 0 {
+			return repository.FilterCondition{}, fmt.Errorf("operator %q needs at least one value", op)
+		}
+	case repository.FilterOperatorBetween:
+		if len(s.Values) != 2 {
+			return repository.FilterCondition{}, fmt.Errorf("operator %q needs exactly two values", op)
+		}
+	case repository.FilterOperatorIsNull, repository.FilterOperatorIsNotNull:
+	default:
+		if s.Value == nil {
+			return repository.FilterCondition{}, fmt.Errorf("operator %q needs a value", op)
+		}
+	}
+	return repository.FilterCondition{Field: s.Field, Operator: op, Value: s.Value, Values: s.Values}, nil
+}
@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/biairmal/go-sdk/repository"
+)
+
+func TestFilterRequest_ToFilter(t *testing.T) {
+	req := FilterRequest{Filters: []FilterSpec{
+		{Field: "status", Operator: "EQ", Value: "active"},
+		{Field: "age", Operator: "between", Values: []any{18, 65}},
+		{Field: "deleted_at", Operator: "is_null"},
+	}}
+	got, err := req.ToFilter()
+	if err != nil {
+		t.Fatalf("ToFilter() error = %v", err)
+	}
+	if len(got.Conditions) != 3 {
+		t.Fatalf("ToFilter() returned %d conditions, want 3", len(got.Conditions))
+	}
+	if c := got.Conditions[0]; c.Operator != repository.FilterOperatorEq || c.Value != "active" {
+		t.Errorf("Conditions[0] = %+v, want status eq active", c)
+	}
+	if c := got.Conditions[1]; c.Operator != repository.FilterOperatorBetween || len(c.Values) != 2 {
+		t.Errorf("Conditions[1] = %+v, want age between 18 and 65", c)
+	}
+}
+
+func TestFilterRequest_ToFilter_invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		spec FilterSpec
+	}{
+		{"unknown operator", FilterSpec{Field: "name", Operator: "contains", Value: "x"}},
+		{"empty field", FilterSpec{Operator: "eq", Value: "x"}},
+		{"field not allowed", FilterSpec{Field: "password", Operator: "eq", Value: "x"}},
+		{"missing value", FilterSpec{Field: "name", Operator: "eq"}},
+		{"empty in", FilterSpec{Field: "name", Operator: "in"}},
+		{"between with one value", FilterSpec{Field: "age", Operator: "between", Values: []any{1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := FilterRequest{Filters: []FilterSpec{tt.spec}}
+			_, err := req.ToFilter("name", "age")
+			if !errors.Is(err, repository.ErrInvalidQuery) {
+				t.Errorf("ToFilter() error = %v, want ErrInvalidQuery", err)
+			}
+		})
+	}
+}
@@ -1,5 +1,5 @@
 // Package config provides a Viper-based configuration loader with support for
-// JSON/YAML files, in-file environment variable substitution, and .env loading.
+// JSON/YAML/TOML/INI files, in-file environment variable substitution, and .env loading.
 //
 // Example usage:
 //
@@ -20,22 +20,41 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
-// readFileAndSubstitute reads path, substitutes env vars in content, and returns
-// the data plus the config type extension (e.g. "yaml", "json").
+var timeType = reflect.TypeOf(time.Time{})
+
+// configTypes maps supported file extensions to Viper config types.
+var configTypes = map[string]string{
+	"yaml":   "yaml",
+	"yml":    "yaml",
+	"json":   "json",
+	"toml":   "toml",
+	"ini":    "ini",
+	"env":    "dotenv",
+	"dotenv": "dotenv",
+}
+
+// readFileAndSubstitute reads path, substitutes env vars and ${file:...} includes
+// in content (see Substitute), and returns the data plus the config type derived
+// from its extension (e.g. "yaml", "toml").
 func readFileAndSubstitute(path string) (data []byte, ext string, err error) {
+	ext, ok := configTypes[strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")]
+	if !ok {
+		return nil, "", fmt.Errorf("config: unsupported file type %q (want .yaml, .yml, .json, .toml or .ini)", path)
+	}
 	data, err = os.ReadFile(path)
 	if err != nil {
 		return nil, "", fmt.Errorf("config: read file %q: %w", path, err)
 	}
-	data = SubstituteEnv(data)
-	ext = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
-	if ext == "yml" {
-		ext = "yaml"
+	data, err = substituter{files: true}.expand(data, 0)
+	if err != nil {
+		return nil, "", fmt.Errorf("config: substitute %q: %w", path, err)
 	}
 	return data, ext, nil
 }
@@ -54,15 +73,99 @@ func applyConfigToViper(v *viper.Viper, data []byte, path string, initial bool)
 	return nil
 }
 
+// applyEnvJSON merges the JSON document held by the environment variable name
+// (see EnvJSON) into v, without substitution. It does nothing when name is empty
+// or the variable is unset or empty.
+func applyEnvJSON(v *viper.Viper, name string, initial bool, meta *LoadMeta) error {
+	if name == "" {
+		return nil
+	}
+	data := []byte(os.Getenv(name))
+	if len(data) == 0 {
+		return nil
+	}
+	source := envJSONSource(name)
+	v.SetConfigType("json")
+	if err := applyConfigToViper(v, data, source, initial); err != nil {
+		return err
+	}
+	if meta != nil {
+		return meta.recordFile(source, "json", data)
+	}
+	return nil
+}
+
+// newViper returns a Viper that reads environment overrides for every key of
+// dst: "handler.port" maps to HANDLER_PORT, or PREFIX_HANDLER_PORT with a prefix.
+func newViper(dst interface{}, prefix string) *viper.Viper {
+	v := newFileViper()
+	if prefix != "" {
+		v.SetEnvPrefix(prefix)
+	}
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+	// AutomaticEnv only applies to keys Viper already knows, so bind the keys of
+	// dst explicitly; env then overrides keys that no config file sets.
+	for _, key := range structKeys(reflect.TypeOf(dst), "") {
+		_ = v.BindEnv(key)
+	}
+	return v
+}
+
+// newFileViper returns a Viper that can read every supported file type.
+func newFileViper() *viper.Viper {
+	codecs := viper.NewCodecRegistry()
+	_ = codecs.RegisterCodec("ini", iniCodec{})
+	return viper.NewWithOptions(viper.WithCodecRegistry(codecs))
+}
+
+// structKeys returns the dotted mapstructure keys of the leaf fields of typ.
+func structKeys(typ reflect.Type, prefix string) []string {
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return nil
+	}
+	var keys []string
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		key := fieldPath(prefix, f)
+		ft := f.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Struct && ft != timeType {
+			keys = append(keys, structKeys(ft, key)...)
+			continue
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
 // Load populates dst from config files and environment. Dst must be a pointer
 // to a struct (possibly nested). Options control .env path and config file
 // paths. Pipeline: load .env (if EnvFile set) → create Viper with AutomaticEnv
-// → for each file (read → substitute ${VAR} and ${VAR:default} → ReadConfig
-// or MergeConfig) → Unmarshal into dst.
+// → for each file (read → substitute ${VAR}, ${VAR:default} and ${file:path} → ReadConfig
+// or MergeConfig) → merge the EnvJSON document → Unmarshal into dst → validate dst
+// (only with Validate).
 //
 // Config files are merged in order; later files override overlapping keys.
+// Files selected by Dir and Profile come first (base, then profile), then Files.
+// The EnvJSON document, if any, is merged after the files. Environment variables
+// (see EnvPrefix) override all of them, so precedence is
+// env > EnvJSON > later files > earlier files.
 // Nested structs are supported via mapstructure tags (see package README).
 func Load(dst interface{}, opts ...Option) error {
+	return load(dst, opts, nil)
+}
+
+// load implements Load and LoadWithMeta; meta is nil when provenance is not tracked.
+func load(dst interface{}, opts []Option, meta *LoadMeta) error {
 	o := &options{}
 	for _, fn := range opts {
 		fn(o)
@@ -74,14 +177,15 @@ func Load(dst interface{}, opts ...Option) error {
 		}
 	}
 
-	v := viper.New()
-	v.AutomaticEnv()
-
-	if len(o.files) == 0 {
-		return v.Unmarshal(dst)
+	files, err := dirFiles(o)
+	if err != nil {
+		return err
 	}
+	files = append(files, o.files...)
+
+	v := newViper(dst, o.envPrefix)
 
-	for i, path := range o.files {
+	for i, path := range files {
 		data, ext, err := readFileAndSubstitute(path)
 		if err != nil {
 			return err
@@ -90,10 +194,26 @@ func Load(dst interface{}, opts ...Option) error {
 		if err := applyConfigToViper(v, data, path, i == 0); err != nil {
 			return err
 		}
+		if meta != nil {
+			if err := meta.recordFile(path, ext, data); err != nil {
+				return err
+			}
+		}
+	}
+	if err := applyEnvJSON(v, o.envJSON, len(files) == 0, meta); err != nil {
+		return err
+	}
+	if meta != nil {
+		meta.recordEnv(v.AllKeys(), o.envPrefix)
 	}
 
 	if err := v.Unmarshal(dst); err != nil {
 		return fmt.Errorf("config: unmarshal: %w", err)
 	}
+	if o.validator != nil {
+		if err := o.validator.Struct(dst); err != nil {
+			return fmt.Errorf("config: validate: %w", err)
+		}
+	}
 	return nil
 }
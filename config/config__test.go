@@ -209,3 +209,164 @@ func TestLoad_jsonFile(t *testing.T) {
 		t.Errorf("port=%d name=%q, want 9000 json", dst.Port, dst.Name)
 	}
 }
+
+func TestLoad_envOverridesNestedKeys(t *testing.T) {
+	type HandlerOptions struct {
+		Port        int           `mapstructure:"port"`
+		ReadTimeout time.Duration `mapstructure:"read_timeout"`
+	}
+	type appConfig struct {
+		Handler HandlerOptions `mapstructure:"handler"`
+		Name    string         `mapstructure:"name"`
+	}
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(path, []byte("handler:\n  port: 8080\nname: file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		env      map[string]string
+		opts     []Option
+		wantPort int
+		wantRT   time.Duration
+		wantName string
+	}{
+		{
+			name:     "no prefix",
+			env:      map[string]string{"HANDLER_PORT": "9090"},
+			wantPort: 9090,
+			wantName: "file",
+		},
+		{
+			name:     "prefix, key missing from file",
+			env:      map[string]string{"APP_HANDLER_READ_TIMEOUT": "5s", "APP_NAME": "env", "HANDLER_PORT": "1"},
+			opts:     []Option{EnvPrefix("APP")},
+			wantPort: 8080,
+			wantRT:   5 * time.Second,
+			wantName: "env",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			var dst appConfig
+			if err := Load(&dst, append(tt.opts, Files(path))...); err != nil {
+				t.Fatalf("Load = %v", err)
+			}
+			if dst.Handler.Port != tt.wantPort || dst.Handler.ReadTimeout != tt.wantRT || dst.Name != tt.wantName {
+				t.Errorf("got port=%d read_timeout=%v name=%q, want %d %v %q",
+					dst.Handler.Port, dst.Handler.ReadTimeout, dst.Name, tt.wantPort, tt.wantRT, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestLoad_mixedFormats(t *testing.T) {
+	type appConfig struct {
+		Handler struct {
+			Port int    `mapstructure:"port"`
+			Host string `mapstructure:"host"`
+		} `mapstructure:"handler"`
+		Name  string `mapstructure:"name"`
+		Debug bool   `mapstructure:"debug"`
+	}
+	dir := t.TempDir()
+	base := filepath.Join(dir, "base.yaml")
+	if err := os.WriteFile(base, []byte("handler:\n  port: 8080\n  host: localhost\nname: base\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{name: "toml overlay", file: "overlay.toml", content: "debug = true\n\n[handler]\nport = 9090\n"},
+		{name: "ini overlay", file: "overlay.ini", content: "debug = true\n\n[handler]\nport = 9090\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			overlay := filepath.Join(dir, tt.file)
+			if err := os.WriteFile(overlay, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			var dst appConfig
+			if err := Load(&dst, Files(base, overlay)); err != nil {
+				t.Fatalf("Load = %v", err)
+			}
+			if dst.Handler.Port != 9090 || dst.Handler.Host != "localhost" || dst.Name != "base" || !dst.Debug {
+				t.Errorf("got %+v, want port 9090 from overlay, host and name from base, debug true", dst)
+			}
+		})
+	}
+}
+
+func TestLoad_unsupportedExtension(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.xml")
+	if err := os.WriteFile(path, []byte("<port>1</port>"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var dst struct{}
+	if err := Load(&dst, Files(path)); err == nil {
+		t.Error("Load with .xml file = nil, want error")
+	}
+}
+
+func TestLoad_envJSON(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	content := "name: from-file\nport: 8080\ndb:\n  host: file-host\n  user: file-user\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("APP_CONFIG", `{"port": 9090, "db": {"host": "json-host", "password": "p${ss}"}, "name": "from-json"}`)
+	t.Setenv("APP_NAME", "from-env")
+
+	var dst struct {
+		Name string `mapstructure:"name"`
+		Port int    `mapstructure:"port"`
+		DB   struct {
+			Host     string `mapstructure:"host"`
+			User     string `mapstructure:"user"`
+			Password string `mapstructure:"password"`
+		} `mapstructure:"db"`
+	}
+	if err := Load(&dst, Files(path), EnvJSON("APP_CONFIG"), EnvPrefix("APP")); err != nil {
+		t.Fatalf("Load = %v", err)
+	}
+	if dst.Name != "from-env" {
+		t.Errorf("name = %q, want from-env (env overrides EnvJSON)", dst.Name)
+	}
+	if dst.Port != 9090 || dst.DB.Host != "json-host" {
+		t.Errorf("port = %d, db.host = %q, want 9090 and json-host (EnvJSON overrides files)", dst.Port, dst.DB.Host)
+	}
+	if dst.DB.User != "file-user" {
+		t.Errorf("db.user = %q, want file-user (kept from file)", dst.DB.User)
+	}
+	if dst.DB.Password != "p${ss}" {
+		t.Errorf("db.password = %q, want p${ss} (no substitution)", dst.DB.Password)
+	}
+}
+
+func TestLoad_envJSONOnly(t *testing.T) {
+	var dst struct {
+		Port int `mapstructure:"port"`
+	}
+	t.Setenv("APP_CONFIG", `{"port": 7070}`)
+	if err := Load(&dst, EnvJSON("APP_CONFIG")); err != nil || dst.Port != 7070 {
+		t.Errorf("Load = %v, port = %d, want nil and 7070", err, dst.Port)
+	}
+
+	t.Setenv("APP_CONFIG", "")
+	if err := Load(&dst, EnvJSON("APP_CONFIG")); err != nil {
+		t.Errorf("Load with empty variable = %v, want nil", err)
+	}
+
+	t.Setenv("APP_CONFIG", `{"port": `)
+	if err := Load(&dst, EnvJSON("APP_CONFIG")); err == nil {
+		t.Error("Load with invalid JSON = nil, want error")
+	}
+}
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// iniCodec decodes INI files for Viper, which has no built-in INI support.
+// "[section]" headers start nested keys (dots in a header nest further, so
+// "[handler.tls]" holds handler.tls.*); keys before any header are top-level.
+// Lines are "key = value" or "key: value"; lines starting with ";" or "#" are
+// comments. Values are strings (surrounding quotes are removed); Viper's weak
+// decoding converts them to the destination field types.
+type iniCodec struct{}
+
+func (iniCodec) Encode(map[string]any) ([]byte, error) {
+	return nil, errors.New("config: encoding INI is not supported")
+}
+
+func (iniCodec) Decode(b []byte, v map[string]any) error {
+	section := v
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == ';' || line[0] == '#' {
+			continue
+		}
+		if line[0] == '[' {
+			if !strings.HasSuffix(line, "]") {
+				return fmt.Errorf("config: ini line %d: unterminated section header", n)
+			}
+			var err error
+			if section, err = iniSection(v, strings.TrimSpace(line[1:len(line)-1])); err != nil {
+				return fmt.Errorf("config: ini line %d: %w", n, err)
+			}
+			continue
+		}
+		i := strings.IndexAny(line, "=:")
+		if i <= 0 {
+			return fmt.Errorf("config: ini line %d: expected key = value", n)
+		}
+		key := strings.ToLower(strings.TrimSpace(line[:i]))
+		section[key] = iniValue(strings.TrimSpace(line[i+1:]))
+	}
+	return scanner.Err()
+}
+
+// iniSection returns the map for the dotted section name, creating it as needed.
+func iniSection(root map[string]any, name string) (map[string]any, error) {
+	if name == "" {
+		return nil, errors.New("empty section name")
+	}
+	m := root
+	for _, part := range strings.Split(strings.ToLower(name), ".") {
+		next, ok := m[part].(map[string]any)
+		if !ok {
+			if _, exists := m[part]; exists {
+				return nil, fmt.Errorf("section %q conflicts with key %q", name, part)
+			}
+			next = make(map[string]any)
+			m[part] = next
+		}
+		m = next
+	}
+	return m, nil
+}
+
+// iniValue removes matching surrounding quotes from a raw value.
+func iniValue(raw string) string {
+	if len(raw) >= 2 && (raw[0] == '"' || raw[0] == '\'') && raw[len(raw)-1] == raw[0] {
+		if raw[0] == '"' {
+			if s, err := strconv.Unquote(raw); err == nil {
+				return s
+			}
+		}
+		return raw[1 : len(raw)-1]
+	}
+	return raw
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIniCodec_Decode(t *testing.T) {
+	input := `; comment
+name = app
+# another comment
+[handler]
+port = 8080
+host: "0.0.0.0"
+
+[handler.tls]
+enabled = true
+cert = '/etc/cert.pem'
+`
+	got := map[string]any{}
+	if err := (iniCodec{}).Decode([]byte(input), got); err != nil {
+		t.Fatalf("Decode = %v", err)
+	}
+	want := map[string]any{
+		"name": "app",
+		"handler": map[string]any{
+			"port": "8080",
+			"host": "0.0.0.0",
+			"tls":  map[string]any{"enabled": "true", "cert": "/etc/cert.pem"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Decode = %v, want %v", got, want)
+	}
+}
+
+func TestIniCodec_DecodeErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "unterminated header", input: "[handler\nport = 1\n"},
+		{name: "missing separator", input: "port\n"},
+		{name: "empty section", input: "[]\n"},
+		{name: "section conflicts with key", input: "handler = x\n[handler]\nport = 1\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := (iniCodec{}).Decode([]byte(tt.input), map[string]any{}); err == nil {
+				t.Errorf("Decode(%q) = nil, want error", tt.input)
+			}
+		})
+	}
+}
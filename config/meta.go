@@ -0,0 +1,94 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Sources recorded in LoadMeta besides file paths.
+const (
+	SourceEnv     = "env"     // an environment variable (including ones set from the .env file)
+	SourceDefault = "default" // no file or variable set the key; the field keeps its zero value
+)
+
+// envJSONSource returns the source recorded in LoadMeta for keys set by the
+// EnvJSON variable name, e.g. "env:APP_CONFIG".
+func envJSONSource(name string) string {
+	return SourceEnv + ":" + name
+}
+
+// LoadMeta records where each resolved config key got its final value.
+type LoadMeta struct {
+	// Sources maps each dotted key (lower-cased, e.g. "handler.port") to the path of the
+	// last file that set it, "env:<VAR>" for the EnvJSON variable, SourceEnv or
+	// SourceDefault.
+	Sources map[string]string
+}
+
+// LoadWithMeta is Load that also reports, per key, which file, environment
+// variable or default supplied the final value. Keys cover every field of dst
+// plus any extra keys found in the files. On error the returned meta is nil.
+func LoadWithMeta(dst interface{}, opts ...Option) (*LoadMeta, error) {
+	meta := &LoadMeta{Sources: make(map[string]string)}
+	if err := load(dst, opts, meta); err != nil {
+		return nil, err
+	}
+	for _, key := range structKeys(reflect.TypeOf(dst), "") {
+		key = strings.ToLower(key)
+		if _, ok := meta.Sources[key]; !ok {
+			meta.Sources[key] = SourceDefault
+		}
+	}
+	return meta, nil
+}
+
+// Source returns where key got its value, or "" when key is unknown.
+// Key lookup is case-insensitive.
+func (m *LoadMeta) Source(key string) string {
+	return m.Sources[strings.ToLower(key)]
+}
+
+// Keys returns the recorded keys in sorted order.
+func (m *LoadMeta) Keys() []string {
+	keys := make([]string, 0, len(m.Sources))
+	for k := range m.Sources {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// recordFile marks every key set by the already substituted file content as coming from path.
+func (m *LoadMeta) recordFile(path, ext string, data []byte) error {
+	fv := newFileViper()
+	fv.SetConfigType(ext)
+	if err := fv.ReadConfig(bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("config: read config %q: %w", path, err)
+	}
+	for _, key := range fv.AllKeys() {
+		m.Sources[key] = path
+	}
+	return nil
+}
+
+// recordEnv marks the keys whose environment variable is set (and non-empty) as coming from env.
+func (m *LoadMeta) recordEnv(keys []string, prefix string) {
+	for _, key := range keys {
+		if os.Getenv(envVarName(prefix, key)) != "" {
+			m.Sources[key] = SourceEnv
+		}
+	}
+}
+
+// envVarName returns the environment variable Viper reads for key.
+func envVarName(prefix, key string) string {
+	name := strings.ReplaceAll(key, ".", "_")
+	if prefix != "" {
+		name = prefix + "_" + name
+	}
+	return strings.ToUpper(name)
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadWithMeta_sources(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "base.yaml")
+	overlay := filepath.Join(dir, "overlay.toml")
+	baseContent := "handler:\n  port: 8080\n  host: localhost\nname: base\nextra: x\n"
+	if err := os.WriteFile(base, []byte(baseContent), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(overlay, []byte("[handler]\nport = 9090\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("APP_NAME", "from-env")
+
+	var dst struct {
+		Handler struct {
+			Port int    `mapstructure:"port"`
+			Host string `mapstructure:"host"`
+			TLS  bool   `mapstructure:"tls"`
+		} `mapstructure:"handler"`
+		Name string `mapstructure:"name"`
+	}
+	meta, err := LoadWithMeta(&dst, EnvPrefix("APP"), Files(base, overlay))
+	if err != nil {
+		t.Fatalf("LoadWithMeta = %v", err)
+	}
+	want := map[string]string{
+		"handler.port": overlay,
+		"handler.host": base,
+		"handler.tls":  SourceDefault,
+		"name":         SourceEnv,
+		"extra":        base,
+	}
+	for key, src := range want {
+		if got := meta.Source(key); got != src {
+			t.Errorf("Source(%q) = %q, want %q", key, got, src)
+		}
+	}
+	if len(meta.Keys()) != len(want) {
+		t.Errorf("Keys() = %v, want %d keys", meta.Keys(), len(want))
+	}
+	if dst.Handler.Port != 9090 || dst.Name != "from-env" {
+		t.Errorf("dst = %+v, want port 9090 and name from-env", dst)
+	}
+}
+
+func TestLoadWithMeta_error(t *testing.T) {
+	var dst struct{}
+	meta, err := LoadWithMeta(&dst, Files("nonexistent.yaml"))
+	if err == nil || meta != nil {
+		t.Errorf("LoadWithMeta = %v, %v, want nil meta and error", meta, err)
+	}
+}
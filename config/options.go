@@ -2,8 +2,13 @@ package config
 
 // options holds configuration for Load. It is populated by Option functions.
 type options struct {
-	envFile string
-	files   []string
+	envFile   string
+	envPrefix string
+	dir       string
+	profile   string
+	files     []string
+	envJSON   string
+	validator StructValidator
 }
 
 // Option configures Load behavior. Options are applied in order; later
@@ -28,3 +33,59 @@ func Files(paths ...string) Option {
 		o.files = paths
 	}
 }
+
+// Dir sets a directory of layered config files: base.<ext> is loaded first (if
+// present), then <profile>.<ext> for the Profile option. Extensions are those
+// supported by Files (yaml, yml, json, toml, ini). Files given with the Files
+// option are merged after the directory files.
+func Dir(path string) Option {
+	return func(o *options) {
+		o.dir = path
+	}
+}
+
+// Profile selects the environment profile loaded over base from Dir, e.g.
+// Profile("prod") loads prod.yaml. Unlike base, the profile file must exist.
+// Without Dir, the current directory is used.
+func Profile(name string) Option {
+	return func(o *options) {
+		o.profile = name
+	}
+}
+
+// EnvJSON reads a whole JSON config document from the environment variable
+// varName, for platforms that deliver config (often secrets) as a single
+// variable. The document is merged after all config files, so its keys override
+// theirs; individual environment variables (see EnvPrefix) still override it.
+// Its content is used as-is: ${VAR} and ${file:...} are not substituted. An unset
+// or empty variable is skipped.
+func EnvJSON(varName string) Option {
+	return func(o *options) {
+		o.envJSON = varName
+	}
+}
+
+// EnvPrefix sets the prefix for environment variable overrides. Keys map to
+// variables by upper-casing and replacing "." with "_", then prefixing with
+// prefix and "_": with EnvPrefix("APP"), handler.port is read from
+// APP_HANDLER_PORT. Without a prefix it is read from HANDLER_PORT. Environment
+// variables override values from every config file.
+func EnvPrefix(prefix string) Option {
+	return func(o *options) {
+		o.envPrefix = prefix
+	}
+}
+
+// Validate enables validation of dst after it is populated. v checks the struct;
+// pass nil to use go-playground/validator (validator.New()) over the `validate`
+// tags, reporting every failed field by its config key path (see
+// ValidationErrors). Pass a configured validator to add custom rules. Without
+// this option Load does not validate.
+func Validate(v StructValidator) Option {
+	return func(o *options) {
+		if v == nil {
+			v = newTagValidator()
+		}
+		o.validator = v
+	}
+}
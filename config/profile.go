@@ -0,0 +1,70 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// profileExts are the extensions tried for files in Dir, in lookup order.
+var profileExts = []string{"yaml", "yml", "json", "toml", "ini"}
+
+// dirFiles returns the files selected by Dir and Profile in merge order: base
+// (optional) then the profile (required when a profile is set).
+func dirFiles(o *options) ([]string, error) {
+	if o.dir == "" && o.profile == "" {
+		return nil, nil
+	}
+	dir := o.dir
+	if dir == "" {
+		dir = "."
+	}
+	var files []string
+	base, err := findConfigFile(dir, "base")
+	if err != nil {
+		return nil, err
+	}
+	if base != "" {
+		files = append(files, base)
+	}
+	if o.profile == "" {
+		return files, nil
+	}
+	if strings.ContainsAny(o.profile, `/\`) || o.profile == ".." {
+		return nil, fmt.Errorf("config: invalid profile name %q", o.profile)
+	}
+	profile, err := findConfigFile(dir, o.profile)
+	if err != nil {
+		return nil, err
+	}
+	if profile == "" {
+		return nil, fmt.Errorf("config: profile %q: no %s.{%s} in %q",
+			o.profile, o.profile, strings.Join(profileExts, ","), dir)
+	}
+	return append(files, profile), nil
+}
+
+// findConfigFile returns the path of name.<ext> in dir, or "" when there is none.
+// More than one match (e.g. base.yaml and base.json) is an error.
+func findConfigFile(dir, name string) (string, error) {
+	var found []string
+	for _, ext := range profileExts {
+		path := filepath.Join(dir, name+"."+ext)
+		info, err := os.Stat(path)
+		switch {
+		case err == nil && !info.IsDir():
+			found = append(found, path)
+		case err != nil && !errors.Is(err, os.ErrNotExist):
+			return "", fmt.Errorf("config: stat %q: %w", path, err)
+		}
+	}
+	if len(found) > 1 {
+		return "", fmt.Errorf("config: ambiguous config %q in %q: %s", name, dir, strings.Join(found, ", "))
+	}
+	if len(found) == 0 {
+		return "", nil
+	}
+	return found[0], nil
+}
@@ -0,0 +1,106 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestLoad_dirProfile(t *testing.T) {
+	type appConfig struct {
+		Port int    `mapstructure:"port"`
+		Name string `mapstructure:"name"`
+		Mode string `mapstructure:"mode"`
+	}
+	tests := []struct {
+		name    string
+		files   map[string]string
+		opts    func(dir string) []Option
+		want    appConfig
+		wantErr string
+	}{
+		{
+			name: "base then profile",
+			files: map[string]string{
+				"base.yaml": "port: 8080\nname: base\n",
+				"prod.yml":  "name: prod\n",
+				"dev.yaml":  "name: dev\n",
+			},
+			opts: func(dir string) []Option { return []Option{Dir(dir), Profile("prod")} },
+			want: appConfig{Port: 8080, Name: "prod"},
+		},
+		{
+			name:  "missing base is optional",
+			files: map[string]string{"prod.json": `{"name": "prod"}`},
+			opts:  func(dir string) []Option { return []Option{Dir(dir), Profile("prod")} },
+			want:  appConfig{Name: "prod"},
+		},
+		{
+			name:  "base only without profile",
+			files: map[string]string{"base.toml": "port = 1\n"},
+			opts:  func(dir string) []Option { return []Option{Dir(dir)} },
+			want:  appConfig{Port: 1},
+		},
+		{
+			name: "explicit files merge last",
+			files: map[string]string{
+				"base.yaml":  "port: 8080\n",
+				"prod.yaml":  "name: prod\n",
+				"local.yaml": "mode: debug\nname: local\n",
+			},
+			opts: func(dir string) []Option {
+				return []Option{Files(filepath.Join(dir, "local.yaml")), Dir(dir), Profile("prod")}
+			},
+			want: appConfig{Port: 8080, Name: "local", Mode: "debug"},
+		},
+		{
+			name:    "missing profile",
+			files:   map[string]string{"base.yaml": "port: 8080\n"},
+			opts:    func(dir string) []Option { return []Option{Dir(dir), Profile("prod")} },
+			wantErr: `profile "prod"`,
+		},
+		{
+			name:    "ambiguous base",
+			files:   map[string]string{"base.yaml": "port: 1\n", "base.json": `{"port": 2}`},
+			opts:    func(dir string) []Option { return []Option{Dir(dir)} },
+			wantErr: "ambiguous",
+		},
+		{
+			name:    "profile with path separator",
+			files:   map[string]string{"base.yaml": "port: 1\n"},
+			opts:    func(dir string) []Option { return []Option{Dir(dir), Profile("../prod")} },
+			wantErr: "invalid profile",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeFiles(t, tt.files)
+			var dst appConfig
+			err := Load(&dst, tt.opts(dir)...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Load = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load = %v", err)
+			}
+			if dst != tt.want {
+				t.Errorf("dst = %+v, want %+v", dst, tt.want)
+			}
+		})
+	}
+}
@@ -1,31 +1,148 @@
 // Package config provides a Viper-based configuration loader with support for
-// JSON/YAML files, in-file environment variable substitution, and .env loading.
+// JSON/YAML/TOML/INI files, in-file environment variable substitution, and .env loading.
 package config
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"os"
-	"regexp"
 )
 
-// envSubstRegex matches ${VAR} or ${VAR:default_value}.
-// Group 1: variable name; Group 2 (optional): default value.
-var envSubstRegex = regexp.MustCompile(`\$\{([^}:]+)(?::([^}]*))?\}`)
+// maxSubstDepth bounds how deeply defaults may nest references, e.g.
+// ${A:${B:${C}}} is depth 3. Deeper references are left as literal text.
+const maxSubstDepth = 8
 
 // SubstituteEnv replaces ${VAR} and ${VAR:default_value} in b with values from
 // the environment. For ${VAR}, the result is os.Getenv("VAR"). For
-// ${VAR:default_value}, the default is used when VAR is unset or empty.
+// ${VAR:default_value}, the default is used when VAR is unset or empty; the
+// default may itself contain references (${URL:${FALLBACK_URL}}), expanded up to
+// maxSubstDepth levels. Values read from the environment are not expanded again.
+// $${...} is an escape and produces the literal text ${...}. An unterminated
+// ${ is kept as is. The environment is only read, never modified.
 // The returned slice is a new allocation; b is not modified.
 func SubstituteEnv(b []byte) []byte {
-	return envSubstRegex.ReplaceAllFunc(b, func(match []byte) []byte {
-		submatches := envSubstRegex.FindSubmatch(match)
-		if len(submatches) < 2 {
-			return match
-		}
-		name := string(submatches[1])
-		val := os.Getenv(name)
-		if len(submatches) == 3 && (val == "") {
-			val = string(submatches[2])
-		}
-		return []byte(val)
-	})
+	out, _ := substituter{}.expand(b, 0)
+	return out
+}
+
+// Substitute is SubstituteEnv plus file includes: ${file:/path} is replaced with
+// the contents of the file at path with surrounding whitespace trimmed (e.g. a
+// Docker or Kubernetes secret), and ${file:/path:fallback} uses fallback when the
+// file does not exist or is empty. A missing file without fallback, or any other
+// read error, is returned. Load uses Substitute for config file content.
+func Substitute(b []byte) ([]byte, error) {
+	out, err := substituter{files: true}.expand(b, 0)
+	if err != nil {
+		return nil, fmt.Errorf("config: %w", err)
+	}
+	return out, nil
+}
+
+// substituter expands ${...} references; files enables ${file:...} includes.
+type substituter struct {
+	files bool
+}
+
+// expand expands the references in b; depth counts the enclosing defaults.
+func (s substituter) expand(b []byte, depth int) ([]byte, error) {
+	out := make([]byte, 0, len(b))
+	for i := 0; i < len(b); {
+		escaped := bytes.HasPrefix(b[i:], []byte("$${"))
+		if !escaped && !bytes.HasPrefix(b[i:], []byte("${")) {
+			out = append(out, b[i])
+			i++
+			continue
+		}
+		start := i + 2
+		if escaped {
+			start++
+		}
+		end := closingBrace(b, start)
+		if end < 0 {
+			out = append(out, b[i:]...)
+			break
+		}
+		if escaped {
+			out = append(out, b[i+1:end+1]...)
+			i = end + 1
+			continue
+		}
+		val, ok, err := s.resolve(b[start:end], depth)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			out = append(out, val...)
+		} else {
+			out = append(out, b[i:end+1]...)
+		}
+		i = end + 1
+	}
+	return out, nil
+}
+
+// resolve returns the value of the reference body "VAR", "VAR:default",
+// "file:path" or "file:path:default". It reports false for an empty variable
+// name, which is left unexpanded.
+func (s substituter) resolve(body []byte, depth int) (val []byte, ok bool, err error) {
+	if s.files && bytes.HasPrefix(body, []byte("file:")) {
+		return s.resolveFile(body[len("file:"):], depth)
+	}
+	name, def, hasDefault := bytes.Cut(body, []byte(":"))
+	if len(name) == 0 {
+		return nil, false, nil
+	}
+	if v := os.Getenv(string(name)); v != "" || !hasDefault {
+		return []byte(v), true, nil
+	}
+	return s.fallback(def, depth)
+}
+
+// resolveFile reads the file reference "path" or "path:default".
+func (s substituter) resolveFile(ref []byte, depth int) (val []byte, ok bool, err error) {
+	path, def, hasDefault := bytes.Cut(ref, []byte(":"))
+	if len(path) == 0 {
+		return nil, false, errors.New("${file:...} reference without a path")
+	}
+	data, err := os.ReadFile(string(path))
+	if errors.Is(err, os.ErrNotExist) {
+		if hasDefault {
+			return s.fallback(def, depth)
+		}
+		return nil, false, fmt.Errorf("${file:%s}: file does not exist (set a default with ${file:%s:value})", path, path)
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("${file:%s}: %w", path, err)
+	}
+	if val = bytes.TrimSpace(data); len(val) == 0 && hasDefault {
+		return s.fallback(def, depth)
+	}
+	return val, true, nil
+}
+
+// fallback expands a default value one level deeper, or returns it verbatim past maxSubstDepth.
+func (s substituter) fallback(def []byte, depth int) ([]byte, bool, error) {
+	if depth+1 >= maxSubstDepth {
+		return def, true, nil
+	}
+	val, err := s.expand(def, depth+1)
+	return val, err == nil, err
+}
+
+// closingBrace returns the index of the "}" closing a reference whose body starts at start,
+// skipping braces of nested references, or -1 when there is none.
+func closingBrace(b []byte, start int) int {
+	nesting := 0
+	for j := start; j < len(b); j++ {
+		switch {
+		case b[j] == '{' && j > 0 && b[j-1] == '$':
+			nesting++
+		case b[j] == '}' && nesting > 0:
+			nesting--
+		case b[j] == '}':
+			return j
+		}
+	}
+	return -1
 }
@@ -2,6 +2,8 @@ package config
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -57,6 +59,55 @@ func TestSubstituteEnv(t *testing.T) {
 			input:    "key: ${NONE:}",
 			expected: "key: ",
 		},
+		{
+			name:     "escaped sequence is literal",
+			env:      map[string]string{"VAR": "value"},
+			input:    "tmpl: $${VAR} val: ${VAR}",
+			expected: "tmpl: ${VAR} val: value",
+		},
+		{
+			name:     "escaped sequence with default",
+			input:    "tmpl: $${VAR:${OTHER}}",
+			expected: "tmpl: ${VAR:${OTHER}}",
+		},
+		{
+			name:     "default references another variable",
+			env:      map[string]string{"FALLBACK_URL": "postgres://fallback"},
+			input:    "url: ${DB_URL:${FALLBACK_URL}}",
+			expected: "url: postgres://fallback",
+		},
+		{
+			name:     "nested default of default",
+			input:    "url: ${A:${B:${C:deep}}}!",
+			expected: "url: deep!",
+		},
+		{
+			name:     "set value skips nested default",
+			env:      map[string]string{"DB_URL": "postgres://set"},
+			input:    "url: ${DB_URL:${FALLBACK_URL}}",
+			expected: "url: postgres://set",
+		},
+		{
+			name:     "env value is not expanded",
+			env:      map[string]string{"RAW": "${OTHER}"},
+			input:    "key: ${RAW}",
+			expected: "key: ${OTHER}",
+		},
+		{
+			name:     "recursion depth is limited",
+			input:    "k: ${A:${B:${C:${D:${E:${F:${G:${H:${I:x}}}}}}}}}",
+			expected: "k: ${I:x}",
+		},
+		{
+			name:     "unterminated reference",
+			input:    "key: ${VAR",
+			expected: "key: ${VAR",
+		},
+		{
+			name:     "empty name",
+			input:    "key: ${}",
+			expected: "key: ${}",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -79,3 +130,82 @@ func TestSubstituteEnv_restoresEnv(t *testing.T) {
 		t.Error("SubstituteEnv must not modify environment")
 	}
 }
+
+func TestSubstituteEnv_nestedDefaultsDoNotModifyEnv(t *testing.T) {
+	t.Setenv("TEST_FALLBACK", "fallback")
+	got := string(SubstituteEnv([]byte("${TEST_UNSET_VAR:${TEST_FALLBACK}} $${TEST_FALLBACK}")))
+	if got != "fallback ${TEST_FALLBACK}" {
+		t.Errorf("SubstituteEnv = %q, want %q", got, "fallback ${TEST_FALLBACK}")
+	}
+	if _, ok := os.LookupEnv("TEST_UNSET_VAR"); ok {
+		t.Error("SubstituteEnv must not set variables that defaults were used for")
+	}
+	if os.Getenv("TEST_FALLBACK") != "fallback" {
+		t.Error("SubstituteEnv must not modify environment")
+	}
+}
+
+func TestSubstitute_fileIncludes(t *testing.T) {
+	dir := t.TempDir()
+	secret := filepath.Join(dir, "db_password")
+	empty := filepath.Join(dir, "empty")
+	if err := os.WriteFile(secret, []byte("  s3cret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(empty, []byte("\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	missing := filepath.Join(dir, "missing")
+
+	tests := []struct {
+		name    string
+		env     map[string]string
+		input   string
+		want    string
+		wantErr string
+	}{
+		{name: "file contents trimmed", input: "pw: ${file:" + secret + "}", want: "pw: s3cret"},
+		{name: "missing file uses default", input: "pw: ${file:" + missing + ":fallback}", want: "pw: fallback"},
+		{name: "empty file uses default", input: "pw: ${file:" + empty + ":fallback}", want: "pw: fallback"},
+		{name: "empty file without default", input: "pw: '${file:" + empty + "}'", want: "pw: ''"},
+		{
+			name:  "default references env",
+			env:   map[string]string{"DB_PASSWORD": "from-env"},
+			input: "pw: ${file:" + missing + ":${DB_PASSWORD}}",
+			want:  "pw: from-env",
+		},
+		{
+			name:  "env and file together",
+			env:   map[string]string{"APP_USER": "app"},
+			input: "${APP_USER}:${file:" + secret + "}",
+			want:  "app:s3cret",
+		},
+		{name: "missing file without default", input: "pw: ${file:" + missing + "}", wantErr: "does not exist"},
+		{name: "no path", input: "pw: ${file:}", wantErr: "without a path"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			got, err := Substitute([]byte(tt.input))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Substitute(%q) error = %v, want %q", tt.input, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || string(got) != tt.want {
+				t.Errorf("Substitute(%q) = %q, %v, want %q", tt.input, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubstituteEnv_ignoresFileIncludes(t *testing.T) {
+	got := string(SubstituteEnv([]byte("pw: ${file:/nonexistent/secret:fallback}")))
+	// Env-only semantics: "file" is an (unset) variable name and the rest is its default.
+	if want := "pw: /nonexistent/secret:fallback"; got != want {
+		t.Errorf("SubstituteEnv = %q, want %q", got, want)
+	}
+}
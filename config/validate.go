@@ -0,0 +1,118 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// StructValidator validates a populated config struct. *validator.Validate from
+// github.com/go-playground/validator/v10 satisfies it, so a validator with custom
+// rules or options can be passed to Validate directly.
+type StructValidator interface {
+	Struct(s interface{}) error
+}
+
+// FieldError is one failed validation rule. Path is the dotted config key of the
+// field (mapstructure names, e.g. "handler.port" or "hosts[0]"); Rule is the failed
+// rule as written in the tag (e.g. "required" or "min=1").
+type FieldError struct {
+	Path string
+	Rule string
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: failed %q", e.Path, e.Rule)
+}
+
+// ValidationErrors holds every FieldError found by the default validator.
+type ValidationErrors []FieldError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, fe := range e {
+		msgs[i] = fe.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// squashName is the name tagName gives squashed (embedded) fields; path drops it
+// so their fields keep the parent's prefix.
+const squashName = ",squash"
+
+// tagValidator is the StructValidator used by Validate(nil). It runs
+// go-playground/validator over the `validate:"..."` tags and converts its
+// errors to ValidationErrors keyed by mapstructure names.
+type tagValidator struct {
+	v *validator.Validate
+}
+
+func newTagValidator() tagValidator {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.RegisterTagNameFunc(tagName)
+	return tagValidator{v: v}
+}
+
+// Struct validates s. A tag with a rule the validator does not know is
+// reported as an error instead of the validator's panic.
+func (t tagValidator) Struct(s interface{}) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("config: validate: %v", r)
+		}
+	}()
+	err = t.v.Struct(s)
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return err
+	}
+	errs := make(ValidationErrors, len(verrs))
+	for i, fe := range verrs {
+		rule := fe.Tag()
+		if fe.Param() != "" {
+			rule += "=" + fe.Param()
+		}
+		errs[i] = FieldError{Path: path(fe.Namespace()), Rule: rule}
+	}
+	return errs
+}
+
+// tagName returns the config key of f (see fieldPath) as the validator's field name.
+func tagName(f reflect.StructField) string {
+	if name := fieldPath("", f); name != "" {
+		return name
+	}
+	return squashName
+}
+
+// fieldPath returns the dotted key of f under prefix, using its mapstructure name.
+// Squashed (embedded) fields keep the prefix.
+func fieldPath(prefix string, f reflect.StructField) string {
+	name, opts, _ := strings.Cut(f.Tag.Get("mapstructure"), ",")
+	if strings.Contains(opts, "squash") {
+		return prefix
+	}
+	if name == "" {
+		name = strings.ToLower(f.Name)
+	}
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// path turns a validator namespace ("AppConfig.handler.port") into a config key
+// ("handler.port") by dropping the root type name and squashed fields.
+func path(namespace string) string {
+	segments := strings.Split(namespace, ".")[1:]
+	keep := segments[:0]
+	for _, s := range segments {
+		if s != squashName {
+			keep = append(keep, s)
+		}
+	}
+	return strings.Join(keep, ".")
+}
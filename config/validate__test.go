@@ -0,0 +1,135 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type validatedConfig struct {
+	Handler struct {
+		Port int    `mapstructure:"port" validate:"required,min=1,max=65535"`
+		Mode string `mapstructure:"mode" validate:"oneof=debug release"`
+	} `mapstructure:"handler"`
+	DatabaseURL string   `mapstructure:"database_url" validate:"required"`
+	Hosts       []string `mapstructure:"hosts" validate:"min=1,dive"`
+}
+
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad_validateAggregatesFieldErrors(t *testing.T) {
+	path := writeConfig(t, "config.yaml", "handler:\n  port: 70000\n  mode: verbose\n")
+
+	var dst validatedConfig
+	err := Load(&dst, Files(path), Validate(nil))
+	var verrs ValidationErrors
+	if !errors.As(err, &verrs) {
+		t.Fatalf("Load = %v, want ValidationErrors", err)
+	}
+	want := ValidationErrors{
+		{Path: "handler.port", Rule: "max=65535"},
+		{Path: "handler.mode", Rule: "oneof=debug release"},
+		{Path: "database_url", Rule: "required"},
+		{Path: "hosts", Rule: "min=1"},
+	}
+	if !reflect.DeepEqual(verrs, want) {
+		t.Errorf("ValidationErrors = %v, want %v", verrs, want)
+	}
+}
+
+func TestLoad_validatePasses(t *testing.T) {
+	path := writeConfig(t, "config.yaml",
+		"handler:\n  port: 8080\n  mode: release\ndatabase_url: postgres://db\nhosts: [a]\n")
+
+	var dst validatedConfig
+	if err := Load(&dst, Files(path), Validate(nil)); err != nil {
+		t.Fatalf("Load = %v", err)
+	}
+}
+
+func TestLoad_validateOptIn(t *testing.T) {
+	path := writeConfig(t, "config.yaml", "handler:\n  port: 0\n")
+
+	var dst validatedConfig
+	if err := Load(&dst, Files(path)); err != nil {
+		t.Errorf("Load without Validate = %v, want nil", err)
+	}
+}
+
+type validatorFunc func(interface{}) error
+
+func (f validatorFunc) Struct(s interface{}) error { return f(s) }
+
+func TestLoad_customValidator(t *testing.T) {
+	path := writeConfig(t, "config.yaml", "database_url: x\n")
+	boom := errors.New("boom")
+
+	var dst validatedConfig
+	var got interface{}
+	err := Load(&dst, Files(path), Validate(validatorFunc(func(s interface{}) error {
+		got = s
+		return boom
+	})))
+	if !errors.Is(err, boom) {
+		t.Errorf("Load = %v, want %v", err, boom)
+	}
+	if got != &dst {
+		t.Errorf("validator got %v, want dst", got)
+	}
+}
+
+type fullRulesConfig struct {
+	commonConfig `mapstructure:",squash"`
+	AdminEmail   string            `mapstructure:"admin_email" validate:"email"`
+	Workers      int               `mapstructure:"workers" validate:"gte=1"`
+	Peers        []string          `mapstructure:"peers" validate:"dive,hostname_port"`
+	Labels       map[string]string `mapstructure:"labels" validate:"dive,keys,alpha,endkeys,required"`
+}
+
+type commonConfig struct {
+	Name string `mapstructure:"name" validate:"required"`
+}
+
+func TestLoad_validateFullRuleSet(t *testing.T) {
+	path := writeConfig(t, "config.yaml", "admin_email: nope\nworkers: 0\n"+
+		"peers: [\"db:5432\", \"bad host\"]\nlabels:\n  team: \"\"\n")
+
+	var dst fullRulesConfig
+	err := Load(&dst, Files(path), Validate(nil))
+	var verrs ValidationErrors
+	if !errors.As(err, &verrs) {
+		t.Fatalf("Load = %v, want ValidationErrors", err)
+	}
+	want := ValidationErrors{
+		{Path: "name", Rule: "required"},
+		{Path: "admin_email", Rule: "email"},
+		{Path: "workers", Rule: "gte=1"},
+		{Path: "peers[1]", Rule: "hostname_port"},
+		{Path: "labels[team]", Rule: "required"},
+	}
+	if !reflect.DeepEqual(verrs, want) {
+		t.Errorf("ValidationErrors = %v, want %v", verrs, want)
+	}
+}
+
+func TestLoad_validateUnknownRule(t *testing.T) {
+	path := writeConfig(t, "config.yaml", "name: x\n")
+
+	var dst struct {
+		Name string `mapstructure:"name" validate:"no_such_rule"`
+	}
+	err := Load(&dst, Files(path), Validate(nil))
+	if err == nil || !strings.Contains(err.Error(), "no_such_rule") {
+		t.Errorf("Load = %v, want an error naming the unknown rule", err)
+	}
+}
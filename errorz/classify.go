@@ -0,0 +1,83 @@
+package errorz
+
+import "errors"
+
+// retryableCodes are the predefined codes that describe transient failures.
+var retryableCodes = map[string]bool{
+	CodeServiceUnavailable: true,
+	CodeBadGateway:         true,
+	CodeTooManyRequests:    true,
+}
+
+// WithRetryable forces the retry classification of the error and returns the
+// receiver for method chaining. The override takes precedence over the default
+// classification in IsRetryable.
+//
+// Example:
+//
+//	// A conflict caused by optimistic locking can safely be retried
+//	err := errorz.Conflict().WithRetryable(true)
+func (e *Error) WithRetryable(retryable bool) *Error {
+	e.Retryable = &retryable
+	return e
+}
+
+// IsRetryable reports whether err describes a transient failure that may
+// succeed when retried.
+//
+// The first *Error in the chain with an explicit WithRetryable override decides
+// the result. Otherwise the error is retryable when it matches ErrServiceUnavailable,
+// ErrBadGateway, or ErrTooManyRequests (via errors.Is), or when an *Error in the
+// chain carries one of their codes. All other errors, including client errors
+// such as ErrNotFound and ErrBadRequest, are not retryable. A nil error is not retryable.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	for cur := err; cur != nil; {
+		errz, ok := AsError(cur)
+		if !ok {
+			break
+		}
+		if errz.Retryable != nil {
+			return *errz.Retryable
+		}
+		if retryableCodes[errz.Code] {
+			return true
+		}
+		cur = errz.Err
+	}
+	return errors.Is(err, ErrServiceUnavailable) ||
+		errors.Is(err, ErrBadGateway) ||
+		errors.Is(err, ErrTooManyRequests)
+}
+
+// Temporary reports whether the error is transient, mirroring the
+// interface{ Temporary() bool } checked by net.Error-aware code.
+// It honors a WithRetryable override; otherwise it is true for
+// CodeTooManyRequests, CodeBadGateway, and CodeServiceUnavailable.
+func (e *Error) Temporary() bool {
+	if e.Retryable != nil {
+		return *e.Retryable
+	}
+	return retryableCodes[e.Code]
+}
+
+// Timeout reports whether the error was caused by a timeout, mirroring
+// net.Error. It is false unless set via WithTimeout.
+func (e *Error) Timeout() bool {
+	return e.timeout
+}
+
+// WithTimeout marks the error as a timeout and returns the receiver for
+// method chaining.
+//
+// Example:
+//
+//	err := errorz.ServiceUnavailable().WithTimeout(true)
+//	var te interface{ Timeout() bool }
+//	errors.As(err, &te) // true, te.Timeout() == true
+func (e *Error) WithTimeout(timeout bool) *Error {
+	e.timeout = timeout
+	return e
+}
@@ -0,0 +1,114 @@
+package errorz
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil error", nil, false},
+		{"plain error", errors.New("plain"), false},
+		{"ServiceUnavailable", ServiceUnavailable(), true},
+		{"BadGateway", BadGateway(), true},
+		{"TooManyRequests", TooManyRequests(), true},
+		{"NotFound", NotFound(), false},
+		{"BadRequest", BadRequest(), false},
+		{"Internal", Internal(), false},
+		{"sentinel directly", ErrServiceUnavailable, true},
+		{"wrapped with fmt", fmt.Errorf("call failed: %w", BadGateway()), true},
+		{"custom code kept from constructor", ServiceUnavailable().WithCode("UPSTREAM_DOWN"), true},
+		{"retryable code without sentinel", New("x").WithCode(CodeTooManyRequests), true},
+		{"override true on client error", Conflict().WithRetryable(true), true},
+		{"override false on transient error", ServiceUnavailable().WithRetryable(false), false},
+		{"outer override wins", Wrap(ServiceUnavailable()).WithRetryable(false), false},
+		{"inner override used when outer has none", Wrap(Conflict().WithRetryable(true)), true},
+		{"joined transient cause", WrapAll(errors.New("a"), TooManyRequests()), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.err); got != tt.want {
+				t.Errorf("IsRetryable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestError_WithRetryable(t *testing.T) {
+	err := New("test")
+	got := err.WithRetryable(true)
+	if got != err {
+		t.Errorf("Error.WithRetryable() should return same instance for chaining")
+	}
+	if got.Retryable == nil || !*got.Retryable {
+		t.Errorf("Error.WithRetryable(true).Retryable = %v, want true", got.Retryable)
+	}
+}
+
+func TestError_Temporary(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *Error
+		want bool
+	}{
+		{"TooManyRequests", TooManyRequests(), true},
+		{"BadGateway", BadGateway(), true},
+		{"ServiceUnavailable", ServiceUnavailable(), true},
+		{"NotFound", NotFound(), false},
+		{"Internal", Internal(), false},
+		{"no code", New("x"), false},
+		{"override true", NotFound().WithRetryable(true), true},
+		{"override false", ServiceUnavailable().WithRetryable(false), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Temporary(); got != tt.want {
+				t.Errorf("Error.Temporary() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestError_WithTimeout(t *testing.T) {
+	err := New("test")
+	if err.Timeout() {
+		t.Errorf("Error.Timeout() = true, want false by default")
+	}
+	got := err.WithTimeout(true)
+	if got != err {
+		t.Errorf("Error.WithTimeout() should return same instance for chaining")
+	}
+	if !got.Timeout() {
+		t.Errorf("Error.WithTimeout(true).Timeout() = false, want true")
+	}
+	if got.Clone().Timeout() != true {
+		t.Errorf("Error.Clone().Timeout() = false, want true")
+	}
+}
+
+func TestError_netErrorStyleInterfaces(t *testing.T) {
+	var err error = fmt.Errorf("transport: %w", ServiceUnavailable().WithTimeout(true))
+
+	var temp interface{ Temporary() bool }
+	if !errors.As(err, &temp) {
+		t.Fatalf("errors.As(err, Temporary) = false, want true")
+	}
+	if !temp.Temporary() {
+		t.Errorf("Temporary() = false, want true")
+	}
+
+	var to interface{ Timeout() bool }
+	if !errors.As(err, &to) {
+		t.Fatalf("errors.As(err, Timeout) = false, want true")
+	}
+	if !to.Timeout() {
+		t.Errorf("Timeout() = false, want true")
+	}
+}
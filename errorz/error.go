@@ -27,8 +27,6 @@ package errorz
 
 import (
 	"errors"
-	"fmt"
-	"strings"
 )
 
 // DefaultSourceSystem is the default value used for the SourceSystem field
@@ -45,8 +43,10 @@ var DefaultSourceSystem = "application"
 //   - SourceSystem: The system or service that generated the error
 //   - Err: The underlying error that was wrapped (if any)
 //   - Meta: Arbitrary key-value metadata for additional context
+//   - HTTPStatus: An optional explicit HTTP status code
 //
 // All With* methods return the receiver to enable method chaining.
+// Use Clone to derive an independent copy from a shared instance.
 type Error struct {
 	// Code is a machine-readable error code that can be used for
 	// programmatic error handling and logging.
@@ -62,40 +62,41 @@ type Error struct {
 
 	// Err is the underlying error that was wrapped, if any.
 	// This field is set when using Wrap() and can be accessed via Unwrap().
+	// When using WrapAll(), Err holds the errors.Join of all causes.
 	Err error
 
+	// Errs holds the individual causes when the error was created via WrapAll().
+	// It is nil for errors created via New() or Wrap().
+	Errs []error
+
 	// Meta contains arbitrary key-value metadata that provides additional
 	// context about the error. Common use cases include request IDs, user IDs,
 	// timestamps, or other contextual information.
 	Meta map[string]any
+
+	// HTTPStatus is an optional explicit HTTP status code for the error.
+	// When zero, StatusCode falls back to the default mapping for Code.
+	HTTPStatus int
+
+	// Retryable optionally forces the retry classification returned by IsRetryable.
+	// When nil, the classification is derived from the error code and wrapped sentinels.
+	Retryable *bool
+
+	// timeout is reported by Timeout(); set via WithTimeout.
+	timeout bool
+
+	// lang is the default language of LocalizedMessage; set via WithLang.
+	lang string
 }
 
 // Error returns a string representation of the error.
-// The string includes the error code, source system, message, metadata, and original error.
-// The string is formatted as:
-// "Code: <code>, SourceSystem: <sourceSystem>, Message: <message>, Meta: <meta>, Original Error: <originalError>"
-// If the error code, source system, message, or metadata is not set, it is not included in the string.
-// If the original error is not set, it is not included in the string.
+// When a formatter has been installed via SetFormatter, its output is returned.
+// Otherwise the string is produced by DefaultFormatter.
 func (e *Error) Error() string {
-	var messageList []string
-
-	if e.Code != "" {
-		messageList = append(messageList, fmt.Sprintf("Code: %s", e.Code))
-	}
-	if e.SourceSystem != "" {
-		messageList = append(messageList, fmt.Sprintf("SourceSystem: %s", e.SourceSystem))
-	}
-	if e.Message != "" {
-		messageList = append(messageList, fmt.Sprintf("Message: %s", e.Message))
-	}
-	if len(e.Meta) > 0 {
-		messageList = append(messageList, fmt.Sprintf("Meta: %v", e.Meta))
+	if f := formatter.Load(); f != nil {
+		return (*f)(e)
 	}
-	if e.Err != nil {
-		messageList = append(messageList, fmt.Sprintf("Original Error: %v", e.Err.Error()))
-	}
-
-	return strings.Join(messageList, ", ")
+	return DefaultFormatter(e)
 }
 
 // Unwrap returns the underlying error that was wrapped, if any.
@@ -128,12 +129,40 @@ func Wrap(err error) *Error {
 	}
 }
 
+// WrapAll wraps multiple errors into a single Error instance, following
+// errors.Join semantics. Nil errors are discarded; if all errors are nil,
+// the resulting Error wraps nothing.
+//
+// The causes are stored in Errs, and Err is set to errors.Join of the causes.
+// Because Err exposes Unwrap() []error, errors.Is and errors.As match against
+// any of the joined errors.
+//
+// Example:
+//
+//	err := errorz.WrapAll(errEmailRequired, errNameTooLong).
+//		WithCode(errorz.CodeBadRequest).
+//		WithMessage("validation failed")
+//	errors.Is(err, errNameTooLong) // true
+func WrapAll(errs ...error) *Error {
+	var causes []error
+	for _, err := range errs {
+		if err != nil {
+			causes = append(causes, err)
+		}
+	}
+	return &Error{
+		Err:          errors.Join(causes...),
+		Errs:         causes,
+		SourceSystem: DefaultSourceSystem,
+	}
+}
+
 // Is checks if the Error wraps an error that matches the target error.
 // This method implements the Is interface defined in the errors package,
 // enabling the use of errors.Is() with Error instances.
 //
 // The method uses errors.Is() to check if the wrapped error (Err) matches
-// the target error, supporting error wrapping chains.
+// the target error, supporting error wrapping chains and errors joined via WrapAll.
 //
 // If the Error does not wrap an error, Is returns false.
 func (e *Error) Is(target error) bool {
@@ -217,6 +246,96 @@ func (e *Error) WithMeta(key string, value any) *Error {
 	return e
 }
 
+// WithMetaMap merges all entries of m into the metadata map and returns the
+// receiver for method chaining. If the Meta map is nil, it is initialized automatically.
+//
+// Existing keys are overwritten by the values in m. A nil or empty m is a no-op.
+//
+// Example:
+//
+//	err := Error.New("operation failed").
+//		WithMetaMap(map[string]any{
+//			"request_id": "abc123",
+//			"user_id":    456,
+//		})
+func (e *Error) WithMetaMap(m map[string]any) *Error {
+	if len(m) == 0 {
+		return e
+	}
+	if e.Meta == nil {
+		e.Meta = make(map[string]any, len(m))
+	}
+	for k, v := range m {
+		e.Meta[k] = v
+	}
+	return e
+}
+
+// Clone returns a new, independent copy of the Error.
+// All scalar fields are copied, the Meta map and Errs slice are copied into new
+// containers, and the wrapped Err is shared (errors are treated as immutable).
+// Metadata values themselves are copied shallowly.
+//
+// The With* methods mutate the receiver, so builder chains that start from an
+// *Error stored in a shared variable should call Clone first to avoid mutating
+// the shared instance across goroutines.
+//
+// Example:
+//
+//	var errQuotaExceeded = errorz.TooManyRequests().WithCode("QUOTA_EXCEEDED")
+//
+//	func check(userID int) error {
+//		return errQuotaExceeded.Clone().WithMeta("user_id", userID)
+//	}
+func (e *Error) Clone() *Error {
+	clone := *e
+	if e.Meta != nil {
+		clone.Meta = make(map[string]any, len(e.Meta))
+		for k, v := range e.Meta {
+			clone.Meta[k] = v
+		}
+	}
+	if e.Errs != nil {
+		clone.Errs = append([]error(nil), e.Errs...)
+	}
+	return &clone
+}
+
+// AsError returns the first *Error in err's chain, as found by errors.As.
+// It returns nil and false when err is nil, contains no *Error, or the *Error
+// found is a nil pointer.
+//
+// Example:
+//
+//	if e, ok := errorz.AsError(err); ok && e.Code == errorz.CodeNotFound {
+//		...
+//	}
+func AsError(err error) (*Error, bool) {
+	var e *Error
+	if !errors.As(err, &e) || e == nil {
+		return nil, false
+	}
+	return e, true
+}
+
+// Code returns the Code of the first *Error in err's chain (see AsError),
+// or "" when there is none.
+func Code(err error) string {
+	if e, ok := AsError(err); ok {
+		return e.Code
+	}
+	return ""
+}
+
+// Meta returns the Meta of the first *Error in err's chain (see AsError),
+// or nil when there is none. The map is not copied.
+func Meta(err error) map[string]any {
+	if e, ok := AsError(err); ok {
+		return e.Meta
+	}
+	return nil
+}
+
 // Default error codes for predefined errors. Use with constructor-returned
 // errors or when building errors with New/Wrap.
 const (
@@ -233,6 +352,9 @@ const (
 	CodePreconditionFailed   = "ERR_PRECONDITION_FAILED"
 	CodePreconditionRequired = "ERR_PRECONDITION_REQUIRED"
 	CodePreconditionNotMet   = "ERR_PRECONDITION_NOT_MET"
+	CodeMethodNotAllowed     = "ERR_METHOD_NOT_ALLOWED"
+	CodeNotAcceptable        = "ERR_NOT_ACCEPTABLE"
+	CodeUnsupportedMediaType = "ERR_UNSUPPORTED_MEDIA_TYPE"
 )
 
 // Sentinel errors for use with errors.Is. Do not call With* on these; use
@@ -252,6 +374,9 @@ var (
 	ErrPreconditionFailed   = sentinelError{code: CodePreconditionFailed, msg: "precondition failed"}
 	ErrPreconditionRequired = sentinelError{code: CodePreconditionRequired, msg: "precondition required"}
 	ErrPreconditionNotMet   = sentinelError{code: CodePreconditionNotMet, msg: "precondition not met"}
+	ErrMethodNotAllowed     = sentinelError{code: CodeMethodNotAllowed, msg: "method not allowed"}
+	ErrNotAcceptable        = sentinelError{code: CodeNotAcceptable, msg: "not acceptable"}
+	ErrUnsupportedMediaType = sentinelError{code: CodeUnsupportedMediaType, msg: "unsupported media type"}
 )
 
 // sentinelError is an error type used as a sentinel for errors.Is checks.
@@ -344,3 +469,27 @@ func PreconditionNotMet() *Error {
 		Err: ErrPreconditionNotMet, SourceSystem: DefaultSourceSystem,
 	}
 }
+
+// MethodNotAllowed returns a new "method not allowed" error (HTTP 405 equivalent).
+func MethodNotAllowed() *Error {
+	return &Error{
+		Code: CodeMethodNotAllowed, Message: "method not allowed",
+		Err: ErrMethodNotAllowed, SourceSystem: DefaultSourceSystem,
+	}
+}
+
+// NotAcceptable returns a new "not acceptable" error (HTTP 406 equivalent).
+func NotAcceptable() *Error {
+	return &Error{
+		Code: CodeNotAcceptable, Message: "not acceptable",
+		Err: ErrNotAcceptable, SourceSystem: DefaultSourceSystem,
+	}
+}
+
+// UnsupportedMediaType returns a new "unsupported media type" error (HTTP 415 equivalent).
+func UnsupportedMediaType() *Error {
+	return &Error{
+		Code: CodeUnsupportedMediaType, Message: "unsupported media type",
+		Err: ErrUnsupportedMediaType, SourceSystem: DefaultSourceSystem,
+	}
+}
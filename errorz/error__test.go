@@ -2,6 +2,8 @@ package errorz
 
 import (
 	"errors"
+	"fmt"
+	"reflect"
 	"testing"
 )
 
@@ -130,6 +132,54 @@ func TestWrap(t *testing.T) {
 	}
 }
 
+type wrapAllTestError struct{ field string }
+
+func (e *wrapAllTestError) Error() string { return "invalid " + e.field }
+
+func TestWrapAll(t *testing.T) {
+	errA := errors.New("error a")
+	errB := &wrapAllTestError{field: "email"}
+	unrelated := errors.New("unrelated")
+
+	got := WrapAll(errA, nil, errB)
+	if len(got.Errs) != 2 {
+		t.Fatalf("WrapAll().Errs length = %v, want 2 (nil discarded)", len(got.Errs))
+	}
+	if got.SourceSystem != DefaultSourceSystem {
+		t.Errorf("WrapAll().SourceSystem = %v, want %v", got.SourceSystem, DefaultSourceSystem)
+	}
+	if !errors.Is(got, errA) {
+		t.Error("errors.Is(WrapAll(), errA) = false, want true")
+	}
+	if !got.Is(errA) {
+		t.Error("WrapAll().Is(errA) = false, want true")
+	}
+	if errors.Is(got, unrelated) {
+		t.Error("errors.Is(WrapAll(), unrelated) = true, want false")
+	}
+	var target *wrapAllTestError
+	if !errors.As(got, &target) || target.field != "email" {
+		t.Errorf("errors.As(WrapAll(), *wrapAllTestError) = %v, want email cause", target)
+	}
+	wantStr := "SourceSystem: application, Original Errors: error a; invalid email"
+	if got.Error() != wantStr {
+		t.Errorf("WrapAll().Error() = %q, want %q", got.Error(), wantStr)
+	}
+}
+
+func TestWrapAll_allNil(t *testing.T) {
+	got := WrapAll(nil, nil)
+	if got.Err != nil {
+		t.Errorf("WrapAll(nil, nil).Err = %v, want nil", got.Err)
+	}
+	if got.Errs != nil {
+		t.Errorf("WrapAll(nil, nil).Errs = %v, want nil", got.Errs)
+	}
+	if got.Error() != "SourceSystem: application" {
+		t.Errorf("WrapAll(nil, nil).Error() = %q", got.Error())
+	}
+}
+
 func TestError_Error(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -493,6 +543,119 @@ func TestError_WithMeta(t *testing.T) {
 	}
 }
 
+func TestError_WithMetaMap(t *testing.T) {
+	tests := []struct {
+		name     string
+		errorz   *Error
+		input    map[string]any
+		wantMeta map[string]any
+	}{
+		{
+			name:     "merges into empty meta",
+			errorz:   New("test"),
+			input:    map[string]any{"key1": "value1", "key2": 2},
+			wantMeta: map[string]any{"key1": "value1", "key2": 2},
+		},
+		{
+			name:     "overwrites existing keys",
+			errorz:   New("test").WithMeta("key1", "old").WithMeta("keep", true),
+			input:    map[string]any{"key1": "new"},
+			wantMeta: map[string]any{"key1": "new", "keep": true},
+		},
+		{
+			name:     "nil map is a no-op",
+			errorz:   New("test").WithMeta("key1", "value1"),
+			input:    nil,
+			wantMeta: map[string]any{"key1": "value1"},
+		},
+		{
+			name:     "nil map on empty meta leaves meta nil",
+			errorz:   New("test"),
+			input:    nil,
+			wantMeta: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.errorz.WithMetaMap(tt.input)
+			if got != tt.errorz {
+				t.Errorf("Error.WithMetaMap() should return same instance for chaining")
+			}
+			if tt.wantMeta == nil {
+				if got.Meta != nil {
+					t.Errorf("Error.WithMetaMap().Meta = %v, want nil", got.Meta)
+				}
+				return
+			}
+			if len(got.Meta) != len(tt.wantMeta) {
+				t.Errorf("Error.WithMetaMap().Meta length = %v, want %v", len(got.Meta), len(tt.wantMeta))
+			}
+			for k, v := range tt.wantMeta {
+				if got.Meta[k] != v {
+					t.Errorf("Error.WithMetaMap().Meta[%v] = %v, want %v", k, got.Meta[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestError_Clone(t *testing.T) {
+	inner := errors.New("inner")
+	original := Wrap(inner).
+		WithCode("ERR001").
+		WithMessage("original").
+		WithSourceSystem("svc").
+		WithHTTPStatus(418).
+		WithMeta("key", "value")
+
+	clone := original.Clone()
+	if clone == original {
+		t.Fatal("Error.Clone() returned the same instance")
+	}
+	if clone.Code != original.Code || clone.Message != original.Message ||
+		clone.SourceSystem != original.SourceSystem || clone.HTTPStatus != original.HTTPStatus {
+		t.Errorf("Error.Clone() = %+v, want fields equal to %+v", clone, original)
+	}
+	if !errors.Is(clone, inner) {
+		t.Error("errors.Is(clone, inner) = false, want true")
+	}
+
+	clone.WithMeta("key", "changed").WithMeta("extra", 1).WithCode("ERR002")
+	if original.Meta["key"] != "value" {
+		t.Errorf("original Meta[key] = %v after mutating clone, want value", original.Meta["key"])
+	}
+	if _, ok := original.Meta["extra"]; ok {
+		t.Error("original Meta has key extra after mutating clone")
+	}
+	if original.Code != "ERR001" {
+		t.Errorf("original Code = %v after mutating clone, want ERR001", original.Code)
+	}
+}
+
+func TestError_Clone_nilMeta(t *testing.T) {
+	original := NotFound()
+	clone := original.Clone().WithMeta("id", 1)
+	if original.Meta != nil {
+		t.Errorf("original Meta = %v after mutating clone, want nil", original.Meta)
+	}
+	if clone.Meta["id"] != 1 {
+		t.Errorf("clone Meta[id] = %v, want 1", clone.Meta["id"])
+	}
+	if !errors.Is(clone, ErrNotFound) {
+		t.Error("errors.Is(clone, ErrNotFound) = false, want true")
+	}
+}
+
+func TestError_Clone_errsIndependent(t *testing.T) {
+	original := WrapAll(errors.New("a"), errors.New("b"))
+	clone := original.Clone()
+	clone.Errs[0] = errors.New("changed")
+	if original.Errs[0].Error() != "a" {
+		t.Errorf("original Errs[0] = %v after mutating clone, want a", original.Errs[0])
+	}
+}
+
 func TestPredefinedErrors(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -567,6 +730,21 @@ func TestPredefinedErrors(t *testing.T) {
 			wantCode: CodePreconditionNotMet, wantMessage: "precondition not met",
 			wantSourceSys: DefaultSourceSystem, sentinel: ErrPreconditionNotMet,
 		},
+		{
+			name: "MethodNotAllowed", err: MethodNotAllowed(),
+			wantCode: CodeMethodNotAllowed, wantMessage: "method not allowed",
+			wantSourceSys: DefaultSourceSystem, sentinel: ErrMethodNotAllowed,
+		},
+		{
+			name: "NotAcceptable", err: NotAcceptable(),
+			wantCode: CodeNotAcceptable, wantMessage: "not acceptable",
+			wantSourceSys: DefaultSourceSystem, sentinel: ErrNotAcceptable,
+		},
+		{
+			name: "UnsupportedMediaType", err: UnsupportedMediaType(),
+			wantCode: CodeUnsupportedMediaType, wantMessage: "unsupported media type",
+			wantSourceSys: DefaultSourceSystem, sentinel: ErrUnsupportedMediaType,
+		},
 	}
 
 	for _, tt := range tests {
@@ -663,3 +841,37 @@ func TestError_MethodChaining(t *testing.T) {
 		})
 	}
 }
+
+func TestAsError_Code_Meta(t *testing.T) {
+	notFound := NotFound().WithMeta("id", 7)
+	var nilErr *Error
+	tests := []struct {
+		name     string
+		err      error
+		want     *Error
+		wantCode string
+		wantMeta map[string]any
+	}{
+		{"nil", nil, nil, "", nil},
+		{"direct", notFound, notFound, CodeNotFound, map[string]any{"id": 7}},
+		{"wrapped with fmt", fmt.Errorf("load user: %w", notFound), notFound, CodeNotFound, map[string]any{"id": 7}},
+		{"joined", errors.Join(errors.New("other"), notFound), notFound, CodeNotFound, map[string]any{"id": 7}},
+		{"plain error", errors.New("plain"), nil, "", nil},
+		{"sentinel", ErrNotFound, nil, "", nil},
+		{"typed nil", nilErr, nil, "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := AsError(tt.err)
+			if got != tt.want || ok != (tt.want != nil) {
+				t.Errorf("AsError() = %v, %v, want %v, %v", got, ok, tt.want, tt.want != nil)
+			}
+			if code := Code(tt.err); code != tt.wantCode {
+				t.Errorf("Code() = %q, want %q", code, tt.wantCode)
+			}
+			if meta := Meta(tt.err); !reflect.DeepEqual(meta, tt.wantMeta) {
+				t.Errorf("Meta() = %v, want %v", meta, tt.wantMeta)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package errorz_test
+
+import (
+	"fmt"
+
+	"github.com/biairmal/go-sdk/errorz"
+)
+
+func ExampleSetFormatter() {
+	defer errorz.SetFormatter(nil)
+
+	errorz.SetFormatter(errorz.CompactFormatter)
+	fmt.Println(errorz.NotFound().Error())
+
+	errorz.SetFormatter(errorz.JSONFormatter)
+	fmt.Println(errorz.NotFound().Error())
+	// Output:
+	// ERR_NOT_FOUND: not found
+	// {"code":"ERR_NOT_FOUND","message":"not found","source_system":"application","cause":"not found"}
+}
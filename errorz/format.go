@@ -0,0 +1,117 @@
+package errorz
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// Formatter renders an Error as the string returned by Error().
+type Formatter func(*Error) string
+
+// formatter holds the formatter installed via SetFormatter; nil means DefaultFormatter.
+var formatter atomic.Pointer[Formatter]
+
+// SetFormatter installs f as the package-wide formatter used by Error().
+// Passing nil restores DefaultFormatter. It is safe to call concurrently
+// with Error(), but is typically called once during application startup.
+//
+// Example:
+//
+//	errorz.SetFormatter(errorz.CompactFormatter)
+//	errorz.NotFound().Error() // "ERR_NOT_FOUND: not found"
+func SetFormatter(f Formatter) {
+	if f == nil {
+		formatter.Store(nil)
+		return
+	}
+	formatter.Store(&f)
+}
+
+// DefaultFormatter renders the error as comma-joined "Key: value" segments:
+// "Code: <code>, SourceSystem: <sourceSystem>, Message: <message>, Meta: <meta>, Original Error: <originalError>"
+// Segments whose value is not set are omitted.
+// Errors created via WrapAll render every cause as "Original Errors: <err1>; <err2>".
+func DefaultFormatter(e *Error) string {
+	var messageList []string
+
+	if e.Code != "" {
+		messageList = append(messageList, fmt.Sprintf("Code: %s", e.Code))
+	}
+	if e.SourceSystem != "" {
+		messageList = append(messageList, fmt.Sprintf("SourceSystem: %s", e.SourceSystem))
+	}
+	if e.Message != "" {
+		messageList = append(messageList, fmt.Sprintf("Message: %s", e.Message))
+	}
+	if len(e.Meta) > 0 {
+		messageList = append(messageList, fmt.Sprintf("Meta: %v", e.Meta))
+	}
+	switch {
+	case len(e.Errs) > 0:
+		messageList = append(messageList, fmt.Sprintf("Original Errors: %s", joinCauses(e.Errs)))
+	case e.Err != nil:
+		messageList = append(messageList, fmt.Sprintf("Original Error: %v", e.Err.Error()))
+	}
+
+	return strings.Join(messageList, ", ")
+}
+
+// CompactFormatter renders the error as "<code>: <message>". When either part
+// is empty only the other is returned; when both are empty the wrapped error's
+// message is used.
+func CompactFormatter(e *Error) string {
+	switch {
+	case e.Code != "" && e.Message != "":
+		return e.Code + ": " + e.Message
+	case e.Code != "":
+		return e.Code
+	case e.Message != "":
+		return e.Message
+	case e.Err != nil:
+		return e.Err.Error()
+	}
+	return ""
+}
+
+// jsonError is the wire shape produced by JSONFormatter.
+type jsonError struct {
+	Code         string         `json:"code,omitempty"`
+	Message      string         `json:"message,omitempty"`
+	SourceSystem string         `json:"source_system,omitempty"`
+	Meta         map[string]any `json:"meta,omitempty"`
+	Cause        string         `json:"cause,omitempty"`
+}
+
+// JSONFormatter renders the error as a single-line JSON object with the keys
+// code, message, source_system, meta, and cause. Empty fields are omitted.
+// If Meta cannot be marshaled, it falls back to DefaultFormatter.
+func JSONFormatter(e *Error) string {
+	payload := jsonError{
+		Code:         e.Code,
+		Message:      e.Message,
+		SourceSystem: e.SourceSystem,
+		Meta:         e.Meta,
+	}
+	switch {
+	case len(e.Errs) > 0:
+		payload.Cause = joinCauses(e.Errs)
+	case e.Err != nil:
+		payload.Cause = e.Err.Error()
+	}
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return DefaultFormatter(e)
+	}
+	return string(b)
+}
+
+// joinCauses renders errs as "<err1>; <err2>".
+func joinCauses(errs []error) string {
+	causes := make([]string, len(errs))
+	for i, err := range errs {
+		causes[i] = err.Error()
+	}
+	return strings.Join(causes, "; ")
+}
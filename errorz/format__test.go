@@ -0,0 +1,90 @@
+package errorz
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSetFormatter(t *testing.T) {
+	t.Cleanup(func() { SetFormatter(nil) })
+
+	err := New("not found").WithCode(CodeNotFound)
+	want := DefaultFormatter(err)
+	if got := err.Error(); got != want {
+		t.Errorf("Error() without formatter = %v, want %v", got, want)
+	}
+
+	SetFormatter(func(e *Error) string { return "custom:" + e.Code })
+	if got := err.Error(); got != "custom:"+CodeNotFound {
+		t.Errorf("Error() with formatter = %v, want %v", got, "custom:"+CodeNotFound)
+	}
+
+	SetFormatter(nil)
+	if got := err.Error(); got != want {
+		t.Errorf("Error() after SetFormatter(nil) = %v, want %v", got, want)
+	}
+}
+
+func TestCompactFormatter(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *Error
+		want string
+	}{
+		{"code and message", New("not found").WithCode(CodeNotFound), "ERR_NOT_FOUND: not found"},
+		{"code only", &Error{Code: CodeNotFound}, "ERR_NOT_FOUND"},
+		{"message only", New("boom"), "boom"},
+		{"wrapped only", Wrap(errors.New("cause")), "cause"},
+		{"empty", &Error{}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CompactFormatter(tt.err); got != tt.want {
+				t.Errorf("CompactFormatter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONFormatter(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *Error
+		want string
+	}{
+		{
+			name: "all fields",
+			err: Wrap(errors.New("db down")).WithCode("ERR_DB").WithMessage("failed").
+				WithSourceSystem("svc").WithMeta("id", 1),
+			want: `{"code":"ERR_DB","message":"failed","source_system":"svc","meta":{"id":1},"cause":"db down"}`,
+		},
+		{
+			name: "joined causes",
+			err:  &Error{Errs: []error{errors.New("a"), errors.New("b")}},
+			want: `{"cause":"a; b"}`,
+		},
+		{
+			name: "empty",
+			err:  &Error{},
+			want: `{}`,
+		},
+		{
+			name: "unmarshalable meta falls back to default",
+			err:  (&Error{Code: "X"}).WithMeta("ch", make(chan int)),
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := tt.want
+			if want == "" {
+				want = DefaultFormatter(tt.err)
+			}
+			if got := JSONFormatter(tt.err); got != want {
+				t.Errorf("JSONFormatter() = %v, want %v", got, want)
+			}
+		})
+	}
+}
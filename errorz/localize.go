@@ -0,0 +1,117 @@
+package errorz
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// MessageCatalog maps an error code to its message templates keyed by language
+// tag, e.g. catalog[CodeNotFound]["id"] = "sumber daya {resource_id} tidak ditemukan".
+// Templates may reference Meta values as {key}.
+type MessageCatalog map[string]map[string]string
+
+// catalog holds the catalog installed via SetMessageCatalog; nil means none.
+var catalog atomic.Pointer[MessageCatalog]
+
+// SetMessageCatalog installs c as the package-wide catalog used by
+// LocalizedMessage. Passing nil removes it. It is safe to call concurrently
+// with LocalizedMessage, but is typically called once during application startup.
+// Do not modify c after installing it.
+//
+// Example:
+//
+//	errorz.SetMessageCatalog(errorz.MessageCatalog{
+//		errorz.CodeNotFound: {
+//			"en": "resource {resource_id} not found",
+//			"id": "sumber daya {resource_id} tidak ditemukan",
+//		},
+//	})
+func SetMessageCatalog(c MessageCatalog) {
+	if c == nil {
+		catalog.Store(nil)
+		return
+	}
+	catalog.Store(&c)
+}
+
+// Template returns the template for code in lang. When lang has no entry, its
+// base language is tried ("pt" for "pt-BR").
+func (c MessageCatalog) Template(code, lang string) (string, bool) {
+	templates, ok := c[code]
+	if !ok || lang == "" {
+		return "", false
+	}
+	if tmpl, ok := templates[lang]; ok {
+		return tmpl, true
+	}
+	if base, _, ok := strings.Cut(lang, "-"); ok {
+		tmpl, ok := templates[base]
+		return tmpl, ok
+	}
+	return "", false
+}
+
+// WithLang sets the language tag used by LocalizedMessage when it is called
+// with an empty lang, and returns the receiver for method chaining. It does not
+// change Message or the output of Error().
+func (e *Error) WithLang(tag string) *Error {
+	e.lang = tag
+	return e
+}
+
+// Lang returns the language tag set via WithLang, or "".
+func (e *Error) Lang() string {
+	return e.lang
+}
+
+// LocalizedMessage renders the message for lang from the catalog installed via
+// SetMessageCatalog, replacing each {key} with the Meta value for key; unknown
+// keys are left as written. An empty lang uses the tag set via WithLang. When no
+// language is given, no catalog is installed, or it has no template for the
+// error's code and language, Message is returned.
+//
+// Example:
+//
+//	err := errorz.NotFound().WithMeta("resource_id", 42)
+//	err.LocalizedMessage("id") // "sumber daya 42 tidak ditemukan"
+//	err.LocalizedMessage("fr") // "not found"
+func (e *Error) LocalizedMessage(lang string) string {
+	if lang == "" {
+		lang = e.lang
+	}
+	c := catalog.Load()
+	if c == nil {
+		return e.Message
+	}
+	tmpl, ok := c.Template(e.Code, lang)
+	if !ok {
+		return e.Message
+	}
+	return renderTemplate(tmpl, e.Meta)
+}
+
+// renderTemplate replaces {key} in tmpl with fmt.Sprint(meta[key]) for keys present in meta.
+func renderTemplate(tmpl string, meta map[string]any) string {
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(tmpl, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(tmpl[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+		b.WriteString(tmpl[:start])
+		if v, ok := meta[tmpl[start+1:end]]; ok {
+			b.WriteString(fmt.Sprint(v))
+		} else {
+			b.WriteString(tmpl[start : end+1])
+		}
+		tmpl = tmpl[end+1:]
+	}
+	b.WriteString(tmpl)
+	return b.String()
+}
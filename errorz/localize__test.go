@@ -0,0 +1,53 @@
+package errorz
+
+import "testing"
+
+func TestLocalizedMessage(t *testing.T) {
+	SetMessageCatalog(MessageCatalog{
+		CodeNotFound: {
+			"en": "{resource} {resource_id} not found",
+			"id": "{resource} {resource_id} tidak ditemukan",
+			"pt": "{resource} {resource_id} não encontrado",
+		},
+	})
+	t.Cleanup(func() { SetMessageCatalog(nil) })
+
+	err := NotFound().WithMeta("resource", "order").WithMeta("resource_id", 42)
+	tests := []struct {
+		name string
+		err  *Error
+		lang string
+		want string
+	}{
+		{"english", err, "en", "order 42 not found"},
+		{"indonesian", err, "id", "order 42 tidak ditemukan"},
+		{"base language", err, "pt-BR", "order 42 não encontrado"},
+		{"lang from WithLang", err.Clone().WithLang("id"), "", "order 42 tidak ditemukan"},
+		{"argument overrides WithLang", err.Clone().WithLang("id"), "en", "order 42 not found"},
+		{"unknown language", err, "fr", "not found"},
+		{"no language", err, "", "not found"},
+		{"code not in catalog", BadRequest(), "id", "bad request"},
+		{"missing meta left as is", NotFound().WithMeta("resource", "user"), "en", "user {resource_id} not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.LocalizedMessage(tt.lang); got != tt.want {
+				t.Errorf("LocalizedMessage(%q) = %q, want %q", tt.lang, got, tt.want)
+			}
+		})
+	}
+
+	if got := err.Error(); got != DefaultFormatter(err) {
+		t.Errorf("Error() = %q, want the unlocalized DefaultFormatter output", got)
+	}
+}
+
+func TestLocalizedMessage_noCatalog(t *testing.T) {
+	err := NotFound().WithLang("id")
+	if got := err.LocalizedMessage(""); got != "not found" {
+		t.Errorf("LocalizedMessage() without catalog = %q, want %q", got, "not found")
+	}
+	if got := err.Lang(); got != "id" {
+		t.Errorf("Lang() = %q, want id", got)
+	}
+}
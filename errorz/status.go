@@ -0,0 +1,52 @@
+package errorz
+
+import "net/http"
+
+// defaultCodeToStatus maps the predefined error codes to their HTTP status equivalents.
+// It is used by StatusCode when no explicit HTTPStatus is set on the error.
+var defaultCodeToStatus = map[string]int{
+	CodeNotFound:             http.StatusNotFound,
+	CodeBadRequest:           http.StatusBadRequest,
+	CodeInternal:             http.StatusInternalServerError,
+	CodeUnauthorized:         http.StatusUnauthorized,
+	CodeForbidden:            http.StatusForbidden,
+	CodeTooManyRequests:      http.StatusTooManyRequests,
+	CodeBadGateway:           http.StatusBadGateway,
+	CodeServiceUnavailable:   http.StatusServiceUnavailable,
+	CodeUnprocessableEntity:  http.StatusUnprocessableEntity,
+	CodeConflict:             http.StatusConflict,
+	CodePreconditionFailed:   http.StatusPreconditionFailed,
+	CodePreconditionRequired: http.StatusPreconditionRequired,
+	CodePreconditionNotMet:   http.StatusPreconditionFailed,
+	CodeMethodNotAllowed:     http.StatusMethodNotAllowed,
+	CodeNotAcceptable:        http.StatusNotAcceptable,
+	CodeUnsupportedMediaType: http.StatusUnsupportedMediaType,
+}
+
+// WithHTTPStatus sets an explicit HTTP status code and returns the receiver
+// for method chaining. The explicit status takes precedence over the default
+// mapping derived from Code, which allows custom codes to carry their own status.
+//
+// Example:
+//
+//	err := errorz.New("short and stout").
+//		WithCode("ERR_TEAPOT").
+//		WithHTTPStatus(http.StatusTeapot)
+func (e *Error) WithHTTPStatus(status int) *Error {
+	e.HTTPStatus = status
+	return e
+}
+
+// StatusCode returns the HTTP status code for the error.
+// If HTTPStatus is set, it is returned as-is. Otherwise Code is looked up in the
+// default code map (e.g. CodeNotFound → 404). Unknown or empty codes yield
+// http.StatusInternalServerError.
+func (e *Error) StatusCode() int {
+	if e.HTTPStatus != 0 {
+		return e.HTTPStatus
+	}
+	if status, ok := defaultCodeToStatus[e.Code]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,79 @@
+package errorz
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestError_WithHTTPStatus(t *testing.T) {
+	err := New("test")
+	got := err.WithHTTPStatus(http.StatusTeapot)
+	if got != err {
+		t.Errorf("Error.WithHTTPStatus() should return same instance for chaining")
+	}
+	if got.HTTPStatus != http.StatusTeapot {
+		t.Errorf("Error.WithHTTPStatus().HTTPStatus = %v, want %v", got.HTTPStatus, http.StatusTeapot)
+	}
+}
+
+func TestError_StatusCode(t *testing.T) {
+	tests := []struct {
+		name   string
+		errorz *Error
+		want   int
+	}{
+		{
+			name:   "predefined code uses default map",
+			errorz: NotFound(),
+			want:   http.StatusNotFound,
+		},
+		{
+			name:   "precondition not met maps to 412",
+			errorz: PreconditionNotMet(),
+			want:   http.StatusPreconditionFailed,
+		},
+		{
+			name:   "method not allowed maps to 405",
+			errorz: MethodNotAllowed(),
+			want:   http.StatusMethodNotAllowed,
+		},
+		{
+			name:   "not acceptable maps to 406",
+			errorz: NotAcceptable(),
+			want:   http.StatusNotAcceptable,
+		},
+		{
+			name:   "unsupported media type maps to 415",
+			errorz: UnsupportedMediaType(),
+			want:   http.StatusUnsupportedMediaType,
+		},
+		{
+			name:   "explicit status overrides default map",
+			errorz: NotFound().WithHTTPStatus(http.StatusGone),
+			want:   http.StatusGone,
+		},
+		{
+			name:   "custom code with explicit status",
+			errorz: New("teapot").WithCode("ERR_TEAPOT").WithHTTPStatus(http.StatusTeapot),
+			want:   http.StatusTeapot,
+		},
+		{
+			name:   "custom code without explicit status",
+			errorz: New("custom").WithCode("ERR_CUSTOM"),
+			want:   http.StatusInternalServerError,
+		},
+		{
+			name:   "empty code",
+			errorz: New("no code"),
+			want:   http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.errorz.StatusCode(); got != tt.want {
+				t.Errorf("Error.StatusCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
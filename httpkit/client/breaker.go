@@ -0,0 +1,279 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/biairmal/go-sdk/errorz"
+)
+
+// BreakerState is the state of the circuit breaker for one host.
+type BreakerState int
+
+const (
+	BreakerClosed   BreakerState = iota // Requests are sent and their outcomes counted
+	BreakerOpen                         // Requests are rejected until OpenTimeout has passed
+	BreakerHalfOpen                     // A limited number of probe requests decide whether to close
+)
+
+// String returns "closed", "open" or "half-open".
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("BreakerState(%d)", int(s))
+	}
+}
+
+// ErrCircuitOpen is matched by errors.Is on the error Do returns when the
+// breaker rejects a request. The error is an *errorz.Error built from
+// errorz.ServiceUnavailable, so errors.Is(err, errorz.ErrServiceUnavailable) matches too.
+var ErrCircuitOpen = errors.New("client: circuit breaker open")
+
+// BreakerConfig configures NewCircuitBreaker. Zero values take the documented defaults.
+type BreakerConfig struct {
+	FailureRatio     float64       // Failed fraction of the requests in Window that trips the breaker (default 0.5)
+	MinRequests      int           // Requests needed in Window before FailureRatio is checked (default 10)
+	Window           time.Duration // Length of the counting window while closed (default 10s)
+	OpenTimeout      time.Duration // Time spent open before probes are let through (default 30s)
+	HalfOpenRequests int           // Probes allowed while half-open; all must succeed to close (default 1)
+	// IsFailure reports whether an attempt counts as a failure. Nil counts
+	// transport errors (except context.Canceled) and statuses >= 500.
+	IsFailure func(resp *http.Response, err error) bool
+	// OnStateChange, when set, is called after a host's breaker changes state,
+	// e.g. to log or export the transition. It is called without holding the
+	// breaker's lock.
+	OnStateChange func(host string, from, to BreakerState)
+}
+
+// BreakerStats is a snapshot of the breaker for one host.
+type BreakerStats struct {
+	State    BreakerState
+	Since    time.Time // When State was entered
+	Requests int       // Requests counted in the current window (closed) or probes sent (half-open)
+	Failures int       // Failures among Requests
+	Rejected uint64    // Requests short-circuited since the breaker was created
+	Trips    uint64    // Transitions to open since the breaker was created
+}
+
+// CircuitBreaker tracks request outcomes per host (the request URL's host,
+// including the port) and rejects requests to hosts that keep failing. Create
+// it with NewCircuitBreaker and set it as Client.Breaker; one breaker may be
+// shared by several clients. It is safe for concurrent use.
+type CircuitBreaker struct {
+	cfg BreakerConfig
+	now func() time.Time
+
+	mu      sync.Mutex
+	hosts   map[string]*hostCircuit
+	changes []stateChange // transitions to report once mu is released
+}
+
+// hostCircuit is the breaker state of one host.
+type hostCircuit struct {
+	stats       BreakerStats
+	windowStart time.Time
+	inFlight    int    // probes not yet finished while half-open
+	generation  uint64 // bumped on every transition so stale outcomes are ignored
+}
+
+type stateChange struct {
+	host     string
+	from, to BreakerState
+}
+
+// NewCircuitBreaker returns a CircuitBreaker with cfg, filling in defaults for zero fields.
+func NewCircuitBreaker(cfg BreakerConfig) *CircuitBreaker {
+	if cfg.FailureRatio <= 0 {
+		cfg.FailureRatio = 0.5
+	}
+	if cfg.MinRequests <= 0 {
+		cfg.MinRequests = 10
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = 10 * time.Second
+	}
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = 30 * time.Second
+	}
+	if cfg.HalfOpenRequests <= 0 {
+		cfg.HalfOpenRequests = 1
+	}
+	if cfg.IsFailure == nil {
+		cfg.IsFailure = defaultIsFailure
+	}
+	return &CircuitBreaker{cfg: cfg, now: time.Now, hosts: make(map[string]*hostCircuit)}
+}
+
+// State returns the current state of host's breaker; unknown hosts are closed.
+func (b *CircuitBreaker) State(host string) BreakerState {
+	b.mu.Lock()
+	defer b.unlock()
+	h, ok := b.hosts[host]
+	if !ok {
+		return BreakerClosed
+	}
+	b.advance(host, h, b.now())
+	return h.stats.State
+}
+
+// Stats returns a snapshot of every host the breaker has seen.
+func (b *CircuitBreaker) Stats() map[string]BreakerStats {
+	b.mu.Lock()
+	defer b.unlock()
+	now := b.now()
+	stats := make(map[string]BreakerStats, len(b.hosts))
+	for host, h := range b.hosts {
+		b.advance(host, h, now)
+		stats[host] = h.stats
+	}
+	return stats
+}
+
+// allow reserves an attempt to host. It returns the function that records the
+// attempt's outcome, or an error wrapping ErrCircuitOpen when the attempt is rejected.
+func (b *CircuitBreaker) allow(host string) (func(resp *http.Response, err error), error) {
+	b.mu.Lock()
+	defer b.unlock()
+	now := b.now()
+	h, ok := b.hosts[host]
+	if !ok {
+		h = &hostCircuit{stats: BreakerStats{Since: now}, windowStart: now}
+		b.hosts[host] = h
+	}
+	b.advance(host, h, now)
+	switch h.stats.State {
+	case BreakerOpen:
+		h.stats.Rejected++
+		return nil, circuitOpenError(host, h.stats.State)
+	case BreakerHalfOpen:
+		if h.inFlight >= b.cfg.HalfOpenRequests {
+			h.stats.Rejected++
+			return nil, circuitOpenError(host, h.stats.State)
+		}
+		h.inFlight++
+	}
+	generation := h.generation
+	return func(resp *http.Response, err error) {
+		b.record(host, generation, b.cfg.IsFailure(resp, err))
+	}, nil
+}
+
+// open reports whether host's breaker is open, so retries can stop early.
+func (b *CircuitBreaker) open(host string) bool {
+	return b != nil && b.State(host) == BreakerOpen
+}
+
+// record counts the outcome of an attempt reserved in generation.
+func (b *CircuitBreaker) record(host string, generation uint64, failed bool) {
+	b.mu.Lock()
+	defer b.unlock()
+	h := b.hosts[host]
+	if h.generation != generation {
+		return
+	}
+	now := b.now()
+	h.stats.Requests++
+	if failed {
+		h.stats.Failures++
+	}
+	switch h.stats.State {
+	case BreakerClosed:
+		if h.stats.Requests >= b.cfg.MinRequests &&
+			float64(h.stats.Failures) >= b.cfg.FailureRatio*float64(h.stats.Requests) {
+			b.transition(host, h, BreakerOpen, now)
+		}
+	case BreakerHalfOpen:
+		h.inFlight--
+		if failed {
+			b.transition(host, h, BreakerOpen, now)
+		} else if h.stats.Requests >= b.cfg.HalfOpenRequests {
+			b.transition(host, h, BreakerClosed, now)
+		}
+	}
+}
+
+// advance moves an open breaker to half-open once OpenTimeout has passed and
+// starts a new counting window when the closed window has expired.
+func (b *CircuitBreaker) advance(host string, h *hostCircuit, now time.Time) {
+	switch h.stats.State {
+	case BreakerOpen:
+		if now.Sub(h.stats.Since) >= b.cfg.OpenTimeout {
+			b.transition(host, h, BreakerHalfOpen, now)
+		}
+	case BreakerClosed:
+		if now.Sub(h.windowStart) >= b.cfg.Window {
+			h.windowStart = now
+			h.stats.Requests, h.stats.Failures = 0, 0
+		}
+	}
+}
+
+// transition sets h's state to "to" and queues the change for OnStateChange.
+func (b *CircuitBreaker) transition(host string, h *hostCircuit, to BreakerState, now time.Time) {
+	from := h.stats.State
+	h.stats.State = to
+	h.stats.Since = now
+	h.stats.Requests, h.stats.Failures = 0, 0
+	h.windowStart = now
+	h.inFlight = 0
+	h.generation++
+	if to == BreakerOpen {
+		h.stats.Trips++
+	}
+	b.changes = append(b.changes, stateChange{host: host, from: from, to: to})
+}
+
+// unlock releases mu and reports the queued state changes.
+func (b *CircuitBreaker) unlock() {
+	changes := b.changes
+	b.changes = nil
+	b.mu.Unlock()
+	if b.cfg.OnStateChange == nil {
+		return
+	}
+	for _, c := range changes {
+		b.cfg.OnStateChange(c.host, c.from, c.to)
+	}
+}
+
+// defaultIsFailure counts transport errors (except cancellation) and 5xx responses.
+func defaultIsFailure(resp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.Canceled)
+	}
+	return resp.StatusCode >= http.StatusInternalServerError
+}
+
+// circuitOpenError returns the errorz.ServiceUnavailable error for a rejected request.
+func circuitOpenError(host string, state BreakerState) *errorz.Error {
+	err := errorz.ServiceUnavailable().
+		WithMessage("circuit breaker open").
+		WithMeta("host", host).
+		WithMeta("state", state.String())
+	err.Err = fmt.Errorf("%w: %w", ErrCircuitOpen, err.Err)
+	return err
+}
+
+// attempt performs one attempt of req through the interceptors, guarded by c.Breaker.
+func (c *Client) attempt(req *http.Request) (*http.Response, error) {
+	if c.Breaker == nil {
+		return c.roundTrip(req)
+	}
+	done, err := c.Breaker.allow(req.URL.Host)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.roundTrip(req)
+	done(resp, err)
+	return resp, err
+}
@@ -0,0 +1,230 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/biairmal/go-sdk/errorz"
+)
+
+// statusServer answers every request with the current value of status.
+func statusServer(t *testing.T, status *atomic.Int32) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(int(status.Load()))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+// breakerClient returns a client for srv with a breaker whose clock is *now.
+func breakerClient(srv *httptest.Server, cfg BreakerConfig, now *time.Time) *Client {
+	c := New(srv.Client())
+	c.Breaker = NewCircuitBreaker(cfg)
+	c.Breaker.now = func() time.Time { return *now }
+	return c
+}
+
+func TestCircuitBreaker_tripsAndRecovers(t *testing.T) {
+	var status atomic.Int32
+	status.Store(http.StatusInternalServerError)
+	srv, calls := statusServer(t, &status)
+	host := mustHost(t, srv.URL)
+
+	now := time.Unix(0, 0)
+	var changes []string
+	c := breakerClient(srv, BreakerConfig{
+		FailureRatio: 0.5,
+		MinRequests:  4,
+		OpenTimeout:  time.Minute,
+		OnStateChange: func(h string, from, to BreakerState) {
+			if h == host {
+				changes = append(changes, from.String()+"->"+to.String())
+			}
+		},
+	}, &now)
+	ctx := context.Background()
+
+	for i := range 4 {
+		if _, _, _, err := Get[string](ctx, c, srv.URL); err != nil {
+			t.Fatalf("Get() #%d error = %v", i, err)
+		}
+	}
+	if got := c.Breaker.State(host); got != BreakerOpen {
+		t.Fatalf("State() after failures = %v, want open", got)
+	}
+
+	_, statusCode, _, err := Get[string](ctx, c, srv.URL)
+	if !errors.Is(err, ErrCircuitOpen) || !errors.Is(err, errorz.ErrServiceUnavailable) {
+		t.Fatalf("Get() while open error = %v, want ErrCircuitOpen and ErrServiceUnavailable", err)
+	}
+	if errorz.Code(err) != errorz.CodeServiceUnavailable || statusCode != 0 {
+		t.Errorf("Get() while open = %q, %d, want %q, 0", errorz.Code(err), statusCode, errorz.CodeServiceUnavailable)
+	}
+	if calls.Load() != 4 {
+		t.Errorf("server calls = %d, want 4 (open breaker must not send)", calls.Load())
+	}
+
+	now = now.Add(time.Minute)
+	if got := c.Breaker.State(host); got != BreakerHalfOpen {
+		t.Fatalf("State() after OpenTimeout = %v, want half-open", got)
+	}
+	status.Store(http.StatusOK)
+	if _, _, _, err := Get[string](ctx, c, srv.URL); err != nil {
+		t.Fatalf("Get() probe error = %v", err)
+	}
+	if got := c.Breaker.State(host); got != BreakerClosed {
+		t.Errorf("State() after successful probe = %v, want closed", got)
+	}
+
+	want := []string{"closed->open", "open->half-open", "half-open->closed"}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("OnStateChange = %v, want %v", changes, want)
+	}
+	stats := c.Breaker.Stats()[host]
+	if stats.Trips != 1 || stats.Rejected != 1 || stats.State != BreakerClosed || !stats.Since.Equal(now) {
+		t.Errorf("Stats() = %+v, want 1 trip, 1 rejected, closed since %v", stats, now)
+	}
+}
+
+func TestCircuitBreaker_halfOpenFailureReopens(t *testing.T) {
+	var status atomic.Int32
+	status.Store(http.StatusBadGateway)
+	srv, calls := statusServer(t, &status)
+	host := mustHost(t, srv.URL)
+
+	now := time.Unix(0, 0)
+	c := breakerClient(srv, BreakerConfig{MinRequests: 1, OpenTimeout: time.Second}, &now)
+	ctx := context.Background()
+
+	_, _, _, _ = Get[string](ctx, c, srv.URL)
+	now = now.Add(time.Second)
+	_, _, _, _ = Get[string](ctx, c, srv.URL) // failed probe
+	if got := c.Breaker.State(host); got != BreakerOpen {
+		t.Fatalf("State() after failed probe = %v, want open", got)
+	}
+	if _, _, _, err := Get[string](ctx, c, srv.URL); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Get() after failed probe error = %v, want ErrCircuitOpen", err)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("server calls = %d, want 2", calls.Load())
+	}
+	if stats := c.Breaker.Stats()[host]; stats.Trips != 2 {
+		t.Errorf("Stats().Trips = %d, want 2", stats.Trips)
+	}
+}
+
+func TestCircuitBreaker_ratioAndWindow(t *testing.T) {
+	var status atomic.Int32
+	srv, _ := statusServer(t, &status)
+	host := mustHost(t, srv.URL)
+
+	now := time.Unix(0, 0)
+	c := breakerClient(srv, BreakerConfig{FailureRatio: 0.5, MinRequests: 4, Window: time.Minute}, &now)
+	ctx := context.Background()
+	send := func(code int) {
+		status.Store(int32(code))
+		_, _, _, _ = Get[string](ctx, c, srv.URL)
+	}
+
+	// 1 of 4 failed: below the ratio.
+	send(http.StatusOK)
+	send(http.StatusOK)
+	send(http.StatusNotFound) // 4xx is not a failure
+	send(http.StatusServiceUnavailable)
+	if got := c.Breaker.State(host); got != BreakerClosed {
+		t.Fatalf("State() at 1/4 failures = %v, want closed", got)
+	}
+
+	// A new window forgets the earlier outcomes.
+	now = now.Add(time.Minute)
+	send(http.StatusServiceUnavailable)
+	send(http.StatusServiceUnavailable)
+	send(http.StatusServiceUnavailable)
+	if got := c.Breaker.Stats()[host]; got.State != BreakerClosed || got.Requests != 3 || got.Failures != 3 {
+		t.Fatalf("Stats() below MinRequests = %+v, want closed with 3/3", got)
+	}
+	send(http.StatusOK)
+	if got := c.Breaker.State(host); got != BreakerOpen {
+		t.Errorf("State() at 3/4 failures = %v, want open", got)
+	}
+}
+
+func TestCircuitBreaker_perHost(t *testing.T) {
+	var failing, healthy atomic.Int32
+	failing.Store(http.StatusInternalServerError)
+	healthy.Store(http.StatusOK)
+	bad, _ := statusServer(t, &failing)
+	good, goodCalls := statusServer(t, &healthy)
+
+	c := New(nil)
+	c.Breaker = NewCircuitBreaker(BreakerConfig{MinRequests: 1})
+	ctx := context.Background()
+
+	_, _, _, _ = Get[string](ctx, c, bad.URL)
+	if _, _, _, err := Get[string](ctx, c, bad.URL); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Get(bad) error = %v, want ErrCircuitOpen", err)
+	}
+	if _, status, _, err := Get[string](ctx, c, good.URL); err != nil || status != http.StatusOK {
+		t.Errorf("Get(good) = %d, %v, want 200, nil", status, err)
+	}
+	if goodCalls.Load() != 1 {
+		t.Errorf("good server calls = %d, want 1", goodCalls.Load())
+	}
+}
+
+func TestDo_retryStopsWhenBreakerOpens(t *testing.T) {
+	srv, calls := flakyServer(t, 10, http.StatusServiceUnavailable, nil)
+	c := retryClient(srv, RetryConfig{MaxAttempts: 5, InitialDelay: time.Millisecond})
+	c.Breaker = NewCircuitBreaker(BreakerConfig{MinRequests: 2})
+
+	_, status, _, err := Get[string](context.Background(), c, srv.URL)
+	if err != nil || status != http.StatusServiceUnavailable {
+		t.Errorf("Get() = %d, %v, want the response that tripped the breaker (503, nil)", status, err)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("attempts = %d, want 2 (retries stop once the breaker opens)", calls.Load())
+	}
+
+	if _, _, _, err := Get[string](context.Background(), c, srv.URL); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Get() after trip error = %v, want ErrCircuitOpen", err)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("attempts after trip = %d, want 2 (rejections are not retried)", calls.Load())
+	}
+}
+
+func TestBreakerState_String(t *testing.T) {
+	tests := []struct {
+		state BreakerState
+		want  string
+	}{
+		{BreakerClosed, "closed"},
+		{BreakerOpen, "open"},
+		{BreakerHalfOpen, "half-open"},
+		{BreakerState(7), "BreakerState(7)"},
+	}
+	for _, tt := range tests {
+		if got := tt.state.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func mustHost(t *testing.T, rawURL string) string {
+	t.Helper()
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u.Host
+}
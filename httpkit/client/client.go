@@ -12,10 +12,27 @@ import (
 	"github.com/biairmal/go-sdk/httpkit/response"
 )
 
-// Client wraps *http.Client and provides Do, Get, and Post helpers
+// Client wraps *http.Client and provides Do, Get, Post, Put, Patch, and Delete helpers
 // that decode the response body into response.BaseResponse[T].
 type Client struct {
 	HTTPClient *http.Client
+	// BaseURL is prepended to relative request URLs: "/users/1" with BaseURL
+	// "http://users/api" is sent to "http://users/api/users/1". Absolute URLs
+	// are sent unchanged.
+	BaseURL string
+	// DefaultHeaders are added to every request that does not already set the
+	// same header; per-request headers always win.
+	DefaultHeaders http.Header
+	// MapErrors makes Do return the *errorz.Error built by ErrorFromResponse
+	// when the status is >= 400 or the envelope's Error field is set.
+	MapErrors bool
+	// Retry enables retries in Do; nil (the default) sends each request once.
+	Retry *RetryConfig
+	// Breaker rejects requests to failing hosts with errorz.ServiceUnavailable
+	// (see CircuitBreaker); nil (the default) disables it.
+	Breaker *CircuitBreaker
+
+	interceptors []Interceptor
 }
 
 // New returns a Client using the given *http.Client.
@@ -31,14 +48,24 @@ func New(c *http.Client) *Client {
 // The returned status code and body are from the HTTP response.
 // If the response body is not valid JSON or does not match BaseResponse[T],
 // Result is zero and Err may be set (caller can still use RawBody or StatusCode).
+// With c.MapErrors, error responses are returned as *errorz.Error (see
+// ErrorFromResponse); a status >= 400 is reported even when the body is not JSON.
+// With c.Retry, retryable failures are retried before the final response is decoded.
+// With c.Breaker, every attempt is counted per host and requests to a host whose
+// breaker is open fail with an error wrapping ErrCircuitOpen without being sent.
+// Every attempt passes through the interceptors registered with c.Use.
+// c.BaseURL and c.DefaultHeaders are applied to a copy of req; req is not modified.
 func Do[T any](ctx context.Context, c *Client, req *http.Request) (
 	result response.BaseResponse[T], statusCode int, rawBody []byte, err error,
 ) {
 	if c == nil {
 		c = New(nil)
 	}
-	req = req.WithContext(ctx)
-	resp, err := c.HTTPClient.Do(req)
+	req, err = c.prepare(ctx, req)
+	if err != nil {
+		return result, 0, nil, err
+	}
+	resp, err := c.send(req)
 	if err != nil {
 		return result, 0, nil, err
 	}
@@ -48,16 +75,17 @@ func Do[T any](ctx context.Context, c *Client, req *http.Request) (
 		return result, resp.StatusCode, rawBody, err
 	}
 	statusCode = resp.StatusCode
-	if len(rawBody) == 0 {
-		return result, statusCode, rawBody, nil
+	if len(rawBody) > 0 {
+		err = json.Unmarshal(rawBody, &result)
 	}
-	if err := json.Unmarshal(rawBody, &result); err != nil {
-		return result, statusCode, rawBody, err
+	if c.MapErrors && (err == nil || statusCode >= http.StatusBadRequest) {
+		err = ErrorFromResponse(result, statusCode)
 	}
-	return result, statusCode, rawBody, nil
+	return result, statusCode, rawBody, err
 }
 
-// Get builds a GET request to url and calls Do.
+// Get builds a GET request to url and calls Do. Like the other helpers, url may
+// be a path relative to c.BaseURL.
 func Get[T any](ctx context.Context, c *Client, url string) (
 	result response.BaseResponse[T], statusCode int, rawBody []byte, err error,
 ) {
@@ -72,6 +100,36 @@ func Get[T any](ctx context.Context, c *Client, url string) (
 // Post builds a POST request to url with body and calls Do.
 func Post[T any](ctx context.Context, c *Client, url string, body any) (
 	result response.BaseResponse[T], statusCode int, rawBody []byte, err error,
+) {
+	return doJSON[T](ctx, c, http.MethodPost, url, body)
+}
+
+// Put builds a PUT request to url with body and calls Do.
+func Put[T any](ctx context.Context, c *Client, url string, body any) (
+	result response.BaseResponse[T], statusCode int, rawBody []byte, err error,
+) {
+	return doJSON[T](ctx, c, http.MethodPut, url, body)
+}
+
+// Patch builds a PATCH request to url with body and calls Do.
+func Patch[T any](ctx context.Context, c *Client, url string, body any) (
+	result response.BaseResponse[T], statusCode int, rawBody []byte, err error,
+) {
+	return doJSON[T](ctx, c, http.MethodPatch, url, body)
+}
+
+// Delete builds a DELETE request to url and calls Do. body is optional: pass nil
+// to send no body, or a value to send it as JSON like Post.
+func Delete[T any](ctx context.Context, c *Client, url string, body any) (
+	result response.BaseResponse[T], statusCode int, rawBody []byte, err error,
+) {
+	return doJSON[T](ctx, c, http.MethodDelete, url, body)
+}
+
+// doJSON builds a method request to url with body encoded as JSON and calls Do.
+// A nil body sends no body and no Content-Type.
+func doJSON[T any](ctx context.Context, c *Client, method, url string, body any) (
+	result response.BaseResponse[T], statusCode int, rawBody []byte, err error,
 ) {
 	var bodyReader io.Reader = http.NoBody
 	if body != nil {
@@ -82,7 +140,7 @@ func Post[T any](ctx context.Context, c *Client, url string, body any) (
 		}
 		bodyReader = bytes.NewReader(b)
 	}
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bodyReader)
+	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
 	if err != nil {
 		var zero response.BaseResponse[T]
 		return zero, 0, nil, err
@@ -0,0 +1,109 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/biairmal/go-sdk/httpkit/response"
+)
+
+// seenRequest is what echoServer observed for one request.
+type seenRequest struct {
+	Method      string `json:"method"`
+	ContentType string `json:"content_type"`
+	Body        string `json:"body"`
+}
+
+// echoServer responds with the method, Content-Type and body it received, wrapped in the envelope.
+func echoServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		response.JSON(w, http.StatusOK, response.BaseResponse[seenRequest]{
+			Code: "OK",
+			Data: seenRequest{Method: r.Method, ContentType: r.Header.Get("Content-Type"), Body: string(b)},
+		})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestHelpers_methodsAndBodies(t *testing.T) {
+	srv := echoServer(t)
+	c := New(srv.Client())
+	ctx := context.Background()
+	body := map[string]string{"name": "x"}
+	encoded, _ := json.Marshal(body)
+
+	type call func() (response.BaseResponse[seenRequest], int, []byte, error)
+	tests := []struct {
+		name string
+		call call
+		want seenRequest
+	}{
+		{
+			name: "Get",
+			call: func() (response.BaseResponse[seenRequest], int, []byte, error) {
+				return Get[seenRequest](ctx, c, srv.URL)
+			},
+			want: seenRequest{Method: http.MethodGet},
+		},
+		{
+			name: "Post",
+			call: func() (response.BaseResponse[seenRequest], int, []byte, error) {
+				return Post[seenRequest](ctx, c, srv.URL, body)
+			},
+			want: seenRequest{Method: http.MethodPost, ContentType: "application/json", Body: string(encoded)},
+		},
+		{
+			name: "Put",
+			call: func() (response.BaseResponse[seenRequest], int, []byte, error) {
+				return Put[seenRequest](ctx, c, srv.URL, body)
+			},
+			want: seenRequest{Method: http.MethodPut, ContentType: "application/json", Body: string(encoded)},
+		},
+		{
+			name: "Patch",
+			call: func() (response.BaseResponse[seenRequest], int, []byte, error) {
+				return Patch[seenRequest](ctx, c, srv.URL, body)
+			},
+			want: seenRequest{Method: http.MethodPatch, ContentType: "application/json", Body: string(encoded)},
+		},
+		{
+			name: "Delete without body",
+			call: func() (response.BaseResponse[seenRequest], int, []byte, error) {
+				return Delete[seenRequest](ctx, c, srv.URL, nil)
+			},
+			want: seenRequest{Method: http.MethodDelete},
+		},
+		{
+			name: "Delete with body",
+			call: func() (response.BaseResponse[seenRequest], int, []byte, error) {
+				return Delete[seenRequest](ctx, c, srv.URL, body)
+			},
+			want: seenRequest{Method: http.MethodDelete, ContentType: "application/json", Body: string(encoded)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, status, _, err := tt.call()
+			if err != nil || status != http.StatusOK {
+				t.Fatalf("%s() = %d, %v, want 200, nil", tt.name, status, err)
+			}
+			if result.Data != tt.want {
+				t.Errorf("%s() server saw %+v, want %+v", tt.name, result.Data, tt.want)
+			}
+		})
+	}
+}
+
+func TestPut_marshalError(t *testing.T) {
+	_, status, _, err := Put[any](context.Background(), New(nil), "http://example.invalid", func() {})
+	if err == nil || status != 0 {
+		t.Errorf("Put() = %d, %v, want 0 and a marshal error", status, err)
+	}
+}
@@ -0,0 +1,50 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// prepare returns a copy of req bound to ctx with c.BaseURL and c.DefaultHeaders
+// applied; req itself is not modified.
+func (c *Client) prepare(ctx context.Context, req *http.Request) (*http.Request, error) {
+	req = req.Clone(ctx)
+	if c.BaseURL != "" && !req.URL.IsAbs() {
+		u, err := resolveURL(c.BaseURL, req.URL)
+		if err != nil {
+			return nil, err
+		}
+		req.URL, req.Host = u, u.Host
+	}
+	for name, values := range c.DefaultHeaders {
+		if len(req.Header.Values(name)) == 0 {
+			for _, v := range values {
+				req.Header.Add(name, v)
+			}
+		}
+	}
+	return req, nil
+}
+
+// resolveURL appends the path of the relative URL ref to base's path (so
+// "http://svc/api" and "users/1" give "http://svc/api/users/1"), taking the
+// query and fragment from ref.
+func resolveURL(base string, ref *url.URL) (*url.URL, error) {
+	b, err := url.Parse(base)
+	if err != nil {
+		return nil, fmt.Errorf("client: invalid BaseURL %q: %w", base, err)
+	}
+	if !b.IsAbs() || b.Host == "" {
+		return nil, fmt.Errorf("client: BaseURL %q must be absolute", base)
+	}
+	u := *b
+	if ref.Path != "" {
+		u.Path = strings.TrimSuffix(b.Path, "/") + "/" + strings.TrimPrefix(ref.Path, "/")
+		u.RawPath = ""
+	}
+	u.RawQuery, u.Fragment = ref.RawQuery, ref.Fragment
+	return &u, nil
+}
@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestResolveURL(t *testing.T) {
+	tests := []struct {
+		base, ref, want string
+	}{
+		{"http://svc", "/users/1", "http://svc/users/1"},
+		{"http://svc/api", "/users/1", "http://svc/api/users/1"},
+		{"http://svc/api/", "users/1", "http://svc/api/users/1"},
+		{"http://svc/api", "users?page=2", "http://svc/api/users?page=2"},
+		{"http://svc/api?v=1", "", "http://svc/api"},
+	}
+	for _, tt := range tests {
+		ref, err := url.Parse(tt.ref)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := resolveURL(tt.base, ref)
+		if err != nil || got.String() != tt.want {
+			t.Errorf("resolveURL(%q, %q) = %v, %v, want %v", tt.base, tt.ref, got, err, tt.want)
+		}
+	}
+}
+
+func TestResolveURL_invalidBase(t *testing.T) {
+	for _, base := range []string{"/api", "://bad"} {
+		if _, err := resolveURL(base, &url.URL{Path: "/x"}); err == nil {
+			t.Errorf("resolveURL(%q) error = nil, want error", base)
+		}
+	}
+}
+
+func TestClient_baseURLAndDefaultHeaders(t *testing.T) {
+	var gotPath, gotQuery string
+	var gotHeader http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotQuery, gotHeader = r.URL.Path, r.URL.RawQuery, r.Header.Clone()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	c := New(srv.Client())
+	c.BaseURL = srv.URL + "/api"
+	c.DefaultHeaders = http.Header{
+		"Authorization": {"Bearer default"},
+		"X-Tenant":      {"acme"},
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "/users?active=true", http.NoBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer per-request")
+	if _, _, _, err := Do[any](context.Background(), c, req); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+
+	if gotPath != "/api/users" || gotQuery != "active=true" {
+		t.Errorf("request = %s?%s, want /api/users?active=true", gotPath, gotQuery)
+	}
+	if got := gotHeader.Values("Authorization"); !reflect.DeepEqual(got, []string{"Bearer per-request"}) {
+		t.Errorf("Authorization = %v, want per-request value only", got)
+	}
+	if got := gotHeader.Get("X-Tenant"); got != "acme" {
+		t.Errorf("X-Tenant = %q, want acme", got)
+	}
+	if req.URL.String() != "/users?active=true" || req.Header.Get("X-Tenant") != "" {
+		t.Errorf("caller's request was modified: %v %v", req.URL, req.Header)
+	}
+}
+
+func TestClient_baseURLKeepsAbsoluteURLs(t *testing.T) {
+	var hit bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hit = true
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	c := New(srv.Client())
+	c.BaseURL = "http://unused.invalid"
+	if _, _, _, err := Get[any](context.Background(), c, srv.URL+"/ping"); err != nil || !hit {
+		t.Errorf("Get(absolute) error = %v, hit = %v, want nil, true", err, hit)
+	}
+}
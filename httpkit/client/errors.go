@@ -0,0 +1,113 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/biairmal/go-sdk/errorz"
+	"github.com/biairmal/go-sdk/httpkit/response"
+)
+
+// codeSentinels maps the predefined errorz codes to their sentinels so that
+// reconstructed errors still match errors.Is(err, errorz.ErrNotFound) etc.
+var codeSentinels = map[string]error{
+	errorz.CodeNotFound:             errorz.ErrNotFound,
+	errorz.CodeBadRequest:           errorz.ErrBadRequest,
+	errorz.CodeInternal:             errorz.ErrInternal,
+	errorz.CodeUnauthorized:         errorz.ErrUnauthorized,
+	errorz.CodeForbidden:            errorz.ErrForbidden,
+	errorz.CodeTooManyRequests:      errorz.ErrTooManyRequests,
+	errorz.CodeBadGateway:           errorz.ErrBadGateway,
+	errorz.CodeServiceUnavailable:   errorz.ErrServiceUnavailable,
+	errorz.CodeUnprocessableEntity:  errorz.ErrUnprocessableEntity,
+	errorz.CodeConflict:             errorz.ErrConflict,
+	errorz.CodePreconditionFailed:   errorz.ErrPreconditionFailed,
+	errorz.CodePreconditionRequired: errorz.ErrPreconditionRequired,
+	errorz.CodePreconditionNotMet:   errorz.ErrPreconditionNotMet,
+	errorz.CodeMethodNotAllowed:     errorz.ErrMethodNotAllowed,
+	errorz.CodeNotAcceptable:        errorz.ErrNotAcceptable,
+	errorz.CodeUnsupportedMediaType: errorz.ErrUnsupportedMediaType,
+}
+
+// statusCodes maps HTTP statuses to errorz codes for error responses without a payload.
+var statusCodes = map[int]string{
+	http.StatusNotFound:             errorz.CodeNotFound,
+	http.StatusBadRequest:           errorz.CodeBadRequest,
+	http.StatusUnauthorized:         errorz.CodeUnauthorized,
+	http.StatusForbidden:            errorz.CodeForbidden,
+	http.StatusTooManyRequests:      errorz.CodeTooManyRequests,
+	http.StatusBadGateway:           errorz.CodeBadGateway,
+	http.StatusServiceUnavailable:   errorz.CodeServiceUnavailable,
+	http.StatusUnprocessableEntity:  errorz.CodeUnprocessableEntity,
+	http.StatusConflict:             errorz.CodeConflict,
+	http.StatusPreconditionFailed:   errorz.CodePreconditionFailed,
+	http.StatusPreconditionRequired: errorz.CodePreconditionRequired,
+	http.StatusMethodNotAllowed:     errorz.CodeMethodNotAllowed,
+	http.StatusNotAcceptable:        errorz.CodeNotAcceptable,
+	http.StatusUnsupportedMediaType: errorz.CodeUnsupportedMediaType,
+}
+
+// ErrorFromResponse reconstructs the *errorz.Error a server wrote with the httpkit
+// envelope. It returns nil when statusCode is below 400 and result.Error is empty.
+// Code, Message, SourceSystem and Meta are copied from the ErrorPayload, Details
+// (if any) is joined into the wrapped error, and statusCode (when >= 400) is set as
+// HTTPStatus. Predefined codes wrap their sentinel, so errors.Is(err,
+// errorz.ErrNotFound) works on the client as it does on the server. When the
+// body carries no payload, the code is derived from statusCode and the message
+// is the status text.
+func ErrorFromResponse[T any](result response.BaseResponse[T], statusCode int) error {
+	if statusCode < http.StatusBadRequest && result.Error == nil {
+		return nil
+	}
+	payload := errorPayload(result.Error)
+	if payload.Code == "" {
+		payload.Code = statusCodes[statusCode]
+		if payload.Code == "" {
+			payload.Code = errorz.CodeInternal
+		}
+	}
+	if payload.Message == "" {
+		payload.Message = nonEmpty(result.Message, http.StatusText(statusCode))
+	}
+	e := errorz.New(payload.Message).WithCode(payload.Code).WithMetaMap(payload.Meta)
+	e.SourceSystem = payload.SourceSystem
+	if sentinel := codeSentinels[payload.Code]; sentinel != nil {
+		e.Err = sentinel
+	}
+	if payload.Details != "" {
+		e.Err = errors.Join(e.Err, errors.New(payload.Details))
+	}
+	if statusCode >= http.StatusBadRequest {
+		e.WithHTTPStatus(statusCode)
+	}
+	return e
+}
+
+// errorPayload converts the decoded Error field (usually a map) into an ErrorPayload.
+// Values that do not have the payload shape yield a zero ErrorPayload.
+func errorPayload(v any) response.ErrorPayload {
+	var payload response.ErrorPayload
+	switch p := v.(type) {
+	case nil:
+	case response.ErrorPayload:
+		payload = p
+	case *response.ErrorPayload:
+		payload = *p
+	case string:
+		payload.Message = p
+	default:
+		b, err := json.Marshal(p)
+		if err == nil {
+			_ = json.Unmarshal(b, &payload)
+		}
+	}
+	return payload
+}
+
+func nonEmpty(a, b string) string {
+	if a != "" {
+		return a
+	}
+	return b
+}
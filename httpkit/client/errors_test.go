@@ -0,0 +1,142 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/biairmal/go-sdk/errorz"
+	"github.com/biairmal/go-sdk/httpkit/handler"
+	"github.com/biairmal/go-sdk/httpkit/response"
+)
+
+func TestDo_mapErrorsRoundTrip(t *testing.T) {
+	srv := httptest.NewServer(handler.Handle(func(*http.Request) (any, error) {
+		return nil, errorz.NotFound().
+			WithSourceSystem("user-service").
+			WithMeta("user_id", "42")
+	}))
+	defer srv.Close()
+
+	c := New(srv.Client())
+	c.MapErrors = true
+	_, status, _, err := Get[map[string]any](context.Background(), c, srv.URL)
+	if status != http.StatusNotFound {
+		t.Errorf("status = %v, want 404", status)
+	}
+	var e *errorz.Error
+	if !errors.As(err, &e) {
+		t.Fatalf("err = %v, want *errorz.Error", err)
+	}
+	if e.Code != errorz.CodeNotFound || e.Message != "not found" || e.SourceSystem != "user-service" {
+		t.Errorf("err = %+v, want ERR_NOT_FOUND / not found / user-service", e)
+	}
+	if e.Meta["user_id"] != "42" {
+		t.Errorf("Meta = %v, want user_id 42", e.Meta)
+	}
+	if e.StatusCode() != http.StatusNotFound {
+		t.Errorf("StatusCode() = %v, want 404", e.StatusCode())
+	}
+	if !errors.Is(err, errorz.ErrNotFound) {
+		t.Error("errors.Is(err, ErrNotFound) = false, want true")
+	}
+}
+
+func TestDo_mapErrorsDisabled(t *testing.T) {
+	srv := httptest.NewServer(handler.Handle(func(*http.Request) (any, error) {
+		return nil, errorz.BadRequest()
+	}))
+	defer srv.Close()
+
+	result, status, _, err := Get[any](context.Background(), New(srv.Client()), srv.URL)
+	if err != nil {
+		t.Errorf("err = %v, want nil", err)
+	}
+	if status != http.StatusBadRequest || result.Error == nil {
+		t.Errorf("status = %v, Error = %v, want 400 with error payload", status, result.Error)
+	}
+}
+
+func TestDo_mapErrorsNonJSONBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = w.Write([]byte("<html>bad gateway</html>"))
+	}))
+	defer srv.Close()
+
+	c := New(srv.Client())
+	c.MapErrors = true
+	_, _, raw, err := Get[any](context.Background(), c, srv.URL)
+	if !errors.Is(err, errorz.ErrBadGateway) {
+		t.Errorf("err = %v, want ErrBadGateway", err)
+	}
+	if string(raw) != "<html>bad gateway</html>" {
+		t.Errorf("rawBody = %q", raw)
+	}
+}
+
+func TestErrorFromResponse(t *testing.T) {
+	tests := []struct {
+		name       string
+		result     response.BaseResponse[any]
+		status     int
+		wantNil    bool
+		wantCode   string
+		wantMsg    string
+		wantStatus int
+	}{
+		{name: "success", status: http.StatusOK, wantNil: true},
+		{
+			name:     "error payload on 200",
+			result:   response.BaseResponse[any]{Error: map[string]any{"code": "ERR_QUOTA", "message": "quota"}},
+			status:   http.StatusOK,
+			wantCode: "ERR_QUOTA", wantMsg: "quota", wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:     "status without payload",
+			status:   http.StatusTooManyRequests,
+			wantCode: errorz.CodeTooManyRequests, wantMsg: "Too Many Requests", wantStatus: http.StatusTooManyRequests,
+		},
+		{
+			name:     "unknown status",
+			status:   http.StatusTeapot,
+			wantCode: errorz.CodeInternal, wantMsg: "I'm a teapot", wantStatus: http.StatusTeapot,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ErrorFromResponse(tt.result, tt.status)
+			if tt.wantNil {
+				if err != nil {
+					t.Errorf("ErrorFromResponse() = %v, want nil", err)
+				}
+				return
+			}
+			var e *errorz.Error
+			if !errors.As(err, &e) {
+				t.Fatalf("ErrorFromResponse() = %v, want *errorz.Error", err)
+			}
+			if e.Code != tt.wantCode || e.Message != tt.wantMsg || e.StatusCode() != tt.wantStatus {
+				t.Errorf("ErrorFromResponse() = %s/%s/%d, want %s/%s/%d",
+					e.Code, e.Message, e.StatusCode(), tt.wantCode, tt.wantMsg, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestErrorFromResponse_details(t *testing.T) {
+	result := response.BaseResponse[any]{Error: response.ErrorPayload{
+		Code: errorz.CodeConflict, Message: "conflict", Details: "version mismatch",
+	}}
+	err := ErrorFromResponse(result, http.StatusConflict)
+	if !errors.Is(err, errorz.ErrConflict) {
+		t.Errorf("errors.Is(err, ErrConflict) = false, want true")
+	}
+	var e *errorz.Error
+	if !errors.As(err, &e) || e.Err == nil || !strings.Contains(e.Err.Error(), "version mismatch") {
+		t.Errorf("wrapped error = %v, want details", e.Err)
+	}
+}
@@ -0,0 +1,51 @@
+package client
+
+import (
+	"net/http"
+
+	"github.com/biairmal/go-sdk/httpkit/middleware"
+)
+
+// RoundTrip sends a request and returns its response; it is the next step of an
+// Interceptor chain (ultimately Client.HTTPClient.Do).
+type RoundTrip func(req *http.Request) (*http.Response, error)
+
+// Interceptor wraps every outgoing request of a Client. It may modify the request
+// (clone it first with req.Clone so the caller's request is left untouched),
+// call next, inspect or replace the response, or return without calling next.
+type Interceptor func(req *http.Request, next RoundTrip) (*http.Response, error)
+
+// Use appends interceptors to c. The first interceptor registered is the
+// outermost (runs first on request, last on response), as with middleware.Chain.
+// Interceptors run once per attempt, so retries see freshly intercepted requests.
+// Use is not safe to call concurrently with requests on c.
+func (c *Client) Use(interceptors ...Interceptor) *Client {
+	c.interceptors = append(c.interceptors, interceptors...)
+	return c
+}
+
+// roundTrip sends req through the interceptors and then c.HTTPClient.
+func (c *Client) roundTrip(req *http.Request) (*http.Response, error) {
+	next := RoundTrip(c.HTTPClient.Do)
+	for i := len(c.interceptors) - 1; i >= 0; i-- {
+		interceptor, inner := c.interceptors[i], next
+		next = func(req *http.Request) (*http.Response, error) { return interceptor(req, inner) }
+	}
+	return next(req)
+}
+
+// PropagateRequestID returns an interceptor that copies the request ID stored in
+// the request context by middleware.RequestID (under middleware.RequestIDKey) to
+// the X-Request-Id header of outgoing requests, so one ID follows a call across
+// services. A header already set on the request is kept.
+func PropagateRequestID() Interceptor {
+	return func(req *http.Request, next RoundTrip) (*http.Response, error) {
+		id, _ := req.Context().Value(middleware.RequestIDKey).(string)
+		if id == "" || req.Header.Get(middleware.RequestIDHeader) != "" {
+			return next(req)
+		}
+		req = req.Clone(req.Context())
+		req.Header.Set(middleware.RequestIDHeader, id)
+		return next(req)
+	}
+}
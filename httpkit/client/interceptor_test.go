@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/biairmal/go-sdk/httpkit/middleware"
+)
+
+func headerEcho(t *testing.T, name string, got *[]string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*got = append(*got, r.Header.Get(name))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestClient_useOrder(t *testing.T) {
+	var seen []string
+	srv := headerEcho(t, "Authorization", &seen)
+
+	var order []string
+	trace := func(name string) Interceptor {
+		return func(req *http.Request, next RoundTrip) (*http.Response, error) {
+			order = append(order, name+" before")
+			resp, err := next(req)
+			order = append(order, name+" after")
+			return resp, err
+		}
+	}
+	auth := func(req *http.Request, next RoundTrip) (*http.Response, error) {
+		req = req.Clone(req.Context())
+		req.Header.Set("Authorization", "Bearer token")
+		return next(req)
+	}
+	c := New(srv.Client()).Use(trace("outer"), trace("inner"), auth)
+
+	if _, _, _, err := Get[any](context.Background(), c, srv.URL); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	wantOrder := []string{"outer before", "inner before", "inner after", "outer after"}
+	if !reflect.DeepEqual(order, wantOrder) {
+		t.Errorf("order = %v, want %v", order, wantOrder)
+	}
+	if !reflect.DeepEqual(seen, []string{"Bearer token"}) {
+		t.Errorf("Authorization = %v, want [Bearer token]", seen)
+	}
+}
+
+func TestClient_useShortCircuit(t *testing.T) {
+	boom := errors.New("offline")
+	c := New(nil).Use(func(*http.Request, RoundTrip) (*http.Response, error) {
+		return nil, boom
+	})
+	if _, _, _, err := Get[any](context.Background(), c, "http://example.invalid"); !errors.Is(err, boom) {
+		t.Errorf("Get() error = %v, want %v", err, boom)
+	}
+}
+
+func TestPropagateRequestID(t *testing.T) {
+	var seen []string
+	srv := headerEcho(t, middleware.RequestIDHeader, &seen)
+	c := New(srv.Client()).Use(PropagateRequestID())
+
+	ctx := context.WithValue(context.Background(), middleware.RequestIDKey, "req-123")
+	if _, _, _, err := Get[any](ctx, c, srv.URL); err != nil {
+		t.Fatal(err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, http.NoBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set(middleware.RequestIDHeader, "explicit")
+	if _, _, _, err := Do[any](ctx, c, req); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, err := Get[any](context.Background(), c, srv.URL); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"req-123", "explicit", ""}
+	if !reflect.DeepEqual(seen, want) {
+		t.Errorf("X-Request-Id = %q, want %q", seen, want)
+	}
+}
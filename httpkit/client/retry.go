@@ -0,0 +1,186 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// RetryConfig is the retry/backoff configuration for Client.Retry.
+// A request is retried when the transport fails (context errors excepted) or the
+// response status is in StatusCodes. The delay before retry n (0-based) is
+// InitialDelay * Multiplier^n, capped at MaxDelay, then randomized by ±Jitter;
+// a Retry-After header on the response replaces the computed delay.
+type RetryConfig struct {
+	MaxAttempts  int           // Total attempts, including the first; 1 or less disables retries
+	InitialDelay time.Duration // Delay before the first retry
+	MaxDelay     time.Duration // Upper bound for a computed delay (optional; Retry-After is not capped)
+	Multiplier   float64       // Backoff growth factor (values below 1 are treated as 1)
+	Jitter       float64       // Randomization fraction in [0, 1]
+	StatusCodes  []int         // Retried statuses (default: 429, 502, 503)
+	Methods      []string      // Retried methods (default: GET; add others only for idempotent endpoints)
+}
+
+// DefaultRetryConfig returns a RetryConfig with default values.
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxAttempts:  3,
+		InitialDelay: 100 * time.Millisecond,
+		MaxDelay:     2 * time.Second,
+		Multiplier:   2,
+		Jitter:       0.2,
+	}
+}
+
+// defaultRetryStatusCodes are retried when RetryConfig.StatusCodes is nil.
+var defaultRetryStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+}
+
+// retries reports whether req may be retried under c.
+func (c *RetryConfig) retries(req *http.Request) bool {
+	if c == nil || c.MaxAttempts <= 1 {
+		return false
+	}
+	if c.Methods == nil {
+		return req.Method == http.MethodGet
+	}
+	return slices.Contains(c.Methods, req.Method)
+}
+
+// retryStatus reports whether status should be retried.
+func (c *RetryConfig) retryStatus(status int) bool {
+	if c.StatusCodes == nil {
+		return slices.Contains(defaultRetryStatusCodes, status)
+	}
+	return slices.Contains(c.StatusCodes, status)
+}
+
+// backoff returns the delay before retry n (0-based), preferring resp's Retry-After.
+func (c *RetryConfig) backoff(n int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if d, ok := retryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			return d
+		}
+	}
+	multiplier := math.Max(c.Multiplier, 1)
+	delay := float64(c.InitialDelay) * math.Pow(multiplier, float64(n))
+	if c.MaxDelay > 0 && delay > float64(c.MaxDelay) {
+		delay = float64(c.MaxDelay)
+	}
+	if c.Jitter > 0 {
+		jitter := math.Min(c.Jitter, 1)
+		delay *= 1 - jitter + 2*jitter*rand.Float64() //nolint:gosec // jitter does not need a CSPRNG
+	}
+	return time.Duration(delay)
+}
+
+// retryAfter parses a Retry-After value given as delay-seconds or an HTTP date.
+func retryAfter(v string, now time.Time) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(max(secs, 0)) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(t.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// send performs req, retrying it according to c.Retry. Retrying stops as soon
+// as c.Breaker opens for the host, returning the attempt that tripped it.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	cfg := c.Retry
+	if !cfg.retries(req) {
+		return c.attempt(req)
+	}
+	if err := bufferBody(req); err != nil {
+		return nil, err
+	}
+	ctx := req.Context()
+	for attempt := 0; ; attempt++ {
+		resp, err := c.attempt(req)
+		if attempt == cfg.MaxAttempts-1 || !shouldRetry(ctx, cfg, resp, err) || c.Breaker.open(req.URL.Host) {
+			return resp, err
+		}
+		delay := cfg.backoff(attempt, resp)
+		if resp != nil {
+			drain(resp.Body)
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+		if req, err = rewind(req); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// shouldRetry reports whether the outcome of an attempt is retryable.
+func shouldRetry(ctx context.Context, cfg *RetryConfig, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) &&
+			!errors.Is(err, ErrCircuitOpen)
+	}
+	return cfg.retryStatus(resp.StatusCode)
+}
+
+// bufferBody makes req's body replayable by setting GetBody when it is missing.
+func bufferBody(req *http.Request) error {
+	if req.Body == nil || req.Body == http.NoBody || req.GetBody != nil {
+		return nil
+	}
+	b, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return err
+	}
+	req.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(b)), nil }
+	req.Body = io.NopCloser(bytes.NewReader(b))
+	return nil
+}
+
+// drain reads and closes a discarded response body so the connection can be reused.
+func drain(body io.ReadCloser) {
+	defer body.Close()
+	io.Copy(io.Discard, body) //nolint:errcheck // best effort; the body is discarded
+}
+
+// rewind returns a copy of req with a fresh body for the next attempt.
+func rewind(req *http.Request) (*http.Request, error) {
+	next := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		next.Body = body
+	}
+	return next, nil
+}
+
+// sleep waits for d or until ctx is done, returning ctx.Err() in the latter case.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,160 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// flakyServer answers the first failures requests with status and then 200,
+// recording every request body it receives.
+func flakyServer(t *testing.T, failures int32, status int, bodies *[]string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		if bodies != nil {
+			*bodies = append(*bodies, string(b))
+		}
+		if calls.Add(1) <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		_, _ = w.Write([]byte(`{"code":"OK","data":"done"}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+func retryClient(srv *httptest.Server, cfg RetryConfig) *Client {
+	c := New(srv.Client())
+	c.Retry = &cfg
+	return c
+}
+
+func TestDo_retriesRetryableStatus(t *testing.T) {
+	srv, calls := flakyServer(t, 2, http.StatusServiceUnavailable, nil)
+	c := retryClient(srv, RetryConfig{MaxAttempts: 3, InitialDelay: time.Millisecond})
+
+	result, status, _, err := Get[string](context.Background(), c, srv.URL)
+	if err != nil || status != http.StatusOK || result.Data != "done" {
+		t.Fatalf("Get() = %v, %v, %v, want done, 200, nil", result.Data, status, err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("attempts = %d, want 3", calls.Load())
+	}
+}
+
+func TestDo_retryGivesUpAfterMaxAttempts(t *testing.T) {
+	srv, calls := flakyServer(t, 5, http.StatusBadGateway, nil)
+	c := retryClient(srv, RetryConfig{MaxAttempts: 2})
+
+	_, status, _, _ := Get[string](context.Background(), c, srv.URL)
+	if status != http.StatusBadGateway {
+		t.Errorf("status = %d, want 502", status)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("attempts = %d, want 2", calls.Load())
+	}
+}
+
+func TestDo_retrySkipsOtherStatuses(t *testing.T) {
+	srv, calls := flakyServer(t, 1, http.StatusInternalServerError, nil)
+	c := retryClient(srv, RetryConfig{MaxAttempts: 3})
+
+	if _, status, _, _ := Get[string](context.Background(), c, srv.URL); status != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", status)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("attempts = %d, want 1", calls.Load())
+	}
+}
+
+func TestDo_retryMethods(t *testing.T) {
+	t.Run("POST not retried by default", func(t *testing.T) {
+		srv, calls := flakyServer(t, 1, http.StatusServiceUnavailable, nil)
+		c := retryClient(srv, RetryConfig{MaxAttempts: 3})
+
+		_, status, _, _ := Post[string](context.Background(), c, srv.URL, map[string]int{"n": 1})
+		if status != http.StatusServiceUnavailable || calls.Load() != 1 {
+			t.Errorf("status = %d, attempts = %d, want 503 after 1 attempt", status, calls.Load())
+		}
+	})
+	t.Run("POST opt-in replays body", func(t *testing.T) {
+		var bodies []string
+		srv, _ := flakyServer(t, 1, http.StatusServiceUnavailable, &bodies)
+		c := retryClient(srv, RetryConfig{MaxAttempts: 3, Methods: []string{http.MethodPost}})
+
+		req, err := http.NewRequest(http.MethodPost, srv.URL, io.NopCloser(strings.NewReader(`{"n":1}`)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, status, _, err := Do[string](context.Background(), c, req); err != nil || status != http.StatusOK {
+			t.Fatalf("Do() = %d, %v, want 200, nil", status, err)
+		}
+		if len(bodies) != 2 || bodies[0] != `{"n":1}` || bodies[1] != `{"n":1}` {
+			t.Errorf("bodies = %q, want the same body twice", bodies)
+		}
+	})
+}
+
+func TestDo_retryStopsOnCancel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+	c := retryClient(srv, RetryConfig{MaxAttempts: 5})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, _, _, err := Get[string](ctx, c, srv.URL)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Get() took %v, want it to stop at the deadline", elapsed)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"", 0, false},
+		{"3", 3 * time.Second, true},
+		{"-1", 0, true},
+		{now.Add(2 * time.Second).Format(http.TimeFormat), 2 * time.Second, true},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+		{"soon", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := retryAfter(tt.value, now)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("retryAfter(%q) = %v, %v, want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestRetryConfig_backoff(t *testing.T) {
+	cfg := RetryConfig{InitialDelay: 100 * time.Millisecond, MaxDelay: 300 * time.Millisecond, Multiplier: 2}
+	for n, want := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond} {
+		if got := cfg.backoff(n, nil); got != want {
+			t.Errorf("backoff(%d) = %v, want %v", n, got, want)
+		}
+	}
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"7"}}}
+	if got := cfg.backoff(0, resp); got != 7*time.Second {
+		t.Errorf("backoff with Retry-After = %v, want 7s", got)
+	}
+}
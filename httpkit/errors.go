@@ -7,7 +7,8 @@ import (
 )
 
 // StatusCodeFromError returns the HTTP status code for the given error.
-// If the error is a *errorz.Error, its Code is looked up in the default map.
+// If the error is a *errorz.Error, its explicit HTTPStatus is used when set,
+// otherwise its Code is looked up in the default map.
 // Otherwise it returns http.StatusInternalServerError.
 // This is the same as handler.StatusCodeFromError; it is re-exported here for convenience.
 func StatusCodeFromError(err error) int {
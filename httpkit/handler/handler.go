@@ -2,35 +2,113 @@ package handler
 
 import (
 	"net/http"
+	"runtime/debug"
 
+	"github.com/biairmal/go-sdk/errorz"
 	"github.com/biairmal/go-sdk/httpkit/response"
+	"github.com/biairmal/go-sdk/logger"
 )
 
 // Func is a function that handles a request and returns a response payload and an optional error.
+// Return *errorz.Error values (e.g. errorz.NotFound(), possibly wrapped) so Handle can
+// derive the HTTP status from the error code; any other error is written as a 500.
+// Return *response.Success to choose the success status, or Response to also set
+// headers and cookies; other values are written with 200.
 type Func func(r *http.Request) (any, error)
 
+// Option configures Handle.
+type Option func(*options)
+
+type options struct {
+	statusMapper StatusMapper
+	log          logger.Logger
+}
+
+// WithStatusMapper sets a StatusMapper consulted before StatusCodeFromError when
+// the handler returns an error, e.g. WithStatusMapper(CodeStatuses(map[string]int{
+// "ERR_PAYMENT_DECLINED": http.StatusPaymentRequired})).
+func WithStatusMapper(m StatusMapper) Option {
+	return func(o *options) { o.statusMapper = m }
+}
+
+// WithLogger sets the logger used to report panics recovered from the handler
+// function, at error level with the stack trace. Without it panics are still
+// recovered but not logged.
+func WithLogger(log logger.Logger) Option {
+	return func(o *options) { o.log = log }
+}
+
 // Handle converts a Func into an http.HandlerFunc.
-// On error it uses StatusCodeFromError to set the status and writes the error envelope.
-// On success it uses *response.Success HTTPStatusCode when present, otherwise 200.
-func Handle(h Func) http.HandlerFunc {
+// On error it derives the status from the error, using the WithStatusMapper mapper
+// (if any) and then StatusCodeFromError, and writes the error envelope
+// (Code response.CodeError). On success it uses the status of a *response.Success
+// or Response when set, otherwise 200, applies a Response's headers and cookies,
+// and writes the success envelope (Code response.CodeOK). Both envelopes are
+// encoded as negotiated from the request's Accept header (see response.Respond).
+// A panic in h is recovered, logged with the WithLogger logger (if any) and written
+// as a 500 with errorz.Internal(), whose meta "panic" holds the panic value;
+// http.ErrAbortHandler is re-panicked.
+func Handle(h Func, opts ...Option) http.HandlerFunc {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
 	return func(w http.ResponseWriter, r *http.Request) {
-		data, err := h(r)
+		data, panicked, err := call(h, r, o.log)
+		if panicked {
+			writeError(w, http.StatusInternalServerError, err, negotiated(r))
+			return
+		}
 		if err != nil {
-			statusCode := StatusCodeFromError(err)
-			WriteErrorResponse(w, statusCode, err)
+			statusCode := statusFor(err, o.statusMapper)
+			writeError(w, statusCode, err, negotiated(r))
 			return
 		}
 
-		var statusCode int
-		var payload any
-		if succ, ok := data.(*response.Success); ok {
-			statusCode = succ.HTTPStatusCode
-			payload = succ.Data
-		} else {
-			statusCode = http.StatusOK
-			payload = data
+		statusCode, payload := http.StatusOK, data
+		switch v := data.(type) {
+		case *response.Success:
+			payload = nil
+			if v != nil {
+				payload = v.Data
+				if v.HTTPStatusCode != 0 {
+					statusCode = v.HTTPStatusCode
+				}
+			}
+		case *Response:
+			payload = nil
+			if v != nil {
+				statusCode, payload = v.apply(w)
+			}
+		case Response:
+			statusCode, payload = v.apply(w)
 		}
 
-		WriteSuccessResponse(w, statusCode, payload)
+		writeSuccess(w, statusCode, payload, negotiated(r))
 	}
 }
+
+// call runs h, recovering a panic into an errorz.Internal() error.
+func call(h Func, r *http.Request, log logger.Logger) (data any, panicked bool, err error) {
+	defer func() {
+		v := recover()
+		if v == nil {
+			return
+		}
+		if v == http.ErrAbortHandler { //nolint:errorlint // net/http compares the sentinel directly
+			panic(v)
+		}
+		msg := stringOrSprint(v)
+		if log != nil {
+			log.ErrorWithContext(r.Context(), "http panic recovered",
+				logger.F("panic", msg),
+				logger.F("stack", string(debug.Stack())),
+				logger.F("path", r.URL.Path),
+				logger.F("method", r.Method),
+			)
+		}
+		data, panicked, err = nil, true, errorz.Internal().WithMeta("panic", msg)
+	}()
+	data, err = h(r)
+	return data, false, err
+}
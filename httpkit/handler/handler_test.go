@@ -1,12 +1,19 @@
 package handler
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/biairmal/go-sdk/errorz"
 	"github.com/biairmal/go-sdk/httpkit/response"
+	"github.com/biairmal/go-sdk/logger"
 )
 
 func TestHandle_success(t *testing.T) {
@@ -50,3 +57,215 @@ func TestHandle_noContent(t *testing.T) {
 		t.Errorf("body should be empty for 204, got %d bytes", w.Body.Len())
 	}
 }
+
+func TestHandle_statusFromError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+		wantErr  string
+	}{
+		{"errorz code", errorz.NotFound(), http.StatusNotFound, errorz.CodeNotFound},
+		{"wrapped errorz", fmt.Errorf("load user: %w", errorz.Forbidden()), http.StatusForbidden, errorz.CodeForbidden},
+		{"explicit status", errorz.New("teapot").WithHTTPStatus(http.StatusTeapot), http.StatusTeapot, ""},
+		{"plain error", errors.New("boom"), http.StatusInternalServerError, errorz.CodeInternal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := Handle(func(*http.Request) (any, error) { return nil, tt.err })
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+			if w.Code != tt.wantCode {
+				t.Errorf("status = %v, want %v", w.Code, tt.wantCode)
+			}
+			var body response.BaseResponse[any]
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("body is not JSON: %v", err)
+			}
+			if body.Code != response.CodeError {
+				t.Errorf("envelope code = %q, want %q", body.Code, response.CodeError)
+			}
+			payload, _ := body.Error.(map[string]any)
+			if tt.wantErr != "" && payload["code"] != tt.wantErr {
+				t.Errorf("error code = %v, want %v", payload["code"], tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHandle_successEnvelope(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     any
+		wantCode int
+		wantData any
+	}{
+		{"plain value", map[string]any{"id": "1"}, http.StatusOK, map[string]any{"id": "1"}},
+		{"Created", response.Created("new"), http.StatusCreated, "new"},
+		{"Success without status", &response.Success{Data: "x"}, http.StatusOK, "x"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := Handle(func(*http.Request) (any, error) { return tt.data, nil })
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+			if w.Code != tt.wantCode {
+				t.Errorf("status = %v, want %v", w.Code, tt.wantCode)
+			}
+			var body response.BaseResponse[any]
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("body is not JSON: %v", err)
+			}
+			if body.Code != response.CodeOK || body.Message != response.MessageSuccess {
+				t.Errorf("envelope = %q/%q, want %q/%q", body.Code, body.Message, response.CodeOK, response.MessageSuccess)
+			}
+			if !reflect.DeepEqual(body.Data, tt.wantData) {
+				t.Errorf("data = %v, want %v", body.Data, tt.wantData)
+			}
+		})
+	}
+}
+
+func TestHandle_response(t *testing.T) {
+	tests := []struct {
+		name string
+		data any
+	}{
+		{"value", Response{
+			Status:  http.StatusCreated,
+			Headers: http.Header{"location": {"/users/7"}},
+			Body:    map[string]any{"id": "7"},
+			Cookies: []*http.Cookie{{Name: "session", Value: "abc", HttpOnly: true}},
+		}},
+		{"pointer", &Response{
+			Status:  http.StatusCreated,
+			Headers: http.Header{"Location": {"/users/7"}},
+			Body:    map[string]any{"id": "7"},
+			Cookies: []*http.Cookie{{Name: "session", Value: "abc", HttpOnly: true}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := Handle(func(*http.Request) (any, error) { return tt.data, nil })
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/users", http.NoBody))
+
+			if w.Code != http.StatusCreated {
+				t.Errorf("status = %v, want 201", w.Code)
+			}
+			if got := w.Header().Get("Location"); got != "/users/7" {
+				t.Errorf("Location = %q, want /users/7", got)
+			}
+			if got := w.Header().Get("Set-Cookie"); got != "session=abc; HttpOnly" {
+				t.Errorf("Set-Cookie = %q, want session=abc; HttpOnly", got)
+			}
+			var body response.BaseResponse[map[string]any]
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("body is not JSON: %v", err)
+			}
+			if body.Code != response.CodeOK || body.Data["id"] != "7" {
+				t.Errorf("envelope = %+v, want OK with data.id 7", body)
+			}
+		})
+	}
+}
+
+func TestHandle_responseDefaults(t *testing.T) {
+	h := Handle(func(*http.Request) (any, error) {
+		return Response{Headers: http.Header{"Cache-Control": {"no-store"}}, Body: "ok"}, nil
+	})
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+	if w.Code != http.StatusOK || w.Header().Get("Cache-Control") != "no-store" {
+		t.Errorf("status = %v, Cache-Control = %q, want 200 and no-store", w.Code, w.Header().Get("Cache-Control"))
+	}
+
+	h = Handle(func(*http.Request) (any, error) {
+		return &Response{Status: http.StatusNoContent, Cookies: []*http.Cookie{{Name: "session", MaxAge: -1}}}, nil
+	})
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/logout", http.NoBody))
+	if w.Code != http.StatusNoContent || w.Body.Len() != 0 || w.Header().Get("Set-Cookie") == "" {
+		t.Errorf("logout = %v, %d bytes, Set-Cookie %q; want 204, empty body, cookie cleared",
+			w.Code, w.Body.Len(), w.Header().Get("Set-Cookie"))
+	}
+}
+
+func TestHandle_negotiatesEncoding(t *testing.T) {
+	response.RegisterEncoder("application/xml", response.EncodeXML)
+	t.Cleanup(func() { response.RegisterEncoder("application/xml", nil) })
+
+	h := Handle(func(*http.Request) (any, error) { return nil, errorz.NotFound() })
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	req.Header.Set("Accept", "application/xml")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound || w.Header().Get("Content-Type") != "application/xml" {
+		t.Errorf("status = %v, Content-Type = %q, want 404 and application/xml",
+			w.Code, w.Header().Get("Content-Type"))
+	}
+	if !strings.Contains(w.Body.String(), "<code>ERR_NOT_FOUND</code>") {
+		t.Errorf("body = %s, want the XML error envelope", w.Body.String())
+	}
+}
+
+func TestHandle_recoversPanic(t *testing.T) {
+	var buf bytes.Buffer
+	log := logger.NewZerologWithWriter(&buf, nil)
+	h := Handle(func(_ *http.Request) (any, error) {
+		panic("nil map write")
+	}, WithLogger(log))
+	req := httptest.NewRequest(http.MethodGet, "/boom", http.NoBody)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("status = %v, want 500", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", ct)
+	}
+	var body struct {
+		Code  string                 `json:"code"`
+		Error *response.ErrorPayload `json:"error"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("body is not JSON: %v: %s", err, w.Body.String())
+	}
+	if body.Code != response.CodeError || body.Error == nil || body.Error.Code != errorz.CodeInternal {
+		t.Fatalf("body = %s, want error envelope with %s", w.Body.String(), errorz.CodeInternal)
+	}
+	if body.Error.Meta["panic"] != "nil map write" {
+		t.Errorf("meta = %v, want panic: nil map write", body.Error.Meta)
+	}
+
+	logged := buf.String()
+	for _, want := range []string{`"message":"http panic recovered"`, "nil map write", `"stack":`, `"path":"/boom"`} {
+		if !strings.Contains(logged, want) {
+			t.Errorf("log does not contain %s: %s", want, logged)
+		}
+	}
+}
+
+func TestHandle_recoversPanicWithoutLogger(t *testing.T) {
+	h := Handle(func(_ *http.Request) (any, error) {
+		panic(errors.New("boom"))
+	})
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+	if w.Code != http.StatusInternalServerError || !strings.Contains(w.Body.String(), `"panic":"boom"`) {
+		t.Errorf("response = %d %s, want 500 with panic meta", w.Code, w.Body.String())
+	}
+}
+
+func TestHandle_rethrowsAbortHandler(t *testing.T) {
+	h := Handle(func(_ *http.Request) (any, error) {
+		panic(http.ErrAbortHandler)
+	})
+	defer func() {
+		if v := recover(); v != http.ErrAbortHandler { //nolint:errorlint // sentinel compared directly
+			t.Errorf("recover() = %v, want http.ErrAbortHandler", v)
+		}
+	}()
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+}
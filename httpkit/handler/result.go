@@ -0,0 +1,38 @@
+package handler
+
+import "net/http"
+
+// Response is a handler result that also sets response headers and cookies, e.g.
+// Location on 201 or Set-Cookie in auth flows. Handle applies Headers and Cookies
+// before writing the success envelope with Body as data; Status 0 means 200.
+// It can be returned as Response or *Response.
+//
+// Example:
+//
+//	return handler.Response{
+//		Status:  http.StatusCreated,
+//		Headers: http.Header{"Location": {"/users/" + id}},
+//		Body:    user,
+//	}, nil
+type Response struct {
+	Status  int
+	Headers http.Header
+	Body    any
+	Cookies []*http.Cookie
+}
+
+// apply sets the headers and cookies on w and returns the status and payload to write.
+func (r Response) apply(w http.ResponseWriter) (statusCode int, payload any) {
+	h := w.Header()
+	for name, values := range r.Headers {
+		h[http.CanonicalHeaderKey(name)] = append([]string(nil), values...)
+	}
+	for _, c := range r.Cookies {
+		http.SetCookie(w, c)
+	}
+	statusCode = r.Status
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+	return statusCode, r.Body
+}
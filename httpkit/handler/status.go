@@ -1,40 +1,75 @@
 package handler
 
 import (
-	"errors"
 	"net/http"
+	"sync"
 
 	"github.com/biairmal/go-sdk/errorz"
 )
 
-var defaultCodeToStatus = map[string]int{
-	errorz.CodeNotFound:             http.StatusNotFound,
-	errorz.CodeBadRequest:           http.StatusBadRequest,
-	errorz.CodeInternal:             http.StatusInternalServerError,
-	errorz.CodeUnauthorized:         http.StatusUnauthorized,
-	errorz.CodeForbidden:            http.StatusForbidden,
-	errorz.CodeTooManyRequests:      http.StatusTooManyRequests,
-	errorz.CodeBadGateway:           http.StatusBadGateway,
-	errorz.CodeServiceUnavailable:   http.StatusServiceUnavailable,
-	errorz.CodeUnprocessableEntity:  http.StatusUnprocessableEntity,
-	errorz.CodeConflict:             http.StatusConflict,
-	errorz.CodePreconditionFailed:   http.StatusPreconditionFailed,
-	errorz.CodePreconditionRequired: http.StatusPreconditionRequired,
-	errorz.CodePreconditionNotMet:   http.StatusPreconditionFailed,
+// registeredStatuses holds the code mappings added with RegisterStatusCode.
+var (
+	registeredMu       sync.RWMutex
+	registeredStatuses = map[string]int{}
+)
+
+// RegisterStatusCode maps an application error code to an HTTP status for
+// StatusCodeFromError, e.g. RegisterStatusCode("ERR_PAYMENT_DECLINED", http.StatusPaymentRequired).
+// Registered codes take precedence over the errorz defaults, but not over an
+// explicit errorz.Error.WithHTTPStatus. Call it during initialization; it is safe
+// for concurrent use.
+func RegisterStatusCode(code string, status int) {
+	registeredMu.Lock()
+	defer registeredMu.Unlock()
+	registeredStatuses[code] = status
 }
 
 // StatusCodeFromError returns the HTTP status code for the given error.
-// If the error is a *errorz.Error, its Code is looked up in the default map.
-// Otherwise it returns http.StatusInternalServerError.
+// If the error is a *errorz.Error, its explicit HTTPStatus is used when set;
+// otherwise its Code is looked up in the codes added with RegisterStatusCode and
+// then in the default map (see errorz.Error.StatusCode).
+// Any other error returns http.StatusInternalServerError.
 func StatusCodeFromError(err error) int {
 	if err == nil {
 		return http.StatusOK
 	}
-	var errz *errorz.Error
-	if errors.As(err, &errz) && errz != nil && errz.Code != "" {
-		if status, ok := defaultCodeToStatus[errz.Code]; ok {
-			return status
+	if errz, ok := errorz.AsError(err); ok {
+		if errz.HTTPStatus == 0 {
+			registeredMu.RLock()
+			status, ok := registeredStatuses[errz.Code]
+			registeredMu.RUnlock()
+			if ok {
+				return status
+			}
 		}
+		return errz.StatusCode()
 	}
 	return http.StatusInternalServerError
 }
+
+// StatusMapper maps an error to an HTTP status for a single handler. Returning
+// ok == false falls back to StatusCodeFromError.
+type StatusMapper func(err error) (status int, ok bool)
+
+// CodeStatuses returns a StatusMapper that looks up the errorz code of err in m.
+// Errors with an explicit HTTPStatus, other codes and non-errorz errors fall back.
+func CodeStatuses(m map[string]int) StatusMapper {
+	return func(err error) (int, bool) {
+		errz, ok := errorz.AsError(err)
+		if !ok || errz.HTTPStatus != 0 {
+			return 0, false
+		}
+		status, ok := m[errz.Code]
+		return status, ok
+	}
+}
+
+// statusFor returns the status for err using mapper first, when set.
+func statusFor(err error, mapper StatusMapper) int {
+	if mapper != nil {
+		if status, ok := mapper(err); ok {
+			return status
+		}
+	}
+	return StatusCodeFromError(err)
+}
@@ -2,7 +2,9 @@ package handler
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/biairmal/go-sdk/errorz"
@@ -22,7 +24,12 @@ func TestStatusCodeFromError(t *testing.T) {
 		{"errorz Unauthorized", errorz.Unauthorized(), http.StatusUnauthorized},
 		{"errorz Forbidden", errorz.Forbidden(), http.StatusForbidden},
 		{"errorz UnprocessableEntity", errorz.UnprocessableEntity(), http.StatusUnprocessableEntity},
+		{"errorz MethodNotAllowed", errorz.MethodNotAllowed(), http.StatusMethodNotAllowed},
+		{"errorz NotAcceptable", errorz.NotAcceptable(), http.StatusNotAcceptable},
+		{"errorz UnsupportedMediaType", errorz.UnsupportedMediaType(), http.StatusUnsupportedMediaType},
 		{"errorz with unknown code", errorz.New("x").WithCode("UNKNOWN"), http.StatusInternalServerError},
+		{"errorz with explicit status", errorz.New("x").WithHTTPStatus(http.StatusTeapot), http.StatusTeapot},
+		{"wrapped errorz", fmt.Errorf("wrap: %w", errorz.Conflict()), http.StatusConflict},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -33,3 +40,58 @@ func TestStatusCodeFromError(t *testing.T) {
 		})
 	}
 }
+
+func TestRegisterStatusCode(t *testing.T) {
+	const code = "ERR_PAYMENT_DECLINED"
+	RegisterStatusCode(code, http.StatusPaymentRequired)
+	t.Cleanup(func() {
+		registeredMu.Lock()
+		delete(registeredStatuses, code)
+		registeredMu.Unlock()
+	})
+
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+	}{
+		{"registered code", errorz.New("declined").WithCode(code), http.StatusPaymentRequired},
+		{"wrapped registered code", fmt.Errorf("pay: %w", errorz.New("declined").WithCode(code)), http.StatusPaymentRequired},
+		{"explicit status wins", errorz.New("x").WithCode(code).WithHTTPStatus(http.StatusConflict), http.StatusConflict},
+		{"default codes unchanged", errorz.NotFound(), http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StatusCodeFromError(tt.err); got != tt.wantCode {
+				t.Errorf("StatusCodeFromError() = %v, want %v", got, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestHandle_withStatusMapper(t *testing.T) {
+	mapper := CodeStatuses(map[string]int{
+		"ERR_PAYMENT_DECLINED": http.StatusPaymentRequired,
+		errorz.CodeNotFound:    http.StatusGone,
+	})
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+	}{
+		{"mapped app code", errorz.New("declined").WithCode("ERR_PAYMENT_DECLINED"), http.StatusPaymentRequired},
+		{"mapped default code", errorz.NotFound(), http.StatusGone},
+		{"unmapped falls back", errorz.BadRequest(), http.StatusBadRequest},
+		{"plain error falls back", errors.New("boom"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := Handle(func(*http.Request) (any, error) { return nil, tt.err }, WithStatusMapper(mapper))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+			if w.Code != tt.wantCode {
+				t.Errorf("status = %v, want %v", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
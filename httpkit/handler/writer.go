@@ -13,24 +13,42 @@ import (
 
 // WriteSuccessResponse writes a success response using the standard envelope.
 func WriteSuccessResponse(w http.ResponseWriter, statusCode int, data any) {
+	writeSuccess(w, statusCode, data, response.JSON)
+}
+
+// WriteErrorResponse writes an error response using the standard envelope
+// and ErrorPayload from the given error.
+func WriteErrorResponse(w http.ResponseWriter, statusCode int, err any) {
+	writeError(w, statusCode, err, response.JSON)
+}
+
+// encodeFunc writes an envelope, e.g. response.JSON or a response.Respond bound to a request.
+type encodeFunc func(w http.ResponseWriter, statusCode int, data any)
+
+// negotiated returns an encodeFunc that writes in the encoding negotiated from r's Accept header.
+func negotiated(r *http.Request) encodeFunc {
+	return func(w http.ResponseWriter, statusCode int, data any) {
+		response.Respond(w, r, statusCode, data)
+	}
+}
+
+func writeSuccess(w http.ResponseWriter, statusCode int, data any, encode encodeFunc) {
 	if statusCode == http.StatusNoContent {
 		w.WriteHeader(http.StatusNoContent)
 		return
 	}
-	response.JSON(w, statusCode, response.BaseResponse[any]{
-		Code:      "OK",
-		Message:   "success",
+	encode(w, statusCode, response.BaseResponse[any]{
+		Code:      response.CodeOK,
+		Message:   response.MessageSuccess,
 		Timestamp: time.Now(),
 		Data:      data,
 	})
 }
 
-// WriteErrorResponse writes an error response using the standard envelope
-// and ErrorPayload from the given error.
-func WriteErrorResponse(w http.ResponseWriter, statusCode int, err any) {
+func writeError(w http.ResponseWriter, statusCode int, err any, encode encodeFunc) {
 	payload := response.ErrorFromErr(toError(err))
-	response.JSON(w, statusCode, response.BaseResponse[any]{
-		Code:      "ERROR",
+	encode(w, statusCode, response.BaseResponse[any]{
+		Code:      response.CodeError,
 		Message:   payload.Message,
 		Timestamp: time.Now(),
 		Error:     payload,
@@ -4,12 +4,27 @@ import (
 	"bytes"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/biairmal/go-sdk/logger"
 )
 
+// LogFormat selects how the logging middleware writes the response log.
+type LogFormat string
+
+const (
+	// LogFormatStructured logs the response as structured fields (the default).
+	LogFormatStructured LogFormat = "structured"
+	// LogFormatCombined logs the response as one access-log line in Apache combined
+	// format, followed by the duration in milliseconds, e.g.
+	// 10.0.0.1 - - [02/Jan/2006:15:04:05 -0700] "GET /users HTTP/1.1" 200 512 "-" "curl/8.5.0" 3ms
+	LogFormatCombined LogFormat = "combined"
+	// LogFormatBoth logs the access-log line as the message of the structured response log.
+	LogFormatBoth LogFormat = "both"
+)
+
 // LoggingOptions controls what the logging middleware logs.
 // Nil means default: log request and response with full info including bodies.
 type LoggingOptions struct {
@@ -24,37 +39,73 @@ type LoggingOptions struct {
 	// MaxBodyBytesForLogging limits how many bytes of request/response body are logged.
 	// Zero means no limit. For example 4096 logs the first 4KB only.
 	MaxBodyBytesForLogging int
+	// LogRequestHeaders includes the request headers in the request log, with
+	// SensitiveHeaders redacted.
+	LogRequestHeaders bool
+
+	// SkipPaths lists request paths that are not logged, e.g. health probes. An
+	// entry matches the path exactly, or as a prefix when it ends with "*"
+	// ("/debug/*" skips everything under /debug/).
+	SkipPaths []string
+	// Skipper, when set, skips logging for requests it returns true for, in
+	// addition to SkipPaths.
+	Skipper func(r *http.Request) bool
+	// SensitiveHeaders lists headers whose values are logged as "[REDACTED]".
+	// Nil means DefaultSensitiveHeaders; use an empty slice to log all values.
+	SensitiveHeaders []string
+	// RedactBodyFields lists JSON field names (case-insensitive, at any depth)
+	// whose scalar values are logged as "[REDACTED]" in request and response
+	// bodies, e.g. "password" or "token". Non-JSON bodies are logged unchanged.
+	RedactBodyFields []string
+	// Format selects how the response is logged (see LogFormat). Empty means
+	// LogFormatStructured. It needs LogResponse and does not change the request log.
+	Format LogFormat
 }
 
+// DefaultSensitiveHeaders are redacted when LoggingOptions.SensitiveHeaders is nil.
+var DefaultSensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie"}
+
 func defaultLoggingOptions() *LoggingOptions {
 	return &LoggingOptions{
-		LogRequest:      true,
-		LogResponse:     true,
-		LogRequestBody:  true,
-		LogResponseBody: true,
+		LogRequest:        true,
+		LogResponse:       true,
+		LogRequestBody:    true,
+		LogResponseBody:   true,
+		LogRequestHeaders: true,
 	}
 }
 
 // Logging returns a middleware that logs requests and responses using the given logger.
-// If opts is nil, defaults are used (log request and response with path, IP, method, body).
+// If opts is nil, defaults are used (log request and response with path, IP, method,
+// headers and body; DefaultSensitiveHeaders are redacted).
 func Logging(log logger.Logger, opts *LoggingOptions) func(http.Handler) http.Handler {
 	if opts == nil {
 		opts = defaultLoggingOptions()
 	}
+	red := newRedactor(opts)
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if skipLogging(r, opts) {
+				next.ServeHTTP(w, r)
+				return
+			}
 			start := time.Now()
 			path, clientIPAddr, method := requestMeta(r)
-			reqBody := maybeReadRequestBody(r, opts)
-			maybeLogRequest(log, r, opts, path, clientIPAddr, method, reqBody)
+			reqBody := maybeReadRequestBody(r, opts, red)
+			maybeLogRequest(log, r, opts, red, path, clientIPAddr, method, reqBody)
 
 			var capture *responseCapture
 			if opts.LogResponse {
-				capture = &responseCapture{ResponseWriter: w, status: http.StatusOK}
+				capture = &responseCapture{
+					ResponseWriter: w,
+					status:         http.StatusOK,
+					captureBody:    opts.LogResponseBody,
+					limit:          opts.MaxBodyBytesForLogging,
+				}
 				w = capture
 			}
 			next.ServeHTTP(w, r)
-			maybeLogResponse(log, r, opts, path, clientIPAddr, method, start, capture)
+			maybeLogResponse(log, r, opts, red, path, clientIPAddr, method, start, capture)
 		})
 	}
 }
@@ -67,7 +118,21 @@ func requestMeta(r *http.Request) (path, clientIPAddr, method string) {
 	return path, clientIP(r), r.Method
 }
 
-func maybeReadRequestBody(r *http.Request, opts *LoggingOptions) []byte {
+// skipLogging reports whether r matches opts.SkipPaths or opts.Skipper.
+func skipLogging(r *http.Request, opts *LoggingOptions) bool {
+	for _, p := range opts.SkipPaths {
+		if prefix, ok := strings.CutSuffix(p, "*"); ok {
+			if strings.HasPrefix(r.URL.Path, prefix) {
+				return true
+			}
+		} else if r.URL.Path == p {
+			return true
+		}
+	}
+	return opts.Skipper != nil && opts.Skipper(r)
+}
+
+func maybeReadRequestBody(r *http.Request, opts *LoggingOptions, red *redactor) []byte {
 	if !opts.LogRequest || !opts.LogRequestBody || r.Body == nil {
 		return nil
 	}
@@ -76,11 +141,11 @@ func maybeReadRequestBody(r *http.Request, opts *LoggingOptions) []byte {
 		body = nil
 	}
 	r.Body = io.NopCloser(bytes.NewReader(body))
-	return truncateForLog(body, opts.MaxBodyBytesForLogging)
+	return truncateForLog(red.body(body), opts.MaxBodyBytesForLogging)
 }
 
 func maybeLogRequest(
-	log logger.Logger, r *http.Request, opts *LoggingOptions,
+	log logger.Logger, r *http.Request, opts *LoggingOptions, red *redactor,
 	path, clientIPAddr, method string, reqBody []byte,
 ) {
 	if !opts.LogRequest {
@@ -91,6 +156,9 @@ func maybeLogRequest(
 		logger.F("ip", clientIPAddr),
 		logger.F("method", method),
 	}
+	if opts.LogRequestHeaders && len(r.Header) > 0 {
+		fields = append(fields, logger.F("headers", red.headers(r.Header)))
+	}
 	if len(reqBody) > 0 {
 		fields = append(fields, logger.F("body", string(reqBody)))
 	}
@@ -98,37 +166,82 @@ func maybeLogRequest(
 }
 
 func maybeLogResponse(
-	log logger.Logger, r *http.Request, opts *LoggingOptions,
+	log logger.Logger, r *http.Request, opts *LoggingOptions, red *redactor,
 	path, clientIPAddr, method string, start time.Time, capture *responseCapture,
 ) {
 	if !opts.LogResponse || capture == nil {
 		return
 	}
+	duration := time.Since(start)
+	msg := "http response"
+	switch opts.Format {
+	case LogFormatCombined:
+		log.InfoWithContext(r.Context(), accessLogLine(r, path, clientIPAddr, method, start, duration, capture))
+		return
+	case LogFormatBoth:
+		msg = accessLogLine(r, path, clientIPAddr, method, start, duration, capture)
+	}
 	fields := []logger.Field{
 		logger.F("path", path),
 		logger.F("ip", clientIPAddr),
 		logger.F("method", method),
 		logger.F("status", capture.status),
-		logger.F("duration_ms", time.Since(start).Milliseconds()),
+		logger.Duration("duration_ms", duration),
 	}
 	if opts.LogResponseBody && capture.buf.Len() > 0 {
-		body := truncateForLog(capture.buf.Bytes(), opts.MaxBodyBytesForLogging)
+		body := truncateForLog(red.body(capture.buf.Bytes()), opts.MaxBodyBytesForLogging)
 		fields = append(fields, logger.F("body", string(body)))
 	}
-	log.InfoWithContext(r.Context(), "http response", fields...)
+	log.InfoWithContext(r.Context(), msg, fields...)
+}
+
+// accessLogLine formats the response as an Apache combined log line followed by
+// the duration in milliseconds. Missing values are written as "-".
+func accessLogLine(
+	r *http.Request, path, clientIPAddr, method string, start time.Time, duration time.Duration,
+	capture *responseCapture,
+) string {
+	size := "-"
+	if capture.bytes > 0 {
+		size = strconv.FormatInt(capture.bytes, 10)
+	}
+	var b strings.Builder
+	b.WriteString(orDash(clientIPAddr))
+	b.WriteString(" - - [")
+	b.WriteString(start.Format("02/Jan/2006:15:04:05 -0700"))
+	b.WriteString("] ")
+	b.WriteString(strconv.Quote(method + " " + path + " " + r.Proto))
+	b.WriteString(" " + strconv.Itoa(capture.status) + " " + size + " ")
+	b.WriteString(strconv.Quote(orDash(r.Referer())))
+	b.WriteString(" ")
+	b.WriteString(strconv.Quote(orDash(r.UserAgent())))
+	b.WriteString(" " + strconv.FormatInt(duration.Milliseconds(), 10) + "ms")
+	return b.String()
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
 }
 
 func clientIP(r *http.Request) string {
+	if s := forwardedIP(r); s != "" {
+		return s
+	}
+	return r.RemoteAddr
+}
+
+// forwardedIP returns the first X-Forwarded-For entry, else X-Real-IP, else "".
+func forwardedIP(r *http.Request) string {
 	if s := r.Header.Get("X-Forwarded-For"); s != "" {
 		if i := strings.Index(s, ","); i >= 0 {
 			return strings.TrimSpace(s[:i])
 		}
 		return strings.TrimSpace(s)
 	}
-	if s := r.Header.Get("X-Real-IP"); s != "" {
-		return s
-	}
-	return r.RemoteAddr
+	return r.Header.Get("X-Real-IP")
 }
 
 func truncateForLog(b []byte, limit int) []byte {
@@ -138,11 +251,17 @@ func truncateForLog(b []byte, limit int) []byte {
 	return b[:limit]
 }
 
+// responseCapture records the status, the number of bytes written and, when captureBody is set, up to limit
+// bytes of the body (zero means no limit); everything is passed through to the
+// ResponseWriter unbuffered.
 type responseCapture struct {
 	http.ResponseWriter
-	status int
-	buf    bytes.Buffer
-	wrote  bool
+	status      int
+	bytes       int64
+	buf         bytes.Buffer
+	wrote       bool
+	captureBody bool
+	limit       int
 }
 
 func (c *responseCapture) WriteHeader(code int) {
@@ -157,8 +276,16 @@ func (c *responseCapture) Write(p []byte) (n int, err error) {
 	if !c.wrote {
 		c.WriteHeader(http.StatusOK)
 	}
-	c.buf.Write(p)
-	return c.ResponseWriter.Write(p)
+	if c.captureBody {
+		keep := p
+		if c.limit > 0 {
+			keep = p[:min(len(p), max(c.limit-c.buf.Len(), 0))]
+		}
+		c.buf.Write(keep)
+	}
+	n, err = c.ResponseWriter.Write(p)
+	c.bytes += int64(n)
+	return n, err
 }
 
 // Unwrap allows middleware to expose the underlying ResponseWriter for optional checks.
@@ -0,0 +1,176 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/biairmal/go-sdk/logger"
+)
+
+// logRequest serves req through Logging(opts) and a handler writing respBody, returning the log output.
+func logRequest(t *testing.T, opts *LoggingOptions, req *http.Request, respBody string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	log := logger.NewZerologWithWriter(&buf, nil)
+	h := Logging(log, opts)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(respBody))
+	}))
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	return buf.String()
+}
+
+func TestLogging_skip(t *testing.T) {
+	opts := &LoggingOptions{
+		LogRequest:  true,
+		LogResponse: true,
+		SkipPaths:   []string{"/health", "/debug/*"},
+		Skipper:     func(r *http.Request) bool { return r.Method == http.MethodOptions },
+	}
+	tests := []struct {
+		method, path string
+		wantLogged   bool
+	}{
+		{http.MethodGet, "/health", false},
+		{http.MethodGet, "/healthz", true},
+		{http.MethodGet, "/debug/pprof/heap", false},
+		{http.MethodOptions, "/api/users", false},
+		{http.MethodGet, "/api/users", true},
+	}
+	for _, tt := range tests {
+		out := logRequest(t, opts, httptest.NewRequest(tt.method, tt.path, http.NoBody), "ok")
+		if logged := out != ""; logged != tt.wantLogged {
+			t.Errorf("%s %s logged = %v, want %v", tt.method, tt.path, logged, tt.wantLogged)
+		}
+	}
+}
+
+func TestLogging_redactsHeadersByDefault(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	req.Header.Set("Authorization", "Bearer secret-token")
+	req.Header.Set("X-Trace", "abc")
+
+	out := logRequest(t, nil, req, "")
+	if strings.Contains(out, "secret-token") {
+		t.Errorf("log leaks the Authorization header: %s", out)
+	}
+	if !strings.Contains(out, `"Authorization":"[REDACTED]"`) || !strings.Contains(out, `"X-Trace":"abc"`) {
+		t.Errorf("headers not logged as expected: %s", out)
+	}
+}
+
+func TestLogging_sensitiveHeadersOverride(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	req.Header.Set("Authorization", "Bearer visible")
+	req.Header.Set("X-Api-Key", "k-123")
+
+	opts := defaultLoggingOptions()
+	opts.SensitiveHeaders = []string{"x-api-key"}
+	out := logRequest(t, opts, req, "")
+	if !strings.Contains(out, "Bearer visible") || strings.Contains(out, "k-123") {
+		t.Errorf("SensitiveHeaders override not applied: %s", out)
+	}
+}
+
+func TestLogging_redactBodyFields(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/login",
+		strings.NewReader(`{"user":"ann","Password":"hunter2","nested":{"token":"t-1","n":42}}`))
+	opts := defaultLoggingOptions()
+	opts.RedactBodyFields = []string{"password", "token", "pin"}
+
+	out := logRequest(t, opts, req, `{"token": "t-2", "pin": 9876}`)
+	for _, secret := range []string{"hunter2", "t-1", "t-2", "9876"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("log leaks %q: %s", secret, out)
+		}
+	}
+	if !strings.Contains(out, `ann`) || !strings.Contains(out, `42`) {
+		t.Errorf("non-sensitive fields were redacted: %s", out)
+	}
+}
+
+func TestRedactor_truncatedBody(t *testing.T) {
+	red := newRedactor(&LoggingOptions{RedactBodyFields: []string{"password"}})
+	got := string(red.body([]byte(`{"user":"ann","password":"hunt`)))
+	if want := `{"user":"ann","password":"[REDACTED]"`; got != want {
+		t.Errorf("body() = %s, want %s", got, want)
+	}
+}
+
+func TestResponseCapture_boundedBuffer(t *testing.T) {
+	chunk := bytes.Repeat([]byte("x"), 64*1024)
+	tests := []struct {
+		name    string
+		capture responseCapture
+		wantBuf int
+	}{
+		{"capped", responseCapture{captureBody: true, limit: 16}, 16},
+		{"body not logged", responseCapture{captureBody: false, limit: 16}, 0},
+		{"no cap", responseCapture{captureBody: true}, 16 * len(chunk)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			c := tt.capture
+			c.ResponseWriter = rec
+			for i := 0; i < 16; i++ {
+				if n, err := c.Write(chunk); n != len(chunk) || err != nil {
+					t.Fatalf("Write() = %d, %v", n, err)
+				}
+			}
+			if c.buf.Len() != tt.wantBuf {
+				t.Errorf("buffered %d bytes, want %d", c.buf.Len(), tt.wantBuf)
+			}
+			if rec.Body.Len() != 16*len(chunk) {
+				t.Errorf("client got %d bytes, want %d", rec.Body.Len(), 16*len(chunk))
+			}
+		})
+	}
+}
+
+func TestLogging_largeResponseBodyCapped(t *testing.T) {
+	opts := defaultLoggingOptions()
+	opts.MaxBodyBytesForLogging = 8
+	out := logRequest(t, opts, httptest.NewRequest(http.MethodGet, "/download", http.NoBody),
+		strings.Repeat("abcdefgh", 128*1024))
+	if !strings.Contains(out, `"body":"abcdefgh"`) {
+		t.Errorf("response body not logged with the 8-byte cap: %.300s", out)
+	}
+}
+
+func TestLogging_format(t *testing.T) {
+	tests := []struct {
+		format         LogFormat
+		wantStructured bool
+	}{
+		{LogFormatCombined, false},
+		{LogFormatBoth, true},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/users?page=2", http.NoBody)
+			req.Header.Set("X-Forwarded-For", "10.0.0.1")
+			req.Header.Set("User-Agent", "curl/8.5.0")
+			opts := &LoggingOptions{LogResponse: true, Format: tt.format}
+
+			var entry map[string]any
+			if err := json.Unmarshal([]byte(logRequest(t, opts, req, "created")), &entry); err != nil {
+				t.Fatalf("log output is not one JSON entry: %v", err)
+			}
+			line, _ := entry["message"].(string)
+			re := regexp.MustCompile(
+				`^10\.0\.0\.1 - - \[\d{2}/\w{3}/\d{4}:\d{2}:\d{2}:\d{2} [+-]\d{4}\] ` +
+					`"POST /users HTTP/1\.1" 200 7 "-" "curl/8\.5\.0" \d+ms$`)
+			if !re.MatchString(line) {
+				t.Errorf("access log line = %q", line)
+			}
+			if _, ok := entry["status"]; ok != tt.wantStructured {
+				t.Errorf("structured fields logged = %v, want %v: %v", ok, tt.wantStructured, entry)
+			}
+		})
+	}
+}
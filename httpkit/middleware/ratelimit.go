@@ -0,0 +1,167 @@
+package middleware
+
+import (
+	"context"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/biairmal/go-sdk/errorz"
+	"github.com/biairmal/go-sdk/httpkit/handler"
+)
+
+// Limiter decides whether a request for a client key may proceed. Implementations
+// must be safe for concurrent use; MemoryLimiter is the in-process default, and a
+// shared store (e.g. Redis) can implement Limiter to limit across instances.
+type Limiter interface {
+	// Allow consumes one request for key. When the request is denied, retryAfter
+	// is how long the client should wait before trying again (zero if unknown).
+	Allow(ctx context.Context, key string) (allowed bool, retryAfter time.Duration, err error)
+}
+
+// RateLimitOptions controls the rate limiting middleware.
+// Nil means default: key requests by the IP of RemoteAddr.
+type RateLimitOptions struct {
+	// KeyFunc returns the key requests are limited by, e.g. an API key or user ID.
+	// Default: the IP of RemoteAddr without the port (see TrustProxyHeaders).
+	KeyFunc func(r *http.Request) string
+	// TrustProxyHeaders makes the default key prefer X-Forwarded-For, then
+	// X-Real-IP, over RemoteAddr. Enable it only behind a proxy that sets these
+	// headers; otherwise clients can pick their own key. Ignored with KeyFunc.
+	TrustProxyHeaders bool
+	// OnError is called when the limiter returns an error; the request is then let
+	// through (fail open) so a limiter outage does not take the service down. Optional.
+	OnError func(r *http.Request, err error)
+}
+
+// RateLimit returns a middleware that consults limiter for every request. Denied
+// requests get a 429 with errorz.TooManyRequests() in the error envelope and a
+// Retry-After header (whole seconds, at least 1) when the limiter reports a wait.
+func RateLimit(limiter Limiter, opts *RateLimitOptions) func(http.Handler) http.Handler {
+	if opts == nil {
+		opts = &RateLimitOptions{}
+	}
+	keyFunc := opts.KeyFunc
+	if keyFunc == nil {
+		keyFunc = remoteIP
+		if opts.TrustProxyHeaders {
+			keyFunc = proxiedIP
+		}
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			allowed, retryAfter, err := limiter.Allow(r.Context(), keyFunc(r))
+			if err != nil {
+				if opts.OnError != nil {
+					opts.OnError(r, err)
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+			if !allowed {
+				if retryAfter > 0 {
+					secs := int(math.Ceil(retryAfter.Seconds()))
+					w.Header().Set("Retry-After", strconv.Itoa(max(secs, 1)))
+				}
+				handler.WriteErrorResponse(w, http.StatusTooManyRequests, errorz.TooManyRequests())
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// remoteIP returns the host part of r.RemoteAddr, so every connection from one
+// client shares a key; RemoteAddr is returned as is when it has no port.
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// proxiedIP returns the client IP reported by X-Forwarded-For or X-Real-IP,
+// falling back to remoteIP.
+func proxiedIP(r *http.Request) string {
+	if ip := forwardedIP(r); ip != "" {
+		return ip
+	}
+	return remoteIP(r)
+}
+
+// MemoryLimiter is an in-memory token-bucket Limiter with one rate.Limiter per key.
+// Keys idle for longer than the idle TTL are evicted so the map does not grow
+// without bound. Limits are per process; use a shared Limiter for multiple instances.
+type MemoryLimiter struct {
+	limit   rate.Limit
+	burst   int
+	idleTTL time.Duration
+	now     func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// defaultIdleTTL is how long MemoryLimiter keeps the bucket of an idle key.
+const defaultIdleTTL = 10 * time.Minute
+
+// NewMemoryLimiter returns a MemoryLimiter allowing limit requests per second per
+// key with bursts of up to burst requests, e.g. NewMemoryLimiter(rate.Every(time.Second), 5).
+func NewMemoryLimiter(limit rate.Limit, burst int) *MemoryLimiter {
+	return &MemoryLimiter{
+		limit:   limit,
+		burst:   burst,
+		idleTTL: defaultIdleTTL,
+		now:     time.Now,
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// Allow implements Limiter. It never returns an error.
+func (l *MemoryLimiter) Allow(_ context.Context, key string) (allowed bool, retryAfter time.Duration, err error) {
+	now := l.now()
+	l.mu.Lock()
+	l.sweep(now)
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{limiter: rate.NewLimiter(l.limit, l.burst)}
+		l.buckets[key] = b
+	}
+	b.lastSeen = now
+	l.mu.Unlock()
+
+	res := b.limiter.ReserveN(now, 1)
+	if !res.OK() {
+		return false, 0, nil
+	}
+	if delay := res.DelayFrom(now); delay > 0 {
+		res.CancelAt(now)
+		return false, delay, nil
+	}
+	return true, 0, nil
+}
+
+// sweep evicts idle buckets at most once per idle TTL; l.mu must be held.
+func (l *MemoryLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.idleTTL {
+		return
+	}
+	for key, b := range l.buckets {
+		if now.Sub(b.lastSeen) >= l.idleTTL {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
@@ -0,0 +1,169 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+func rateLimited(limiter Limiter, opts *RateLimitOptions) http.Handler {
+	return RateLimit(limiter, opts)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+func requestFrom(ip string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	req.RemoteAddr = ip
+	return req
+}
+
+func TestRateLimit_allowThenDeny(t *testing.T) {
+	h := rateLimited(NewMemoryLimiter(rate.Every(time.Minute), 2), nil)
+
+	for i, want := range []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests} {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, requestFrom("10.0.0.1:1234"))
+		if w.Code != want {
+			t.Fatalf("request %d: status = %d, want %d", i+1, w.Code, want)
+		}
+		if want != http.StatusTooManyRequests {
+			continue
+		}
+		if got := w.Header().Get("Retry-After"); got == "" || got == "0" {
+			t.Errorf("Retry-After = %q, want a positive number of seconds", got)
+		}
+		if !strings.Contains(w.Body.String(), `"code":"ERR_TOO_MANY_REQUESTS"`) {
+			t.Errorf("body = %s, want the error envelope with ERR_TOO_MANY_REQUESTS", w.Body.String())
+		}
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, requestFrom("10.0.0.2:1234"))
+	if w.Code != http.StatusOK {
+		t.Errorf("other client: status = %d, want 200", w.Code)
+	}
+}
+
+func TestRateLimit_keyFunc(t *testing.T) {
+	h := rateLimited(NewMemoryLimiter(rate.Every(time.Minute), 1), &RateLimitOptions{
+		KeyFunc: func(r *http.Request) string { return r.Header.Get("X-API-Key") },
+	})
+
+	// Same API key from two IPs shares one bucket.
+	for i, ip := range []string{"10.0.0.1:1", "10.0.0.2:1"} {
+		req := requestFrom(ip)
+		req.Header.Set("X-API-Key", "key-a")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		if want := []int{http.StatusOK, http.StatusTooManyRequests}[i]; w.Code != want {
+			t.Errorf("request from %s: status = %d, want %d", ip, w.Code, want)
+		}
+	}
+}
+
+func TestRateLimit_defaultKey(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   *RateLimitOptions
+		first  *http.Request
+		second *http.Request
+		want   int
+	}{
+		{
+			name:   "same host on different ports shares a bucket",
+			first:  requestFrom("10.0.0.1:50001"),
+			second: requestFrom("10.0.0.1:50002"),
+			want:   http.StatusTooManyRequests,
+		},
+		{
+			name:   "forwarding headers are ignored by default",
+			first:  requestFrom("10.0.0.1:50001"),
+			second: withHeader(requestFrom("10.0.0.1:50002"), "X-Forwarded-For", "203.0.113.9"),
+			want:   http.StatusTooManyRequests,
+		},
+		{
+			name:   "forwarding headers are used with TrustProxyHeaders",
+			opts:   &RateLimitOptions{TrustProxyHeaders: true},
+			first:  withHeader(requestFrom("10.0.0.1:50001"), "X-Forwarded-For", "203.0.113.9, 10.0.0.1"),
+			second: withHeader(requestFrom("10.0.0.1:50002"), "X-Real-IP", "203.0.113.10"),
+			want:   http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := rateLimited(NewMemoryLimiter(rate.Every(time.Minute), 1), tt.opts)
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, tt.first)
+			if w.Code != http.StatusOK {
+				t.Fatalf("first request: status = %d, want 200", w.Code)
+			}
+			w = httptest.NewRecorder()
+			h.ServeHTTP(w, tt.second)
+			if w.Code != tt.want {
+				t.Errorf("second request: status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
+
+func withHeader(req *http.Request, key, value string) *http.Request {
+	req.Header.Set(key, value)
+	return req
+}
+
+type limiterFunc func(ctx context.Context, key string) (bool, time.Duration, error)
+
+func (f limiterFunc) Allow(ctx context.Context, key string) (bool, time.Duration, error) {
+	return f(ctx, key)
+}
+
+func TestRateLimit_failsOpen(t *testing.T) {
+	boom := errors.New("redis down")
+	var reported error
+	h := rateLimited(limiterFunc(func(context.Context, string) (bool, time.Duration, error) {
+		return false, 0, boom
+	}), &RateLimitOptions{OnError: func(_ *http.Request, err error) { reported = err }})
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, requestFrom("10.0.0.1:1"))
+	if w.Code != http.StatusOK {
+		t.Errorf("status = %d, want 200", w.Code)
+	}
+	if !errors.Is(reported, boom) {
+		t.Errorf("OnError got %v, want %v", reported, boom)
+	}
+}
+
+func TestMemoryLimiter_refillAndEviction(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := NewMemoryLimiter(rate.Every(time.Second), 1)
+	l.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	if ok, _, _ := l.Allow(ctx, "a"); !ok {
+		t.Fatal("first request denied")
+	}
+	ok, retryAfter, _ := l.Allow(ctx, "a")
+	if ok || retryAfter != time.Second {
+		t.Errorf("second request = %v, %v, want denied with 1s", ok, retryAfter)
+	}
+	now = now.Add(time.Second)
+	if ok, _, _ := l.Allow(ctx, "a"); !ok {
+		t.Error("request after refill denied")
+	}
+
+	now = now.Add(defaultIdleTTL)
+	if _, _, err := l.Allow(ctx, "b"); err != nil {
+		t.Fatal(err)
+	}
+	if _, exists := l.buckets["a"]; exists {
+		t.Error("idle key was not evicted")
+	}
+}
@@ -1,9 +1,13 @@
 package middleware
 
 import (
+	"fmt"
 	"net/http"
+	"runtime/debug"
 
+	"github.com/biairmal/go-sdk/errorz"
 	"github.com/biairmal/go-sdk/httpkit/handler"
+	"github.com/biairmal/go-sdk/logger"
 )
 
 // Recover returns a middleware that recovers from panics and writes
@@ -20,3 +24,62 @@ func Recover() func(http.Handler) http.Handler {
 		})
 	}
 }
+
+// Recovery returns a middleware that recovers panics from downstream handlers,
+// logs them at error level with the stack trace (when log is not nil), and writes
+// a 500 with errorz.Internal() in the error envelope. Unlike Recover, the panic
+// value is never sent to the client. If the handler already started the response,
+// only the log is written. http.ErrAbortHandler is re-panicked so net/http can
+// abort the connection as intended.
+//
+// Place Recovery inside Logging (after it in Chain) so the logged response
+// status is the 500 that Recovery writes.
+func Recovery(log logger.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tw := &headerTracker{ResponseWriter: w}
+			defer func() {
+				v := recover()
+				if v == nil {
+					return
+				}
+				if v == http.ErrAbortHandler { //nolint:errorlint // net/http compares the sentinel directly
+					panic(v)
+				}
+				if log != nil {
+					log.ErrorWithContext(r.Context(), "http panic recovered",
+						logger.F("panic", fmt.Sprint(v)),
+						logger.F("stack", string(debug.Stack())),
+						logger.F("path", r.URL.Path),
+						logger.F("method", r.Method),
+					)
+				}
+				if !tw.wroteHeader {
+					handler.WriteErrorResponse(tw, http.StatusInternalServerError, errorz.Internal())
+				}
+			}()
+			next.ServeHTTP(tw, r)
+		})
+	}
+}
+
+// headerTracker records whether the response header has been written.
+type headerTracker struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+func (t *headerTracker) WriteHeader(code int) {
+	t.wroteHeader = true
+	t.ResponseWriter.WriteHeader(code)
+}
+
+func (t *headerTracker) Write(p []byte) (int, error) {
+	t.wroteHeader = true
+	return t.ResponseWriter.Write(p)
+}
+
+// Unwrap allows middleware to expose the underlying ResponseWriter for optional checks.
+func (t *headerTracker) Unwrap() http.ResponseWriter {
+	return t.ResponseWriter
+}
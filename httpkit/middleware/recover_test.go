@@ -1,9 +1,15 @@
 package middleware
 
 import (
+	"bytes"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+
+	"github.com/biairmal/go-sdk/httpkit/response"
+	"github.com/biairmal/go-sdk/logger"
 )
 
 func TestRecover(t *testing.T) {
@@ -34,3 +40,62 @@ func TestRecover_noPanic(t *testing.T) {
 		t.Errorf("status = %v, want 200", w.Code)
 	}
 }
+
+func TestRecovery(t *testing.T) {
+	var buf bytes.Buffer
+	log := logger.NewZerologWithWriter(&buf, nil)
+	panicHandler := http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		panic("db password is hunter2")
+	})
+	h := Chain(panicHandler, Logging(log, &LoggingOptions{LogResponse: true}), Recovery(log))
+	req := httptest.NewRequest(http.MethodGet, "/boom", http.NoBody)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("status = %v, want 500", w.Code)
+	}
+	var body response.BaseResponse[any]
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("body is not JSON: %v: %s", err, w.Body.String())
+	}
+	payload, _ := body.Error.(map[string]any)
+	if payload["code"] != "ERR_INTERNAL" || payload["message"] != "internal server error" {
+		t.Errorf("error payload = %v, want ERR_INTERNAL / internal server error", body.Error)
+	}
+	if strings.Contains(w.Body.String(), "hunter2") {
+		t.Errorf("response leaks the panic value: %s", w.Body.String())
+	}
+
+	logged := buf.String()
+	for _, want := range []string{`"message":"http panic recovered"`, "hunter2", `"stack":`, `"status":500`} {
+		if !strings.Contains(logged, want) {
+			t.Errorf("log does not contain %s: %s", want, logged)
+		}
+	}
+}
+
+func TestRecovery_afterPartialWrite(t *testing.T) {
+	h := Recovery(nil)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte("partial"))
+		panic("late")
+	}))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+	if w.Code != http.StatusAccepted || w.Body.String() != "partial" {
+		t.Errorf("response = %d %q, want the handler's partial response untouched", w.Code, w.Body.String())
+	}
+}
+
+func TestRecovery_abortHandler(t *testing.T) {
+	h := Recovery(nil)(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+	defer func() {
+		if v := recover(); v != http.ErrAbortHandler { //nolint:errorlint // the sentinel itself must be re-panicked
+			t.Errorf("recovered %v, want http.ErrAbortHandler", v)
+		}
+	}()
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+}
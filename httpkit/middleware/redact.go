@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// redacted replaces sensitive values in logs.
+const redacted = "[REDACTED]"
+
+// redactor masks LoggingOptions.SensitiveHeaders and RedactBodyFields in logged values.
+type redactor struct {
+	headerNames map[string]bool // canonical header names
+	bodyFields  *regexp.Regexp  // nil when no body fields are redacted
+}
+
+func newRedactor(opts *LoggingOptions) *redactor {
+	names := opts.SensitiveHeaders
+	if names == nil {
+		names = DefaultSensitiveHeaders
+	}
+	red := &redactor{headerNames: make(map[string]bool, len(names))}
+	for _, name := range names {
+		red.headerNames[http.CanonicalHeaderKey(name)] = true
+	}
+	if len(opts.RedactBodyFields) > 0 {
+		quoted := make([]string, len(opts.RedactBodyFields))
+		for i, f := range opts.RedactBodyFields {
+			quoted[i] = regexp.QuoteMeta(f)
+		}
+		// "field": followed by a string or another scalar; works on truncated JSON too.
+		red.bodyFields = regexp.MustCompile(
+			`(?i)("(?:` + strings.Join(quoted, "|") + `)"\s*:\s*)("(?:[^"\\]|\\.)*"?|[^\s,}\]"{\[]+)`)
+	}
+	return red
+}
+
+// headers returns h as a flat map with sensitive values redacted.
+func (red *redactor) headers(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for name, values := range h {
+		if red.headerNames[http.CanonicalHeaderKey(name)] {
+			out[name] = redacted
+			continue
+		}
+		out[name] = strings.Join(values, ", ")
+	}
+	return out
+}
+
+// body returns b with the values of RedactBodyFields replaced; b itself is not modified.
+func (red *redactor) body(b []byte) []byte {
+	if red.bodyFields == nil || len(b) == 0 {
+		return b
+	}
+	return red.bodyFields.ReplaceAll(b, []byte(`${1}"`+redacted+`"`))
+}
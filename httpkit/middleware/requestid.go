@@ -5,28 +5,54 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"net/http"
-)
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
 
-// requestIDKey is the context key for the request ID.
-// Use it with logger.ContextExtractor to include request_id in logs.
-type requestIDKey struct{}
+	"github.com/biairmal/go-sdk/logger"
+)
 
 // RequestIDKey is the context key for the request ID value.
-// Handlers or logger extractors can use it: ctx.Value(RequestIDKey).
-var RequestIDKey = requestIDKey{}
+// Handlers can read it with ctx.Value(RequestIDKey). It is the same key as
+// logger.RequestIDKey, so the default logger context extractor includes the
+// request ID in logs written via the *WithContext methods.
+var RequestIDKey = logger.RequestIDKey
 
 // RequestIDHeader is the HTTP header name for the request ID (incoming and outgoing).
 const RequestIDHeader = "X-Request-Id"
 
+// RequestIDOption configures the RequestID middleware.
+type RequestIDOption func(*requestIDConfig)
+
+type requestIDConfig struct {
+	generate func() string
+}
+
+// WithGenerator sets the function that creates IDs for requests without an
+// X-Request-Id header, e.g. GenerateUUID or GenerateULID. Default: GenerateHex.
+func WithGenerator(generate func() string) RequestIDOption {
+	return func(c *requestIDConfig) {
+		if generate != nil {
+			c.generate = generate
+		}
+	}
+}
+
 // RequestID returns a middleware that injects a request ID into the context
 // and response header. It reads X-Request-Id from the request if present;
-// otherwise it generates a new random hex string.
-func RequestID() func(http.Handler) http.Handler {
+// otherwise it generates a new ID (a random hex string unless WithGenerator is used).
+func RequestID(opts ...RequestIDOption) func(http.Handler) http.Handler {
+	cfg := requestIDConfig{generate: GenerateHex}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			id := r.Header.Get(RequestIDHeader)
 			if id == "" {
-				id = generateRequestID()
+				id = cfg.generate()
 			}
 			ctx := context.WithValue(r.Context(), RequestIDKey, id)
 			w.Header().Set(RequestIDHeader, id)
@@ -35,10 +61,65 @@ func RequestID() func(http.Handler) http.Handler {
 	}
 }
 
-func generateRequestID() string {
+// GenerateHex returns 16 random bytes as a 32-character hex string. It is the
+// default RequestID generator.
+func GenerateHex() string {
 	b := make([]byte, 16)
 	if _, err := rand.Read(b); err != nil {
-		return "req-fallback"
+		return fallbackRequestID()
 	}
 	return hex.EncodeToString(b)
 }
+
+// GenerateUUID returns a random (version 4) UUID in its canonical 36-character form.
+func GenerateUUID() string {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return fallbackRequestID()
+	}
+	return id.String()
+}
+
+// crockford is the Crockford base32 alphabet used by ULIDs.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// GenerateULID returns a ULID: a 26-character, lexicographically sortable ID made
+// of a 48-bit millisecond timestamp and 80 random bits, in Crockford base32.
+func GenerateULID() string {
+	var b [16]byte
+	ms := uint64(time.Now().UnixMilli()) //nolint:gosec // Unix milliseconds are positive until year 292278994
+	for i := 5; i >= 0; i-- {
+		b[i] = byte(ms)
+		ms >>= 8
+	}
+	if _, err := rand.Read(b[6:]); err != nil {
+		return fallbackRequestID()
+	}
+	return encodeULID(b)
+}
+
+// encodeULID encodes the 128 bits of b as 26 base32 characters, the first one
+// carrying the top 3 bits.
+func encodeULID(b [16]byte) string {
+	hi := uint64(b[0])<<56 | uint64(b[1])<<48 | uint64(b[2])<<40 | uint64(b[3])<<32 |
+		uint64(b[4])<<24 | uint64(b[5])<<16 | uint64(b[6])<<8 | uint64(b[7])
+	lo := uint64(b[8])<<56 | uint64(b[9])<<48 | uint64(b[10])<<40 | uint64(b[11])<<32 |
+		uint64(b[12])<<24 | uint64(b[13])<<16 | uint64(b[14])<<8 | uint64(b[15])
+	out := make([]byte, 26)
+	for i := 25; i >= 0; i-- {
+		out[i] = crockford[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out)
+}
+
+// fallbackCounter makes fallback IDs unique within the process.
+var fallbackCounter atomic.Uint64
+
+// fallbackRequestID returns a unique ID from the time and a counter, for when
+// the random source fails.
+func fallbackRequestID() string {
+	return "req-" + strconv.FormatInt(time.Now().UnixNano(), 36) + "-" +
+		strconv.FormatUint(fallbackCounter.Add(1), 36)
+}
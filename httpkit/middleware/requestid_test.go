@@ -0,0 +1,146 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/biairmal/go-sdk/logger"
+)
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name     string
+		headerID string
+	}{
+		{"uses incoming header", "incoming-id"},
+		{"generates when missing", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ctxID any
+			h := RequestID()(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				ctxID = r.Context().Value(RequestIDKey)
+			}))
+			req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+			if tt.headerID != "" {
+				req.Header.Set(RequestIDHeader, tt.headerID)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, req)
+
+			got := w.Header().Get(RequestIDHeader)
+			if got == "" {
+				t.Fatalf("response %s header is empty", RequestIDHeader)
+			}
+			if tt.headerID != "" && got != tt.headerID {
+				t.Errorf("response %s = %v, want %v", RequestIDHeader, got, tt.headerID)
+			}
+			if ctxID != got {
+				t.Errorf("context request ID = %v, want %v", ctxID, got)
+			}
+		})
+	}
+}
+
+func TestRequestID_defaultLoggerExtractor(t *testing.T) {
+	var buf bytes.Buffer
+	log := logger.NewZerologWithWriter(&buf, nil)
+
+	h := Chain(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			log.InfoWithContext(r.Context(), "handled")
+			w.WriteHeader(http.StatusOK)
+		}),
+		RequestID(),
+		Logging(log, &LoggingOptions{LogResponse: true}),
+	)
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	req.Header.Set(RequestIDHeader, "req-abc")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("logged %d lines, want 2: %s", len(lines), buf.String())
+	}
+	for _, line := range lines {
+		if !strings.Contains(line, `"request_id":"req-abc"`) {
+			t.Errorf("log line %s has no request_id from RequestID middleware", line)
+		}
+	}
+}
+
+func TestRequestID_withGenerator(t *testing.T) {
+	noop := http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})
+	h := RequestID(WithGenerator(func() string { return "fixed-id" }))(noop)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+	if got := w.Header().Get(RequestIDHeader); got != "fixed-id" {
+		t.Errorf("generated ID = %q, want fixed-id", got)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	req.Header.Set(RequestIDHeader, "inbound")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if got := w.Header().Get(RequestIDHeader); got != "inbound" {
+		t.Errorf("ID with inbound header = %q, want inbound", got)
+	}
+}
+
+func TestGenerators(t *testing.T) {
+	tests := []struct {
+		name     string
+		generate func() string
+		pattern  string
+	}{
+		{"hex", GenerateHex, `^[0-9a-f]{32}$`},
+		{"uuid", GenerateUUID, `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`},
+		{"ulid", GenerateULID, `^[0-7][0-9A-HJKMNP-TV-Z]{25}$`},
+		{"fallback", fallbackRequestID, `^req-[0-9a-z]+-[0-9a-z]+$`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			re := regexp.MustCompile(tt.pattern)
+			seen := make(map[string]bool)
+			for i := 0; i < 100; i++ {
+				id := tt.generate()
+				if !re.MatchString(id) {
+					t.Fatalf("ID %q does not match %s", id, tt.pattern)
+				}
+				if seen[id] {
+					t.Fatalf("duplicate ID %q", id)
+				}
+				seen[id] = true
+			}
+		})
+	}
+}
+
+func TestEncodeULID(t *testing.T) {
+	var b [16]byte
+	b[5] = 1 // timestamp 1ms
+	b[15] = 0x1f
+	if got, want := encodeULID(b), "0000000001"+"000000000000000Z"; got != want {
+		t.Errorf("encodeULID() = %s, want %s", got, want)
+	}
+	for i := range b {
+		b[i] = 0xff
+	}
+	if got, want := encodeULID(b), "7ZZZZZZZZZZZZZZZZZZZZZZZZZ"; got != want {
+		t.Errorf("encodeULID(max) = %s, want %s", got, want)
+	}
+}
+
+func TestGenerateULID_sortable(t *testing.T) {
+	first := GenerateULID()
+	time.Sleep(2 * time.Millisecond)
+	if second := GenerateULID(); second[:10] <= first[:10] {
+		t.Errorf("ULID timestamps not increasing: %s then %s", first, second)
+	}
+}
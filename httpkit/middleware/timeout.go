@@ -0,0 +1,158 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/biairmal/go-sdk/errorz"
+	"github.com/biairmal/go-sdk/httpkit/handler"
+)
+
+// Timeout returns a middleware that gives each request a context deadline of d
+// and stops waiting for the handler when it passes. If the handler has not
+// written anything yet, a 503 with errorz.ServiceUnavailable() ("request timed
+// out") is written in the error envelope. If it already started the response,
+// the response is cut off where it is. In both cases later writes by the handler
+// fail with http.ErrHandlerTimeout and are never passed on, so an outer Logging
+// middleware records exactly one status and body. Handlers should watch
+// r.Context() to stop work early. Panics in the handler are re-raised on the
+// serving goroutine, so Recovery placed outside Timeout still catches them.
+func Timeout(d time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := newDeadlineContext(r.Context(), time.Now().Add(d))
+			defer cancel(context.Canceled)
+			timer := time.NewTimer(d)
+			defer timer.Stop()
+
+			tw := &timeoutWriter{w: w, header: make(http.Header)}
+			done := make(chan struct{})
+			panicked := make(chan any, 1)
+			go func() {
+				defer func() {
+					if v := recover(); v != nil {
+						panicked <- v
+					}
+				}()
+				next.ServeHTTP(tw, r.WithContext(ctx))
+				close(done)
+			}()
+
+			select {
+			case v := <-panicked:
+				panic(v)
+			case <-done:
+				tw.finish()
+			case <-timer.C:
+				// Block handler writes before its context reports the deadline, so a
+				// handler reacting to ctx.Done() cannot slip a write in first.
+				tw.timeout()
+				cancel(context.DeadlineExceeded)
+			case <-r.Context().Done():
+				tw.timeout()
+			}
+		})
+	}
+}
+
+// deadlineContext reports a deadline like context.WithDeadline, but is only done
+// when its parent is or when the middleware cancels it, so Timeout controls the
+// order of "stop writes" and "context expired".
+type deadlineContext struct {
+	context.Context
+	deadline time.Time
+}
+
+func newDeadlineContext(parent context.Context, deadline time.Time) (context.Context, context.CancelCauseFunc) {
+	if d, ok := parent.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	ctx, cancel := context.WithCancelCause(parent)
+	return deadlineContext{Context: ctx, deadline: deadline}, cancel
+}
+
+func (c deadlineContext) Deadline() (time.Time, bool) { return c.deadline, true }
+
+// Err returns context.DeadlineExceeded once the timeout fired, like a context
+// created by context.WithDeadline.
+func (c deadlineContext) Err() error {
+	if c.Context.Err() == nil {
+		return nil
+	}
+	if cause := context.Cause(c.Context); errors.Is(cause, context.DeadlineExceeded) {
+		return context.DeadlineExceeded
+	}
+	return c.Context.Err()
+}
+
+// timeoutWriter serializes the handler's writes with the timeout response. The
+// handler sets headers on its own map, which is copied to w when the response starts.
+type timeoutWriter struct {
+	w      http.ResponseWriter
+	header http.Header
+
+	mu          sync.Mutex
+	wroteHeader bool
+	timedOut    bool
+}
+
+func (tw *timeoutWriter) Header() http.Header { return tw.header }
+
+func (tw *timeoutWriter) WriteHeader(code int) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut || tw.wroteHeader {
+		return
+	}
+	tw.writeHeaderLocked(code)
+}
+
+func (tw *timeoutWriter) Write(p []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	if !tw.wroteHeader {
+		tw.writeHeaderLocked(http.StatusOK)
+	}
+	return tw.w.Write(p)
+}
+
+// writeHeaderLocked copies the handler's headers and starts the response; tw.mu must be held.
+func (tw *timeoutWriter) writeHeaderLocked(code int) {
+	tw.copyHeader()
+	tw.wroteHeader = true
+	tw.w.WriteHeader(code)
+}
+
+// finish passes on the headers of a handler that returned without writing.
+func (tw *timeoutWriter) finish() {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if !tw.wroteHeader {
+		tw.copyHeader()
+	}
+}
+
+func (tw *timeoutWriter) copyHeader() {
+	dst := tw.w.Header()
+	for k, v := range tw.header {
+		dst[k] = v
+	}
+}
+
+// timeout blocks further handler writes and writes the timeout response if none started.
+func (tw *timeoutWriter) timeout() {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	tw.timedOut = true
+	if !tw.wroteHeader {
+		tw.wroteHeader = true
+		handler.WriteErrorResponse(tw.w, http.StatusServiceUnavailable,
+			errorz.ServiceUnavailable().WithMessage("request timed out"))
+	}
+}
@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/biairmal/go-sdk/logger"
+)
+
+func TestTimeout_slowHandler(t *testing.T) {
+	var buf bytes.Buffer
+	log := logger.NewZerologWithWriter(&buf, nil)
+	writeErr, ctxErr := make(chan error, 1), make(chan error, 1)
+	slow := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		ctxErr <- r.Context().Err()
+		_, err := w.Write([]byte("too late"))
+		writeErr <- err
+	})
+	opts := &LoggingOptions{LogResponse: true, LogResponseBody: true}
+	h := Chain(slow, Logging(log, opts), Timeout(20*time.Millisecond))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want 503", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), `"message":"request timed out"`) {
+		t.Errorf("body = %s, want the error envelope", w.Body.String())
+	}
+	if err := <-ctxErr; !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("handler ctx.Err() = %v, want context.DeadlineExceeded", err)
+	}
+	if err := <-writeErr; !errors.Is(err, http.ErrHandlerTimeout) {
+		t.Errorf("late Write error = %v, want http.ErrHandlerTimeout", err)
+	}
+	if strings.Contains(w.Body.String(), "too late") {
+		t.Errorf("late write reached the client: %s", w.Body.String())
+	}
+	if !strings.Contains(buf.String(), `"status":503`) {
+		t.Errorf("logged response = %s, want status 503", buf.String())
+	}
+}
+
+func TestTimeout_partialResponse(t *testing.T) {
+	release := make(chan struct{})
+	finished := make(chan struct{})
+	h := Timeout(20 * time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		defer close(finished)
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("part 1;"))
+		<-release
+		_, _ = w.Write([]byte("part 2"))
+	}))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+	close(release)
+	<-finished
+
+	if w.Code != http.StatusOK || w.Body.String() != "part 1;" {
+		t.Errorf("response = %d %q, want 200 %q", w.Code, w.Body.String(), "part 1;")
+	}
+	if w.Header().Get("Content-Type") != "text/plain" {
+		t.Errorf("Content-Type = %q, want text/plain", w.Header().Get("Content-Type"))
+	}
+}
+
+func TestTimeout_fastHandler(t *testing.T) {
+	h := Timeout(time.Second)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Context().Deadline(); !ok {
+			t.Error("request context has no deadline")
+		}
+		w.Header().Set("X-Done", "yes")
+		w.WriteHeader(http.StatusCreated)
+	}))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+	if w.Code != http.StatusCreated || w.Header().Get("X-Done") != "yes" {
+		t.Errorf("response = %d, X-Done = %q, want 201 and yes", w.Code, w.Header().Get("X-Done"))
+	}
+}
+
+func TestTimeout_panicReachesRecovery(t *testing.T) {
+	h := Chain(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("boom")
+	}), Recovery(nil), Timeout(time.Second))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", w.Code)
+	}
+}
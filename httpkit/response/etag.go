@@ -0,0 +1,96 @@
+package response
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// envelope is implemented by BaseResponse and PagedResponse so ETag hashing can
+// recognise them.
+type envelope interface{ isEnvelope() }
+
+func (BaseResponse[T]) isEnvelope() {}
+
+// ETag returns a strong entity tag for body: a quoted hex SHA-256 prefix.
+func ETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// JSONWithETag writes data like JSON, with an ETag computed from its serialized
+// form. If r is a GET or HEAD whose If-None-Match matches the tag, it writes 304
+// Not Modified with no body instead, which saves bandwidth for polling clients.
+// Only 200 responses with a non-nil body are tagged; anything else is written
+// as by JSON.
+//
+// When data is a BaseResponse or PagedResponse (value or pointer), its top-level
+// "timestamp" is left out of the hash, so an envelope whose payload did not
+// change keeps the same ETag even though it is built at a different time.
+func JSONWithETag(w http.ResponseWriter, r *http.Request, statusCode int, data any) {
+	if statusCode != http.StatusOK || data == nil {
+		JSON(w, statusCode, data)
+		return
+	}
+	body, err := json.Marshal(data)
+	if err != nil {
+		payload := ErrorFromErr(err)
+		JSON(w, http.StatusInternalServerError, BaseResponse[any]{
+			Code:      CodeError,
+			Message:   payload.Message,
+			Timestamp: time.Now(),
+			Error:     payload,
+		})
+		return
+	}
+	tag := ETag(etagInput(data, body))
+	w.Header().Set("ETag", tag)
+	if (r.Method == http.MethodGet || r.Method == http.MethodHead) && etagMatch(r.Header.Get("If-None-Match"), tag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if _, err := w.Write(append(body, '\n')); err != nil {
+		// Header already written; cannot send another status. Log or ignore.
+		_ = err
+	}
+}
+
+// etagInput returns the bytes to hash for data serialized as body, dropping the
+// envelope timestamp.
+func etagInput(data any, body []byte) []byte {
+	if _, ok := data.(envelope); !ok {
+		return body
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil || fields == nil {
+		return body
+	}
+	delete(fields, "timestamp")
+	// Map keys are marshaled in sorted order, so the result is deterministic.
+	stripped, err := json.Marshal(fields)
+	if err != nil {
+		return body
+	}
+	return stripped
+}
+
+// etagMatch reports whether the If-None-Match header value matches tag, using
+// the weak comparison required for If-None-Match.
+func etagMatch(header, tag string) bool {
+	if header == "" {
+		return false
+	}
+	tag = strings.TrimPrefix(tag, "W/")
+	for candidate := range strings.SplitSeq(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == tag {
+			return true
+		}
+	}
+	return false
+}
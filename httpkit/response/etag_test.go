@@ -0,0 +1,75 @@
+package response
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestJSONWithETag(t *testing.T) {
+	data := map[string]int{"count": 3}
+	first := httptest.NewRecorder()
+	JSONWithETag(first, httptest.NewRequest(http.MethodGet, "/", http.NoBody), http.StatusOK, data)
+	tag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || tag == "" || first.Body.Len() == 0 {
+		t.Fatalf("first response = %d, ETag %q, %d bytes; want 200 with ETag and body", first.Code, tag, first.Body.Len())
+	}
+
+	tests := []struct {
+		name        string
+		method      string
+		ifNoneMatch string
+		wantStatus  int
+	}{
+		{"matching tag", http.MethodGet, tag, http.StatusNotModified},
+		{"weak and listed", http.MethodGet, `"other", W/` + tag, http.StatusNotModified},
+		{"wildcard", http.MethodHead, "*", http.StatusNotModified},
+		{"stale tag", http.MethodGet, `"stale"`, http.StatusOK},
+		{"not a read", http.MethodPost, tag, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/", http.NoBody)
+			req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			w := httptest.NewRecorder()
+			JSONWithETag(w, req, http.StatusOK, data)
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %v, want %v", w.Code, tt.wantStatus)
+			}
+			if w.Header().Get("ETag") != tag {
+				t.Errorf("ETag = %v, want %v", w.Header().Get("ETag"), tag)
+			}
+			if tt.wantStatus == http.StatusNotModified && w.Body.Len() != 0 {
+				t.Errorf("304 body should be empty, got %d bytes", w.Body.Len())
+			}
+		})
+	}
+}
+
+func TestJSONWithETag_ignoresEnvelopeTimestamp(t *testing.T) {
+	tagAt := func(data any) string {
+		w := httptest.NewRecorder()
+		JSONWithETag(w, httptest.NewRequest(http.MethodGet, "/", http.NoBody), http.StatusOK, data)
+		return w.Header().Get("ETag")
+	}
+	now := time.Now()
+	a := BaseResponse[string]{Code: CodeOK, Message: MessageSuccess, Timestamp: now, Data: "v1"}
+	b := a
+	b.Timestamp = now.Add(time.Minute)
+	if tagAt(a) != tagAt(&b) {
+		t.Error("ETag changed with only the envelope timestamp")
+	}
+	b.Data = "v2"
+	if tagAt(a) == tagAt(b) {
+		t.Error("ETag did not change with the data")
+	}
+}
+
+func TestJSONWithETag_untaggedStatus(t *testing.T) {
+	w := httptest.NewRecorder()
+	JSONWithETag(w, httptest.NewRequest(http.MethodGet, "/", http.NoBody), http.StatusCreated, "x")
+	if w.Code != http.StatusCreated || w.Header().Get("ETag") != "" {
+		t.Errorf("got %d with ETag %q, want 201 without ETag", w.Code, w.Header().Get("ETag"))
+	}
+}
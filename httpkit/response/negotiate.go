@@ -0,0 +1,164 @@
+package response
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ContentTypeJSON is the content type of the default encoder.
+const ContentTypeJSON = "application/json"
+
+// EncoderFunc encodes data to w in the content type it was registered for.
+type EncoderFunc func(w io.Writer, data any) error
+
+// encoders is the registry used by Negotiate, in registration order; JSON is first.
+var encoders = struct {
+	mu    sync.RWMutex
+	types []string
+	funcs map[string]EncoderFunc
+}{
+	types: []string{ContentTypeJSON},
+	funcs: map[string]EncoderFunc{ContentTypeJSON: EncodeJSON},
+}
+
+// RegisterEncoder registers enc for contentType (e.g. "application/xml"), replacing
+// any encoder already registered for it; a nil enc removes it. Registering
+// ContentTypeJSON is ignored: JSON is always available as the fallback.
+// Safe for concurrent use; typically called from init.
+//
+// Example:
+//
+//	response.RegisterEncoder("application/xml", response.EncodeXML)
+//	response.RegisterEncoder("application/msgpack", func(w io.Writer, v any) error {
+//		return msgpack.NewEncoder(w).Encode(v)
+//	})
+func RegisterEncoder(contentType string, enc EncoderFunc) {
+	contentType = normalizeMediaType(contentType)
+	if contentType == "" || contentType == ContentTypeJSON {
+		return
+	}
+	encoders.mu.Lock()
+	defer encoders.mu.Unlock()
+	if enc == nil {
+		delete(encoders.funcs, contentType)
+		encoders.types = slices.DeleteFunc(encoders.types, func(t string) bool { return t == contentType })
+		return
+	}
+	if _, ok := encoders.funcs[contentType]; !ok {
+		encoders.types = append(encoders.types, contentType)
+	}
+	encoders.funcs[contentType] = enc
+}
+
+// EncodeJSON is the JSON encoder used by JSON and as the fallback of Respond.
+func EncodeJSON(w io.Writer, data any) error {
+	return json.NewEncoder(w).Encode(data)
+}
+
+// EncodeXML encodes data as XML with a <response> root element. It is not
+// registered by default. encoding/xml cannot encode maps, so data holding a
+// non-empty map (including ErrorPayload.Meta) fails and Respond falls back to JSON.
+func EncodeXML(w io.Writer, data any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	return xml.NewEncoder(w).EncodeElement(data, xml.StartElement{Name: xml.Name{Local: "response"}})
+}
+
+// Negotiate returns the registered content type and encoder that best match r's
+// Accept header, honoring q-values and wildcards ("*/*", "application/*"); ties
+// go to the earlier media range, then to the earlier registered encoder. It falls
+// back to JSON when r is nil, Accept is empty, or nothing acceptable is registered.
+func Negotiate(r *http.Request) (contentType string, enc EncoderFunc) {
+	encoders.mu.RLock()
+	defer encoders.mu.RUnlock()
+	if r != nil {
+		for _, accepted := range parseAccept(r.Header.Get("Accept")) {
+			for _, t := range encoders.types {
+				if mediaTypeMatches(accepted, t) {
+					return t, encoders.funcs[t]
+				}
+			}
+		}
+	}
+	return ContentTypeJSON, EncodeJSON
+}
+
+// Respond writes data like JSON, but in the encoding Negotiate selects for r, and
+// adds "Accept" to the Vary header. Non-JSON bodies are encoded before the header
+// is written, so if the selected encoder fails the response is written as JSON.
+func Respond(w http.ResponseWriter, r *http.Request, statusCode int, data any) {
+	w.Header().Add("Vary", "Accept")
+	contentType, enc := Negotiate(r)
+	if contentType == ContentTypeJSON || data == nil {
+		JSON(w, statusCode, data)
+		return
+	}
+	var buf bytes.Buffer
+	if err := enc(&buf, data); err != nil {
+		JSON(w, statusCode, data)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(statusCode)
+	if _, err := buf.WriteTo(w); err != nil {
+		// Header already written; cannot send another status.
+		return
+	}
+}
+
+// mediaRange is one entry of an Accept header.
+type mediaRange struct {
+	mediaType string
+	q         float64
+}
+
+// parseAccept parses an Accept header into media ranges ordered by descending q,
+// dropping invalid entries and those with q=0.
+func parseAccept(header string) []mediaRange {
+	var ranges []mediaRange
+	for part := range strings.SplitSeq(header, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if s, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(s, 64); err != nil {
+				continue
+			}
+		}
+		if q > 0 {
+			ranges = append(ranges, mediaRange{mediaType: mediaType, q: q})
+		}
+	}
+	slices.SortStableFunc(ranges, func(a, b mediaRange) int { return cmp.Compare(b.q, a.q) })
+	return ranges
+}
+
+// mediaTypeMatches reports whether the media range accepts contentType.
+func mediaTypeMatches(accepted mediaRange, contentType string) bool {
+	if accepted.mediaType == "*/*" || accepted.mediaType == contentType {
+		return true
+	}
+	prefix, ok := strings.CutSuffix(accepted.mediaType, "/*")
+	return ok && strings.HasPrefix(contentType, prefix+"/")
+}
+
+// normalizeMediaType lowercases contentType and drops its parameters.
+func normalizeMediaType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	return mediaType
+}
@@ -0,0 +1,103 @@
+package response
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// registerTestEncoder registers enc for contentType for the duration of the test.
+func registerTestEncoder(t *testing.T, contentType string, enc EncoderFunc) {
+	t.Helper()
+	RegisterEncoder(contentType, enc)
+	t.Cleanup(func() { RegisterEncoder(contentType, nil) })
+}
+
+func textEncoder(w io.Writer, _ any) error {
+	_, err := io.WriteString(w, "text")
+	return err
+}
+
+func TestNegotiate(t *testing.T) {
+	registerTestEncoder(t, "application/xml", EncodeXML)
+	registerTestEncoder(t, "text/plain", textEncoder)
+	tests := []struct {
+		accept string
+		want   string
+	}{
+		{"", ContentTypeJSON},
+		{"application/xml", "application/xml"},
+		{"Application/XML; charset=utf-8", "application/xml"},
+		{"text/html, text/*", "text/plain"},
+		{"application/json;q=0.5, application/xml", "application/xml"},
+		{"application/xml;q=0.4, text/plain;q=0.8", "text/plain"},
+		{"application/*", ContentTypeJSON},
+		{"*/*", ContentTypeJSON},
+		{"image/png", ContentTypeJSON},
+		{"application/xml;q=0", ContentTypeJSON},
+		{"application/xml;q=oops, text/plain", "text/plain"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+		req.Header.Set("Accept", tt.accept)
+		if got, _ := Negotiate(req); got != tt.want {
+			t.Errorf("Negotiate(Accept %q) = %v, want %v", tt.accept, got, tt.want)
+		}
+	}
+	if got, _ := Negotiate(nil); got != ContentTypeJSON {
+		t.Errorf("Negotiate(nil) = %v, want %v", got, ContentTypeJSON)
+	}
+}
+
+func TestRegisterEncoder_removeAndJSON(t *testing.T) {
+	RegisterEncoder("application/xml", EncodeXML)
+	RegisterEncoder("application/xml", nil)
+	RegisterEncoder(ContentTypeJSON, nil)
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	req.Header.Set("Accept", "application/xml, application/json;q=0.1")
+	if got, enc := Negotiate(req); got != ContentTypeJSON || enc == nil {
+		t.Errorf("Negotiate() = %v, nil encoder %v; want JSON", got, enc == nil)
+	}
+}
+
+func TestRespond(t *testing.T) {
+	registerTestEncoder(t, "application/xml", EncodeXML)
+	registerTestEncoder(t, "application/x-failing", func(io.Writer, any) error { return errors.New("boom") })
+	body := BaseResponse[any]{Code: CodeOK, Message: MessageSuccess, Data: "hi"}
+	tests := []struct {
+		name            string
+		accept          string
+		data            any
+		wantContentType string
+		wantBody        string
+	}{
+		{"xml", "application/xml", body, "application/xml", "<response><code>OK</code><message>success</message>"},
+		{"json default", "", body, ContentTypeJSON, `"code":"OK"`},
+		{"encoder error falls back", "application/x-failing", body, ContentTypeJSON, `"data":"hi"`},
+		{"xml unsupported map falls back", "application/xml",
+			BaseResponse[any]{Code: CodeOK, Data: map[string]int{"n": 1}}, ContentTypeJSON, `"n":1`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+			req.Header.Set("Accept", tt.accept)
+			w := httptest.NewRecorder()
+			Respond(w, req, http.StatusCreated, tt.data)
+			if w.Code != http.StatusCreated {
+				t.Errorf("status = %v, want 201", w.Code)
+			}
+			if got := w.Header().Get("Content-Type"); got != tt.wantContentType {
+				t.Errorf("Content-Type = %v, want %v", got, tt.wantContentType)
+			}
+			if got := w.Header().Get("Vary"); got != "Accept" {
+				t.Errorf("Vary = %v, want Accept", got)
+			}
+			if !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("body = %s, want it to contain %s", w.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package response
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/biairmal/go-sdk/common/dto"
+)
+
+// PageMeta is the pagination metadata of a PagedResponse.
+type PageMeta struct {
+	Total      int64 `json:"total"`
+	Page       int   `json:"page"`
+	Size       int   `json:"size"`
+	TotalPages int   `json:"total_pages"`
+	HasPrev    bool  `json:"has_prev"`
+	HasNext    bool  `json:"has_next"`
+}
+
+// PagedResponse is the success envelope for list endpoints: the page items in
+// Data and the pagination metadata next to it in Pagination.
+type PagedResponse[T any] struct {
+	Code       string    `json:"code"`
+	Message    string    `json:"message"`
+	Timestamp  time.Time `json:"timestamp"`
+	Data       []*T      `json:"data"`
+	Pagination PageMeta  `json:"pagination"`
+}
+
+func (PagedResponse[T]) isEnvelope() {}
+
+// NewPagedResponse builds a PagedResponse from a dto.PageResponse. A nil page or
+// nil items give an empty data array, so clients always receive a list.
+func NewPagedResponse[T any](page *dto.PageResponse[T]) PagedResponse[T] {
+	if page == nil {
+		page = dto.NewPageResponse[T](nil, 0, 0, 0)
+	}
+	items := page.Items
+	if items == nil {
+		items = []*T{}
+	}
+	return PagedResponse[T]{
+		Code:      CodeOK,
+		Message:   MessageSuccess,
+		Timestamp: time.Now(),
+		Data:      items,
+		Pagination: PageMeta{
+			Total:      page.Total,
+			Page:       page.Page,
+			Size:       page.Size,
+			TotalPages: page.TotalPages,
+			HasPrev:    page.HasPrev,
+			HasNext:    page.HasNext,
+		},
+	}
+}
+
+// Paged writes page as a PagedResponse with the given status:
+//
+//	{"code":"OK","message":"success","timestamp":"...","data":[...],
+//	 "pagination":{"total":42,"page":2,"size":20,"total_pages":3,"has_prev":true,"has_next":true}}
+func Paged[T any](w http.ResponseWriter, statusCode int, page *dto.PageResponse[T]) {
+	JSON(w, statusCode, NewPagedResponse(page))
+}
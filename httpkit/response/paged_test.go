@@ -0,0 +1,72 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/biairmal/go-sdk/common/dto"
+)
+
+type item struct {
+	ID int `json:"id"`
+}
+
+func TestPaged_jsonShape(t *testing.T) {
+	page := dto.NewPageResponse([]*item{{ID: 1}, {ID: 2}}, 42, 2, 20)
+	w := httptest.NewRecorder()
+	Paged(w, http.StatusOK, page)
+	if w.Code != http.StatusOK {
+		t.Errorf("Paged status = %v, want 200", w.Code)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("body is not valid JSON: %v", err)
+	}
+	if _, ok := got["timestamp"].(string); !ok {
+		t.Errorf("timestamp missing: %s", w.Body.String())
+	}
+	delete(got, "timestamp")
+	want := map[string]any{
+		"code":    "OK",
+		"message": "success",
+		"data":    []any{map[string]any{"id": 1.0}, map[string]any{"id": 2.0}},
+		"pagination": map[string]any{
+			"total": 42.0, "page": 2.0, "size": 20.0, "total_pages": 3.0, "has_prev": true, "has_next": true,
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Paged body = %v, want %v", got, want)
+	}
+}
+
+func TestNewPagedResponse_empty(t *testing.T) {
+	tests := []struct {
+		name string
+		page *dto.PageResponse[item]
+	}{
+		{"nil page", nil},
+		{"nil items", dto.NewPageResponse[item](nil, 0, 1, 10)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(NewPagedResponse(tt.page))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got struct {
+				Data       json.RawMessage `json:"data"`
+				Pagination PageMeta        `json:"pagination"`
+			}
+			if err := json.Unmarshal(b, &got); err != nil {
+				t.Fatal(err)
+			}
+			if string(got.Data) != "[]" || got.Pagination.Page != 1 || got.Pagination.TotalPages != 1 {
+				t.Errorf("NewPagedResponse() = %s, want empty data on page 1 of 1", b)
+			}
+		})
+	}
+}
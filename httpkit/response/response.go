@@ -5,45 +5,52 @@ package response
 
 import (
 	"encoding/json"
-	"errors"
 	"net/http"
 	"time"
 
 	"github.com/biairmal/go-sdk/errorz"
 )
 
+// Envelope codes and message written by the handler adapter in BaseResponse.Code
+// and BaseResponse.Message. Error responses use the error's message.
+const (
+	CodeOK         = "OK"
+	CodeError      = "ERROR"
+	MessageSuccess = "success"
+)
+
 // BaseResponse is the base response struct for all API responses.
 // Use Data for success and Error for error responses; keep the other field nil/zero.
 type BaseResponse[T any] struct {
-	Code      string    `json:"code,omitempty"`
-	Message   string    `json:"message,omitempty"`
-	Timestamp time.Time `json:"timestamp"`
-	Data      T         `json:"data,omitempty"`
-	Error     any       `json:"error,omitempty"`
+	Code      string    `json:"code,omitempty" xml:"code,omitempty"`
+	Message   string    `json:"message,omitempty" xml:"message,omitempty"`
+	Timestamp time.Time `json:"timestamp" xml:"timestamp"`
+	Data      T         `json:"data,omitempty" xml:"data,omitempty"`
+	Error     any       `json:"error,omitempty" xml:"error,omitempty"`
 }
 
-// ErrorPayload is the normalised error shape for JSON responses.
+// ErrorPayload is the normalised error shape for JSON (and negotiated) responses.
 // It is populated from errorz.Error when present, or from a generic message for other errors.
 type ErrorPayload struct {
-	Code         string         `json:"code"`
-	Message      string         `json:"message"`
-	SourceSystem string         `json:"source_system,omitempty"`
-	Meta         map[string]any `json:"meta,omitempty"`
-	Details      string         `json:"details,omitempty"`
+	Code         string         `json:"code" xml:"code"`
+	Message      string         `json:"message" xml:"message"`
+	SourceSystem string         `json:"source_system,omitempty" xml:"source_system,omitempty"`
+	Meta         map[string]any `json:"meta,omitempty" xml:"meta,omitempty"`
+	Details      string         `json:"details,omitempty" xml:"details,omitempty"`
 }
 
 // ErrorFromErr builds an ErrorPayload from an error.
-// If the error is a *errorz.Error, Code, Message, SourceSystem, and Meta are copied.
+// If the error is a *errorz.Error, Code, Message, SourceSystem, and Meta are copied;
+// the message is localized when the error carries a language (errorz.Error.WithLang).
 // Otherwise a generic payload with code "ERR_INTERNAL" and the error string as message is returned.
 func ErrorFromErr(err error) ErrorPayload {
 	if err == nil {
 		return ErrorPayload{Code: "ERR_INTERNAL", Message: "unknown error"}
 	}
-	var errz *errorz.Error
-	if errors.As(err, &errz) && errz != nil {
+	if errz, ok := errorz.AsError(err); ok {
 		return ErrorPayload{
 			Code:         nonEmpty(errz.Code, "ERR_INTERNAL"),
-			Message:      nonEmpty(errz.Message, errz.Error()),
+			Message:      nonEmpty(errz.LocalizedMessage(""), errz.Error()),
 			SourceSystem: errz.SourceSystem,
 			Meta:         errz.Meta,
 		}
@@ -30,6 +30,21 @@ func TestErrorFromErr(t *testing.T) {
 	}
 }
 
+func TestErrorFromErr_localized(t *testing.T) {
+	errorz.SetMessageCatalog(errorz.MessageCatalog{
+		errorz.CodeNotFound: {"id": "pesanan {order_id} tidak ditemukan"},
+	})
+	t.Cleanup(func() { errorz.SetMessageCatalog(nil) })
+
+	err := errorz.NotFound().WithMeta("order_id", 7)
+	if got := ErrorFromErr(err).Message; got != "not found" {
+		t.Errorf("ErrorFromErr().Message without lang = %q, want %q", got, "not found")
+	}
+	if got := ErrorFromErr(err.WithLang("id")).Message; got != "pesanan 7 tidak ditemukan" {
+		t.Errorf("ErrorFromErr().Message with lang = %q, want %q", got, "pesanan 7 tidak ditemukan")
+	}
+}
+
 func TestJSON(t *testing.T) {
 	w := httptest.NewRecorder()
 	body := BaseResponse[any]{Code: "OK", Message: "ok", Data: "test"}
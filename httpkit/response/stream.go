@@ -0,0 +1,95 @@
+package response
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"iter"
+	"net/http"
+	"time"
+)
+
+// streamFlushEvery is how many items StreamJSON writes between flushes.
+const streamFlushEvery = 100
+
+// StreamJSON writes a success envelope whose data is a JSON array of the values
+// yielded by items, encoding and flushing them as they arrive instead of holding
+// the whole list in memory. It is meant for exports and other large lists:
+//
+//	{"data":[item, item, ...],"code":"OK","message":"success","timestamp":"..."}
+//
+// data comes first so the envelope fields can reflect how the stream ended. If
+// items yields a non-nil error or an item cannot be encoded as JSON, the array
+// is closed after the items written so far and the envelope gets code CodeError,
+// the error's message and an "error" payload (see ErrorFromErr); the body stays
+// valid JSON, but the status was already sent, so clients must check code. The
+// first error from items, from encoding an item or from writing or flushing w
+// (e.g. a disconnected client) is returned and ends the stream. A channel can be adapted with a small iter.Seq2 that ranges over it.
+func StreamJSON[T any](w http.ResponseWriter, statusCode int, items iter.Seq2[T, error]) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	rc := http.NewResponseController(w)
+	flush := func() error {
+		if err := rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return err
+		}
+		return nil
+	}
+
+	if _, err := io.WriteString(w, `{"data":[`); err != nil {
+		return err
+	}
+	var streamErr error
+	n := 0
+	for item, err := range items {
+		if err != nil {
+			streamErr = err
+			break
+		}
+		// Encode before writing so a failed item leaves no partial output.
+		b, err := json.Marshal(item)
+		if err != nil {
+			streamErr = err
+			break
+		}
+		if n > 0 {
+			b = append([]byte(","), b...)
+		}
+		if _, err := w.Write(b); err != nil {
+			return err
+		}
+		if n++; n%streamFlushEvery == 0 {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := writeStreamTrailer(w, streamErr); err != nil {
+		return err
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+	return streamErr
+}
+
+// writeStreamTrailer closes the data array and writes the remaining envelope fields.
+func writeStreamTrailer(w io.Writer, streamErr error) error {
+	trailer := struct {
+		Code      string        `json:"code"`
+		Message   string        `json:"message"`
+		Timestamp time.Time     `json:"timestamp"`
+		Error     *ErrorPayload `json:"error,omitempty"`
+	}{Code: CodeOK, Message: MessageSuccess, Timestamp: time.Now()}
+	if streamErr != nil {
+		payload := ErrorFromErr(streamErr)
+		trailer.Code, trailer.Message, trailer.Error = CodeError, payload.Message, &payload
+	}
+	b, err := json.Marshal(trailer)
+	if err != nil {
+		return err
+	}
+	// b is {"code":...}; replace its opening brace with the end of the array.
+	_, err = io.WriteString(w, "],"+string(b[1:]))
+	return err
+}
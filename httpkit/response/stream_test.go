@@ -0,0 +1,104 @@
+package response
+
+import (
+	"encoding/json"
+	"errors"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/biairmal/go-sdk/errorz"
+)
+
+// seq yields items and then err, if non-nil.
+func seq(items []int, err error) iter.Seq2[int, error] {
+	return func(yield func(int, error) bool) {
+		for _, v := range items {
+			if !yield(v, nil) {
+				return
+			}
+		}
+		if err != nil {
+			yield(0, err)
+		}
+	}
+}
+
+type streamBody struct {
+	Data    []int         `json:"data"`
+	Code    string        `json:"code"`
+	Message string        `json:"message"`
+	Error   *ErrorPayload `json:"error"`
+}
+
+func TestStreamJSON(t *testing.T) {
+	many := make([]int, 250)
+	for i := range many {
+		many[i] = i
+	}
+	tests := []struct {
+		name     string
+		items    []int
+		err      error
+		wantCode string
+		wantErr  string
+	}{
+		{"empty", nil, nil, CodeOK, ""},
+		{"several flushes", many, nil, CodeOK, ""},
+		{"error mid-stream", []int{1, 2}, errorz.Internal().WithMessage("db gone"), CodeError, "ERR_INTERNAL"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			err := StreamJSON(w, http.StatusOK, seq(tt.items, tt.err))
+			if !errors.Is(err, tt.err) {
+				t.Errorf("StreamJSON() error = %v, want %v", err, tt.err)
+			}
+			if w.Header().Get("Content-Type") != "application/json" {
+				t.Errorf("Content-Type = %v, want application/json", w.Header().Get("Content-Type"))
+			}
+			var got streamBody
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatalf("body is not valid JSON: %v\n%s", err, w.Body.String())
+			}
+			if len(got.Data) != len(tt.items) || got.Code != tt.wantCode {
+				t.Errorf("got %d items with code %v, want %d with %v", len(got.Data), got.Code, len(tt.items), tt.wantCode)
+			}
+			if tt.wantErr == "" {
+				if got.Error != nil || got.Message != MessageSuccess {
+					t.Errorf("message = %v, error = %+v, want success without error", got.Message, got.Error)
+				}
+				return
+			}
+			if got.Error == nil || got.Error.Code != tt.wantErr || got.Message != "db gone" {
+				t.Errorf("message = %v, error = %+v, want %v with db gone", got.Message, got.Error, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStreamJSON_unencodableItem(t *testing.T) {
+	items := func(yield func(any, error) bool) {
+		for _, v := range []any{1, make(chan int), 3} {
+			if !yield(v, nil) {
+				return
+			}
+		}
+	}
+
+	w := httptest.NewRecorder()
+	err := StreamJSON(w, http.StatusOK, items)
+	var unsupported *json.UnsupportedTypeError
+	if !errors.As(err, &unsupported) {
+		t.Errorf("StreamJSON() error = %v, want *json.UnsupportedTypeError", err)
+	}
+	var got streamBody
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("body is not valid JSON: %v\n%s", err, w.Body.String())
+	}
+	if len(got.Data) != 1 || got.Code != CodeError || got.Error == nil || got.Error.Code != "ERR_INTERNAL" {
+		t.Errorf("got %d items with code %v, error %+v, want 1 item with %v and ERR_INTERNAL",
+			len(got.Data), got.Code, got.Error, CodeError)
+	}
+}
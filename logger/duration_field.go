@@ -0,0 +1,37 @@
+package logger
+
+import (
+	"log/slog"
+	"strconv"
+	"time"
+)
+
+// durationValue is the value of a Field built by Duration.
+type durationValue time.Duration
+
+// Duration creates a Field whose value is d in milliseconds as a float number
+// (1.5 for 1500µs), so durations logged anywhere in the SDK share one numeric
+// unit that dashboards can aggregate. Name the key accordingly, e.g. "duration_ms".
+//
+// Example:
+//
+//	log.Info("request completed", logger.Duration("duration_ms", time.Since(start)))
+//	// {"level":"info","duration_ms":12.345,...}
+func Duration(key string, d time.Duration) Field {
+	return Field{Key: key, Value: durationValue(d)}
+}
+
+// millis returns the duration in (fractional) milliseconds.
+func (v durationValue) millis() float64 {
+	return float64(v) / float64(time.Millisecond)
+}
+
+// MarshalJSON implements json.Marshaler, writing the milliseconds as a number.
+func (v durationValue) MarshalJSON() ([]byte, error) {
+	return strconv.AppendFloat(nil, v.millis(), 'f', -1, 64), nil
+}
+
+// LogValue implements slog.LogValuer so the slog adapter emits a number.
+func (v durationValue) LogValue() slog.Value {
+	return slog.Float64Value(v.millis())
+}
@@ -0,0 +1,47 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDuration(t *testing.T) {
+	tests := []struct {
+		name string
+		d    time.Duration
+		want string
+	}{
+		{"fractional", 1500 * time.Microsecond, `"duration_ms":1.5`},
+		{"whole", 2 * time.Second, `"duration_ms":2000`},
+		{"zero", 0, `"duration_ms":0`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var zbuf, sbuf bytes.Buffer
+			NewZerologWithWriter(&zbuf, nil).Info("done", Duration("duration_ms", tt.d))
+			NewSlog(slog.New(slog.NewJSONHandler(&sbuf, nil))).Info("done", Duration("duration_ms", tt.d))
+			for backend, out := range map[string]string{"zerolog": zbuf.String(), "slog": sbuf.String()} {
+				if !strings.Contains(out, tt.want) {
+					t.Errorf("%s output = %s, want it to contain %s", backend, out, tt.want)
+				}
+				if _, ok := decodeLine(t, strings.TrimSpace(out))["duration_ms"].(float64); !ok {
+					t.Errorf("%s duration_ms is not a JSON number: %s", backend, out)
+				}
+			}
+		})
+	}
+}
+
+func TestDuration_marshalJSON(t *testing.T) {
+	b, err := json.Marshal(map[string]any{"took": Duration("took", 250*time.Microsecond).Value})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if want := `{"took":0.25}`; string(b) != want {
+		t.Errorf("json.Marshal() = %s, want %s", b, want)
+	}
+}
@@ -0,0 +1,95 @@
+package logger
+
+import (
+	"log/slog"
+
+	"github.com/biairmal/go-sdk/errorz"
+	"github.com/rs/zerolog"
+)
+
+// errorFieldValue is the structured form of an *errorz.Error used by Err.
+// It serializes as a nested object in both JSON and zerolog output.
+type errorFieldValue struct {
+	Code         string         `json:"code,omitempty"`
+	Message      string         `json:"message,omitempty"`
+	SourceSystem string         `json:"source_system,omitempty"`
+	Meta         map[string]any `json:"meta,omitempty"`
+	Cause        string         `json:"cause,omitempty"`
+}
+
+// Err creates a Field with key "error" for err.
+//
+// When err is or wraps an *errorz.Error (see errorz.AsError), the value is a
+// nested object with code, message, source_system, meta, and cause, so the
+// fields are queryable in JSON logs. cause is the wrapped error's message, or
+// err.Error() when err wraps the *errorz.Error, so the context added around it
+// (e.g. "load user: ...") is kept. Other errors are logged as their Error()
+// string, and a nil error is logged as null.
+//
+// Example:
+//
+//	log.Error("failed to load user", logger.Err(err))
+//	// {"level":"error","error":{"code":"ERR_NOT_FOUND","message":"not found",...},...}
+func Err(err error) Field {
+	if err == nil {
+		return Field{Key: "error", Value: nil}
+	}
+	errz, ok := errorz.AsError(err)
+	if !ok {
+		return Field{Key: "error", Value: err.Error()}
+	}
+
+	value := errorFieldValue{
+		Code:         errz.Code,
+		Message:      errz.Message,
+		SourceSystem: errz.SourceSystem,
+		Meta:         errz.Meta,
+	}
+	switch {
+	case error(errz) != err:
+		value.Cause = err.Error()
+	case errz.Err != nil:
+		value.Cause = errz.Err.Error()
+	}
+	return Field{Key: "error", Value: value}
+}
+
+// MarshalZerologObject implements zerolog.LogObjectMarshaler.
+func (v errorFieldValue) MarshalZerologObject(e *zerolog.Event) {
+	if v.Code != "" {
+		e.Str("code", v.Code)
+	}
+	if v.Message != "" {
+		e.Str("message", v.Message)
+	}
+	if v.SourceSystem != "" {
+		e.Str("source_system", v.SourceSystem)
+	}
+	if len(v.Meta) > 0 {
+		e.Interface("meta", v.Meta)
+	}
+	if v.Cause != "" {
+		e.Str("cause", v.Cause)
+	}
+}
+
+// LogValue implements slog.LogValuer so the slog adapter emits a group.
+func (v errorFieldValue) LogValue() slog.Value {
+	var attrs []slog.Attr
+	if v.Code != "" {
+		attrs = append(attrs, slog.String("code", v.Code))
+	}
+	if v.Message != "" {
+		attrs = append(attrs, slog.String("message", v.Message))
+	}
+	if v.SourceSystem != "" {
+		attrs = append(attrs, slog.String("source_system", v.SourceSystem))
+	}
+	if len(v.Meta) > 0 {
+		attrs = append(attrs, slog.Any("meta", v.Meta))
+	}
+	if v.Cause != "" {
+		attrs = append(attrs, slog.String("cause", v.Cause))
+	}
+	return slog.GroupValue(attrs...)
+}
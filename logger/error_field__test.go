@@ -0,0 +1,105 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/biairmal/go-sdk/errorz"
+)
+
+func TestErr(t *testing.T) {
+	notFound := errorz.NotFound().WithSourceSystem("svc").WithMeta("user_id", 7)
+	tests := []struct {
+		name string
+		err  error
+		want any
+	}{
+		{"nil error", nil, nil},
+		{"plain error", errors.New("boom"), "boom"},
+		{
+			name: "errorz error",
+			err:  errorz.Wrap(errors.New("db down")).WithCode("ERR_DB").WithMessage("failed").WithSourceSystem("svc"),
+			want: errorFieldValue{Code: "ERR_DB", Message: "failed", SourceSystem: "svc", Cause: "db down"},
+		},
+		{
+			name: "wrapped errorz error",
+			err:  fmt.Errorf("load user: %w", notFound),
+			want: errorFieldValue{
+				Code:         errorz.CodeNotFound,
+				Message:      "not found",
+				SourceSystem: "svc",
+				Meta:         map[string]any{"user_id": 7},
+				Cause:        "load user: " + notFound.Error(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Err(tt.err)
+			if got.Key != "error" {
+				t.Errorf("Err() Key = %v, want error", got.Key)
+			}
+			if !reflect.DeepEqual(got.Value, tt.want) {
+				t.Errorf("Err() Value = %#v, want %#v", got.Value, tt.want)
+			}
+		})
+	}
+}
+
+func TestErr_zerologNestedObject(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewZerologWithWriter(&buf, nil)
+
+	err := errorz.NotFound().WithSourceSystem("user-service").WithMeta("user_id", 7)
+	log.Error("lookup failed", Err(err))
+
+	line := decodeLine(t, strings.TrimSpace(buf.String()))
+	obj, ok := line["error"].(map[string]any)
+	if !ok {
+		t.Fatalf("error field = %#v, want nested object", line["error"])
+	}
+	want := map[string]any{
+		"code":          errorz.CodeNotFound,
+		"message":       "not found",
+		"source_system": "user-service",
+		"meta":          map[string]any{"user_id": float64(7)},
+		"cause":         "not found",
+	}
+	if !reflect.DeepEqual(obj, want) {
+		t.Errorf("error field = %v, want %v", obj, want)
+	}
+}
+
+func TestErr_zerologPlainError(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewZerologWithWriter(&buf, nil)
+
+	log.Error("failed", Err(errors.New("boom")))
+
+	line := decodeLine(t, strings.TrimSpace(buf.String()))
+	if line["error"] != "boom" {
+		t.Errorf("error field = %#v, want boom", line["error"])
+	}
+}
+
+func TestErr_slogGroup(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewSlog(slog.New(slog.NewJSONHandler(&buf, nil)))
+
+	log.Error("failed", Err(errorz.BadRequest()))
+
+	line := decodeLine(t, strings.TrimSpace(buf.String()))
+	obj, ok := line["error"].(map[string]any)
+	if !ok {
+		t.Fatalf("error field = %#v, want nested object", line["error"])
+	}
+	if obj["code"] != errorz.CodeBadRequest {
+		t.Errorf("error.code = %v, want %v", obj["code"], errorz.CodeBadRequest)
+	}
+}
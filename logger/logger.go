@@ -3,8 +3,10 @@
 //
 // The package defines a Logger interface that can be implemented by various
 // logging backends. Currently, it provides:
-//   - zerolog implementation (NewZerolog) for production use
+//   - zerolog implementation (NewZerolog) for production use, and NewZerologWithWriter
+//     for capturing JSON output in tests
 //   - no-op implementation (NewNoOp) for testing or disabling logging
+//   - log/slog adapter (NewSlog), plus AsSlogHandler to route slog through any Logger
 //
 // Example usage:
 //
@@ -18,7 +20,7 @@
 //
 // With context:
 //
-//	ctx := context.WithValue(context.Background(), "request_id", "req-123")
+//	ctx := context.WithValue(context.Background(), logger.RequestIDKey, "req-123")
 //	log.InfoWithContext(ctx, "Request processed", logger.F("status", "success"))
 //
 // For testing (no console output):
@@ -29,6 +31,7 @@ package logger
 
 import (
 	"context"
+	"time"
 )
 
 // Level represents the logging level.
@@ -51,6 +54,7 @@ const (
 	OutputStdout Output = "stdout" // Write logs to standard output
 	OutputStderr Output = "stderr" // Write logs to standard error
 	OutputFile   Output = "file"   // Write logs to a file with rotation support
+	OutputMulti  Output = "multi"  // Write logs to every destination listed in Options.Outputs
 )
 
 // Format represents the output format for log messages.
@@ -62,7 +66,7 @@ const (
 )
 
 // RotationConfig configures file rotation settings for log files.
-// This is only used when Output is set to OutputFile.
+// This is only used when Output is set to OutputFile, or OutputMulti with OutputFile in Outputs.
 //
 // File rotation automatically rotates log files when they reach MaxSize.
 // Old log files are retained according to MaxBackups and MaxAge settings.
@@ -93,6 +97,22 @@ type RotationConfig struct {
 	LocalTime bool
 }
 
+// SamplingConfig configures log sampling to reduce log volume under load.
+// Within each Tick, the first Initial messages of each level are logged; after
+// that only every Thereafter-th message is logged until the next Tick starts.
+// Fatal and Panic messages are never sampled.
+type SamplingConfig struct {
+	// Initial is the number of messages per level logged in full during each Tick.
+	Initial int
+
+	// Thereafter logs every Thereafter-th message once Initial is reached within a Tick.
+	// Zero drops all further messages until the next Tick.
+	Thereafter int
+
+	// Tick is the sampling window. Defaults to 1 second if zero.
+	Tick time.Duration
+}
+
 // Options configures the logger behavior.
 // All fields are optional and have sensible defaults.
 type Options struct {
@@ -104,9 +124,15 @@ type Options struct {
 	// Defaults to OutputStdout if not specified.
 	Output Output
 
+	// Outputs lists the destinations used when Output is OutputMulti, e.g.
+	// []Output{OutputStdout, OutputFile}. Ignored for other Output values.
+	// Defaults to stdout if empty.
+	Outputs []Output
+
 	// Format specifies the output format (JSON or text).
 	// Defaults to FormatText if not specified.
-	// Note: File output always uses JSON format regardless of this setting.
+	// Note: File output always uses JSON format regardless of this setting,
+	// including the file destination of OutputMulti.
 	Format Format
 
 	// Rotation configures file rotation when Output is OutputFile.
@@ -116,6 +142,31 @@ type Options struct {
 	// ContextExtractor extracts fields from context.Context for automatic inclusion in logs.
 	// If nil, a default extractor is used that extracts request_id, user_id, and trace_id.
 	ContextExtractor ContextExtractor
+
+	// TimeFieldName is the key of the timestamp field, e.g. "@timestamp".
+	// Defaults to "time" if empty.
+	TimeFieldName string
+
+	// TimeFormat is the time.Format layout of the timestamp, e.g. time.RFC3339Nano.
+	// Defaults to time.RFC3339 if empty.
+	TimeFormat string
+
+	// ReportCaller adds a "caller" field with the file:line of the log call.
+	ReportCaller bool
+
+	// CallerSkipFrames skips additional stack frames when ReportCaller is enabled.
+	// Use it when the logger is wrapped by your own helper functions so the reported
+	// location is the helper's caller. Defaults to 0.
+	CallerSkipFrames int
+
+	// ExitFunc is called with exit code 1 after a Fatal* message is logged.
+	// Tests can inject a function that records the code instead of exiting.
+	// Defaults to os.Exit if nil.
+	ExitFunc func(code int)
+
+	// Sampling enables log sampling for Debug through Error messages.
+	// If nil, every message is logged.
+	Sampling *SamplingConfig
 }
 
 // Field represents a single structured log field with a key-value pair.
@@ -136,6 +187,24 @@ func F(key string, value any) Field {
 	return Field{Key: key, Value: value}
 }
 
+// contextKey is the type of the context keys read by the default context extractor.
+// A dedicated type avoids collisions with keys defined in other packages.
+type contextKey string
+
+// Context keys read by the default context extractor. Store values under these keys
+// (httpkit/middleware.RequestID uses RequestIDKey) to have them included in logs
+// written via the *WithContext methods.
+//
+// Example:
+//
+//	ctx := context.WithValue(ctx, logger.UserIDKey, 456)
+//	log.InfoWithContext(ctx, "Order placed") // includes user_id=456
+const (
+	RequestIDKey contextKey = "request_id" // Context key for the request ID (field "request_id")
+	UserIDKey    contextKey = "user_id"    // Context key for the user ID (field "user_id")
+	TraceIDKey   contextKey = "trace_id"   // Context key for the trace ID (field "trace_id")
+)
+
 // ContextExtractor extracts fields from context.Context for automatic inclusion in log messages.
 // This allows custom extraction of context values such as request IDs, user IDs, trace IDs, etc.
 //
@@ -153,12 +222,52 @@ func F(key string, value any) Field {
 //	}
 type ContextExtractor func(ctx context.Context) []Field
 
+// CombineExtractors returns a ContextExtractor that runs each extractor in order and
+// concatenates their fields. Nil extractors are skipped. Use it to extend the default
+// extractor instead of re-implementing it:
+//
+//	logger.CombineExtractors(logger.DefaultContextExtractor, func(ctx context.Context) []logger.Field {
+//		if tenant := ctx.Value(tenantKey); tenant != nil {
+//			return []logger.Field{logger.F("tenant_id", tenant)}
+//		}
+//		return nil
+//	})
+func CombineExtractors(extractors ...ContextExtractor) ContextExtractor {
+	return func(ctx context.Context) []Field {
+		var fields []Field
+		for _, extract := range extractors {
+			if extract != nil {
+				fields = append(fields, extract(ctx)...)
+			}
+		}
+		return fields
+	}
+}
+
 // Logger defines the contract for logging operations.
 // All logger implementations must satisfy this interface.
 //
 // The interface provides methods for logging at different levels (Debug, Info, Warn, Error, Fatal, Panic)
 // with support for structured fields and context-aware logging.
 type Logger interface {
+	// SetLevel changes the minimum logging level at runtime, e.g. from an admin endpoint.
+	// It is safe to call concurrently with logging. The level is shared by the logger
+	// instance and every logger derived from it, so the change applies to all of them.
+	SetLevel(level Level)
+
+	// Enabled reports whether messages at level would be written. Use it to skip
+	// building expensive fields when the level is disabled:
+	//
+	//	if log.Enabled(logger.LevelDebug) {
+	//		log.Debug("Cache state", logger.F("entries", cache.Dump()))
+	//	}
+	Enabled(level Level) bool
+
+	// Sync flushes any buffered log output. Call it before the program exits,
+	// e.g. defer log.Sync() in main() and before os.Exit paths, so the last lines
+	// are not lost. Implementations without buffering return nil.
+	Sync() error
+
 	// Debug logs a debug-level message with optional structured fields.
 	Debug(msg string, fields ...Field)
 
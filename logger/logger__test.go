@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"context"
 	"reflect"
 	"testing"
 )
@@ -69,3 +70,40 @@ func TestF(t *testing.T) {
 		})
 	}
 }
+
+func TestCombineExtractors(t *testing.T) {
+	type tenantKey struct{}
+	tenant := func(ctx context.Context) []Field {
+		if v := ctx.Value(tenantKey{}); v != nil {
+			return []Field{F("tenant_id", v)}
+		}
+		return nil
+	}
+	ctx := context.WithValue(requestIDContext("req-1"), tenantKey{}, "acme")
+
+	tests := []struct {
+		name       string
+		extractors []ContextExtractor
+		want       []Field
+	}{
+		{name: "none", extractors: nil, want: nil},
+		{
+			name:       "default then custom",
+			extractors: []ContextExtractor{DefaultContextExtractor, tenant},
+			want:       []Field{F("request_id", "req-1"), F("tenant_id", "acme")},
+		},
+		{
+			name:       "order preserved, nil skipped",
+			extractors: []ContextExtractor{tenant, nil, DefaultContextExtractor},
+			want:       []Field{F("tenant_id", "acme"), F("request_id", "req-1")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CombineExtractors(tt.extractors...)(ctx); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CombineExtractors()() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
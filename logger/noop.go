@@ -30,6 +30,15 @@ func NewNoOp() Logger {
 	return &noopLogger{}
 }
 
+// SetLevel is a no-op.
+func (n *noopLogger) SetLevel(_ Level) {}
+
+// Enabled always returns false since nothing is ever logged.
+func (n *noopLogger) Enabled(_ Level) bool { return false }
+
+// Sync is a no-op and always returns nil.
+func (n *noopLogger) Sync() error { return nil }
+
 // Debug is a no-op.
 func (n *noopLogger) Debug(_ string, _ ...Field) {}
 
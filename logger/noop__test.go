@@ -17,6 +17,21 @@ func TestNewNoOp(t *testing.T) {
 	var _ Logger = log
 }
 
+func TestNoOpLogger_Sync(t *testing.T) {
+	if err := NewNoOp().Sync(); err != nil {
+		t.Errorf("Sync() error = %v, want nil", err)
+	}
+}
+
+func TestNoOpLogger_Enabled(t *testing.T) {
+	log := NewNoOp()
+	for _, level := range []Level{LevelDebug, LevelInfo, LevelError, LevelPanic} {
+		if log.Enabled(level) {
+			t.Errorf("Enabled(%v) = true, want false", level)
+		}
+	}
+}
+
 func TestNoOpLogger_AllMethods(t *testing.T) {
 	log := NewNoOp()
 	ctx := context.WithValue(context.Background(), testContextKey("test"), "value")
@@ -25,6 +40,10 @@ func TestNoOpLogger_AllMethods(t *testing.T) {
 		name string
 		fn   func()
 	}{
+		{
+			name: "SetLevel",
+			fn:   func() { log.SetLevel(LevelDebug) },
+		},
 		{
 			name: "Debug",
 			fn:   func() { log.Debug("test", F("key", "value")) },
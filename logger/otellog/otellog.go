@@ -0,0 +1,38 @@
+// Package otellog correlates log lines with OpenTelemetry traces. It is a separate
+// package so that only services importing it depend on the OpenTelemetry API.
+package otellog
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/biairmal/go-sdk/logger"
+)
+
+// Field names written by OTelExtractor.
+const (
+	TraceIDField = "trace_id"
+	SpanIDField  = "span_id"
+)
+
+// OTelExtractor is a logger.ContextExtractor that reads the active span from ctx and
+// returns its trace_id and span_id as hex strings. It returns no fields when ctx
+// carries no valid span context.
+//
+// Use it as Options.ContextExtractor, or together with the default extractor to keep
+// request_id and user_id:
+//
+//	log := logger.NewZerolog(&logger.Options{
+//		ContextExtractor: logger.CombineExtractors(logger.DefaultContextExtractor, otellog.OTelExtractor),
+//	})
+func OTelExtractor(ctx context.Context) []logger.Field {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return nil
+	}
+	return []logger.Field{
+		logger.F(TraceIDField, sc.TraceID().String()),
+		logger.F(SpanIDField, sc.SpanID().String()),
+	}
+}
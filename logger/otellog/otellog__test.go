@@ -0,0 +1,78 @@
+package otellog
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/biairmal/go-sdk/logger"
+)
+
+// spanContext returns a context carrying a sampled span with fixed IDs.
+func spanContext(t *testing.T) context.Context {
+	t.Helper()
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	if err != nil {
+		t.Fatal(err)
+	}
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+	return trace.ContextWithSpanContext(context.Background(), sc)
+}
+
+func TestOTelExtractor(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want []logger.Field
+	}{
+		{name: "no span", ctx: context.Background(), want: nil},
+		{
+			name: "active span",
+			ctx:  spanContext(t),
+			want: []logger.Field{
+				logger.F("trace_id", "4bf92f3577b34da6a3ce929d0e0e4736"),
+				logger.F("span_id", "00f067aa0ba902b7"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := OTelExtractor(tt.ctx); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("OTelExtractor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOTelExtractor_withDefault(t *testing.T) {
+	var buf bytes.Buffer
+	log := logger.NewZerologWithWriter(&buf, &logger.Options{
+		ContextExtractor: logger.CombineExtractors(logger.DefaultContextExtractor, OTelExtractor),
+	})
+	ctx := context.WithValue(spanContext(t), logger.RequestIDKey, "req-1")
+
+	log.InfoWithContext(ctx, "handled")
+	out := buf.String()
+	for _, want := range []string{
+		`"request_id":"req-1"`,
+		`"trace_id":"4bf92f3577b34da6a3ce929d0e0e4736"`,
+		`"span_id":"00f067aa0ba902b7"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log output %s does not contain %s", out, want)
+		}
+	}
+}
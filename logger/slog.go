@@ -0,0 +1,363 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+)
+
+// slog levels used for the levels that log/slog does not define.
+const (
+	slogLevelFatal = slog.LevelError + 4
+	slogLevelPanic = slog.LevelError + 8
+)
+
+// slogLogger implements the Logger interface on top of a *slog.Logger.
+type slogLogger struct {
+	logger           *slog.Logger
+	level            *slog.LevelVar // Minimum level enforced by the adapter in addition to the handler's own
+	contextExtractor ContextExtractor
+}
+
+// NewSlog adapts a *slog.Logger to the Logger interface.
+//
+// Levels are mapped to their slog equivalents; Fatal and Panic are logged at
+// slog.LevelError+4 and slog.LevelError+8 and then exit the program or panic,
+// matching the zerolog implementation. Fields are translated to slog.Any attributes.
+// The *WithContext methods run the default context extractor (request_id, user_id,
+// trace_id) and pass the context on to the slog handler.
+//
+// The slog handler keeps its own minimum level. SetLevel adds a gate in front of
+// it, so it can raise the effective level but never log below the handler's level.
+// If l is nil, slog.Default() is used.
+//
+// Example:
+//
+//	log := logger.NewSlog(slog.New(slog.NewJSONHandler(os.Stdout, nil)))
+//	log.Info("Application started", logger.F("port", 8080))
+func NewSlog(l *slog.Logger) Logger {
+	if l == nil {
+		l = slog.Default()
+	}
+	level := &slog.LevelVar{}
+	level.Set(slog.LevelDebug)
+	return &slogLogger{
+		logger:           l,
+		level:            level,
+		contextExtractor: DefaultContextExtractor,
+	}
+}
+
+// toSlogLevel converts a Level to the corresponding slog.Level.
+// Returns slog.LevelInfo for unknown levels.
+func toSlogLevel(level Level) slog.Level {
+	switch level {
+	case LevelDebug:
+		return slog.LevelDebug
+	case LevelWarn:
+		return slog.LevelWarn
+	case LevelError:
+		return slog.LevelError
+	case LevelFatal:
+		return slogLevelFatal
+	case LevelPanic:
+		return slogLevelPanic
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// fromSlogLevel converts a slog.Level to the Level used by AsSlogHandler.
+// Levels at or above slog.LevelError map to LevelError.
+func fromSlogLevel(level slog.Level) Level {
+	switch {
+	case level >= slog.LevelError:
+		return LevelError
+	case level >= slog.LevelWarn:
+		return LevelWarn
+	case level >= slog.LevelInfo:
+		return LevelInfo
+	default:
+		return LevelDebug
+	}
+}
+
+// enabled reports whether a record at level would be emitted.
+func (l *slogLogger) enabled(ctx context.Context, level slog.Level) bool {
+	return level >= l.level.Level() && l.logger.Enabled(ctx, level)
+}
+
+// log emits msg with fields at level.
+func (l *slogLogger) log(ctx context.Context, level slog.Level, msg string, fields []Field) {
+	if !l.enabled(ctx, level) {
+		return
+	}
+	attrs := make([]slog.Attr, len(fields))
+	for i, field := range fields {
+		attrs[i] = slog.Any(field.Key, field.Value)
+	}
+	l.logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// logContext emits msg at level with context-extracted fields followed by fields.
+func (l *slogLogger) logContext(ctx context.Context, level slog.Level, msg string, fields []Field) {
+	if !l.enabled(ctx, level) {
+		return
+	}
+	if l.contextExtractor != nil {
+		fields = append(l.contextExtractor(ctx), fields...)
+	}
+	l.log(ctx, level, msg, fields)
+}
+
+// logFatal logs at fatal level and exits.
+func (l *slogLogger) logFatal(ctx context.Context, msg string, fields []Field, withContext bool) {
+	if withContext {
+		l.logContext(ctx, slogLevelFatal, msg, fields)
+	} else {
+		l.log(ctx, slogLevelFatal, msg, fields)
+	}
+	os.Exit(1)
+}
+
+// logPanic logs at panic level and panics with msg.
+func (l *slogLogger) logPanic(ctx context.Context, msg string, fields []Field, withContext bool) {
+	if withContext {
+		l.logContext(ctx, slogLevelPanic, msg, fields)
+	} else {
+		l.log(ctx, slogLevelPanic, msg, fields)
+	}
+	panic(msg)
+}
+
+// SetLevel atomically changes the minimum level enforced by the adapter.
+func (l *slogLogger) SetLevel(level Level) {
+	l.level.Set(toSlogLevel(level))
+}
+
+// Enabled reports whether both the adapter's minimum level and the slog handler accept level.
+func (l *slogLogger) Enabled(level Level) bool {
+	return l.enabled(context.Background(), toSlogLevel(level))
+}
+
+// Sync returns nil; slog handlers do not expose a flush operation.
+func (l *slogLogger) Sync() error {
+	return nil
+}
+
+// Debug logs a debug message.
+func (l *slogLogger) Debug(msg string, fields ...Field) {
+	l.log(context.Background(), slog.LevelDebug, msg, fields)
+}
+
+// Info logs an info message.
+func (l *slogLogger) Info(msg string, fields ...Field) {
+	l.log(context.Background(), slog.LevelInfo, msg, fields)
+}
+
+// Warn logs a warning message.
+func (l *slogLogger) Warn(msg string, fields ...Field) {
+	l.log(context.Background(), slog.LevelWarn, msg, fields)
+}
+
+// Error logs an error message.
+func (l *slogLogger) Error(msg string, fields ...Field) {
+	l.log(context.Background(), slog.LevelError, msg, fields)
+}
+
+// Fatal logs a fatal message and exits.
+func (l *slogLogger) Fatal(msg string, fields ...Field) {
+	l.logFatal(context.Background(), msg, fields, false)
+}
+
+// Panic logs a panic message and panics.
+func (l *slogLogger) Panic(msg string, fields ...Field) {
+	l.logPanic(context.Background(), msg, fields, false)
+}
+
+// Debugf logs a formatted debug message.
+func (l *slogLogger) Debugf(format string, args ...any) {
+	l.log(context.Background(), slog.LevelDebug, fmt.Sprintf(format, args...), nil)
+}
+
+// Infof logs a formatted info message.
+func (l *slogLogger) Infof(format string, args ...any) {
+	l.log(context.Background(), slog.LevelInfo, fmt.Sprintf(format, args...), nil)
+}
+
+// Warnf logs a formatted warning message.
+func (l *slogLogger) Warnf(format string, args ...any) {
+	l.log(context.Background(), slog.LevelWarn, fmt.Sprintf(format, args...), nil)
+}
+
+// Errorf logs a formatted error message.
+func (l *slogLogger) Errorf(format string, args ...any) {
+	l.log(context.Background(), slog.LevelError, fmt.Sprintf(format, args...), nil)
+}
+
+// Fatalf logs a formatted fatal message and exits.
+func (l *slogLogger) Fatalf(format string, args ...any) {
+	l.logFatal(context.Background(), fmt.Sprintf(format, args...), nil, false)
+}
+
+// Panicf logs a formatted panic message and panics.
+func (l *slogLogger) Panicf(format string, args ...any) {
+	l.logPanic(context.Background(), fmt.Sprintf(format, args...), nil, false)
+}
+
+// DebugWithContext logs a debug message with context.
+func (l *slogLogger) DebugWithContext(ctx context.Context, msg string, fields ...Field) {
+	l.logContext(ctx, slog.LevelDebug, msg, fields)
+}
+
+// InfoWithContext logs an info message with context.
+func (l *slogLogger) InfoWithContext(ctx context.Context, msg string, fields ...Field) {
+	l.logContext(ctx, slog.LevelInfo, msg, fields)
+}
+
+// WarnWithContext logs a warning message with context.
+func (l *slogLogger) WarnWithContext(ctx context.Context, msg string, fields ...Field) {
+	l.logContext(ctx, slog.LevelWarn, msg, fields)
+}
+
+// ErrorWithContext logs an error message with context.
+func (l *slogLogger) ErrorWithContext(ctx context.Context, msg string, fields ...Field) {
+	l.logContext(ctx, slog.LevelError, msg, fields)
+}
+
+// FatalWithContext logs a fatal message with context and exits.
+func (l *slogLogger) FatalWithContext(ctx context.Context, msg string, fields ...Field) {
+	l.logFatal(ctx, msg, fields, true)
+}
+
+// PanicWithContext logs a panic message with context and panics.
+func (l *slogLogger) PanicWithContext(ctx context.Context, msg string, fields ...Field) {
+	l.logPanic(ctx, msg, fields, true)
+}
+
+// DebugfWithContext logs a formatted debug message with context.
+func (l *slogLogger) DebugfWithContext(ctx context.Context, format string, args ...any) {
+	l.logContext(ctx, slog.LevelDebug, fmt.Sprintf(format, args...), nil)
+}
+
+// InfofWithContext logs a formatted info message with context.
+func (l *slogLogger) InfofWithContext(ctx context.Context, format string, args ...any) {
+	l.logContext(ctx, slog.LevelInfo, fmt.Sprintf(format, args...), nil)
+}
+
+// WarnfWithContext logs a formatted warning message with context.
+func (l *slogLogger) WarnfWithContext(ctx context.Context, format string, args ...any) {
+	l.logContext(ctx, slog.LevelWarn, fmt.Sprintf(format, args...), nil)
+}
+
+// ErrorfWithContext logs a formatted error message with context.
+func (l *slogLogger) ErrorfWithContext(ctx context.Context, format string, args ...any) {
+	l.logContext(ctx, slog.LevelError, fmt.Sprintf(format, args...), nil)
+}
+
+// FatalfWithContext logs a formatted fatal message with context and exits.
+func (l *slogLogger) FatalfWithContext(ctx context.Context, format string, args ...any) {
+	l.logFatal(ctx, fmt.Sprintf(format, args...), nil, true)
+}
+
+// PanicfWithContext logs a formatted panic message with context and panics.
+func (l *slogLogger) PanicfWithContext(ctx context.Context, format string, args ...any) {
+	l.logPanic(ctx, fmt.Sprintf(format, args...), nil, true)
+}
+
+// slogHandler implements slog.Handler by forwarding records to a Logger.
+type slogHandler struct {
+	logger Logger
+	attrs  []Field // Attributes added via WithAttrs, already prefixed with their group
+	prefix string  // Group prefix ("a.b.") applied to record attributes
+}
+
+// AsSlogHandler returns a slog.Handler that routes records through l, so libraries
+// that expect log/slog can log via this package's backends.
+//
+// Records at slog.LevelError and above are logged with ErrorWithContext (never Fatal
+// or Panic), Warn and Info map to their counterparts, and anything lower is logged at
+// debug level. Attributes become Fields; groups are flattened into dotted keys
+// ("group.key"). The record context is passed to the *WithContext methods, so the
+// logger's ContextExtractor still runs. The record time is ignored; the backend
+// stamps its own timestamp. Enabled consults l.Enabled, so disabled records are
+// skipped before their attributes are built.
+//
+// Example:
+//
+//	log := logger.NewZerolog(nil)
+//	slog.SetDefault(slog.New(logger.AsSlogHandler(log)))
+func AsSlogHandler(l Logger) slog.Handler {
+	return &slogHandler{logger: l}
+}
+
+// Enabled reports whether the wrapped Logger accepts records at level.
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.logger.Enabled(fromSlogLevel(level))
+}
+
+// Handle forwards the record to the wrapped Logger.
+func (h *slogHandler) Handle(ctx context.Context, r slog.Record) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	fields := make([]Field, len(h.attrs), len(h.attrs)+r.NumAttrs())
+	copy(fields, h.attrs)
+	r.Attrs(func(a slog.Attr) bool {
+		fields = appendSlogAttr(fields, h.prefix, a)
+		return true
+	})
+
+	switch fromSlogLevel(r.Level) {
+	case LevelError:
+		h.logger.ErrorWithContext(ctx, r.Message, fields...)
+	case LevelWarn:
+		h.logger.WarnWithContext(ctx, r.Message, fields...)
+	case LevelInfo:
+		h.logger.InfoWithContext(ctx, r.Message, fields...)
+	default:
+		h.logger.DebugWithContext(ctx, r.Message, fields...)
+	}
+	return nil
+}
+
+// WithAttrs returns a handler that includes attrs in every record.
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	fields := make([]Field, len(h.attrs), len(h.attrs)+len(attrs))
+	copy(fields, h.attrs)
+	for _, a := range attrs {
+		fields = appendSlogAttr(fields, h.prefix, a)
+	}
+	return &slogHandler{logger: h.logger, attrs: fields, prefix: h.prefix}
+}
+
+// WithGroup returns a handler that prefixes subsequent attribute keys with name.
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &slogHandler{logger: h.logger, attrs: h.attrs, prefix: h.prefix + name + "."}
+}
+
+// appendSlogAttr appends a as one or more Fields, flattening groups into dotted keys.
+func appendSlogAttr(fields []Field, prefix string, a slog.Attr) []Field {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return fields
+	}
+	if a.Value.Kind() == slog.KindGroup {
+		groupPrefix := prefix
+		if a.Key != "" {
+			groupPrefix = prefix + a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			fields = appendSlogAttr(fields, groupPrefix, ga)
+		}
+		return fields
+	}
+	return append(fields, Field{Key: prefix + a.Key, Value: a.Value.Any()})
+}
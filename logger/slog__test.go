@@ -0,0 +1,220 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func decodeLine(t *testing.T, line string) map[string]any {
+	t.Helper()
+	var m map[string]any
+	if err := json.Unmarshal([]byte(line), &m); err != nil {
+		t.Fatalf("json.Unmarshal(%q) error = %v", line, err)
+	}
+	return m
+}
+
+func TestNewSlog(t *testing.T) {
+	var buf bytes.Buffer
+	handler := slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})
+	log := NewSlog(slog.New(handler))
+
+	tests := []struct {
+		name      string
+		fn        func()
+		wantLevel string
+		wantMsg   string
+		wantAttrs map[string]any
+	}{
+		{
+			name:      "Debug with fields",
+			fn:        func() { log.Debug("debug msg", F("user_id", "u1")) },
+			wantLevel: "DEBUG", wantMsg: "debug msg",
+			wantAttrs: map[string]any{"user_id": "u1"},
+		},
+		{
+			name:      "Infof",
+			fn:        func() { log.Infof("count %d", 3) },
+			wantLevel: "INFO", wantMsg: "count 3",
+		},
+		{
+			name:      "Warn",
+			fn:        func() { log.Warn("warn msg") },
+			wantLevel: "WARN", wantMsg: "warn msg",
+		},
+		{
+			name: "ErrorWithContext runs context extractor",
+			fn: func() {
+				ctx := requestIDContext("req-1")
+				log.ErrorWithContext(ctx, "error msg", F("attempt", 2))
+			},
+			wantLevel: "ERROR", wantMsg: "error msg",
+			wantAttrs: map[string]any{"request_id": "req-1", "attempt": float64(2)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			tt.fn()
+			got := decodeLine(t, strings.TrimSpace(buf.String()))
+			if got["level"] != tt.wantLevel {
+				t.Errorf("level = %v, want %v", got["level"], tt.wantLevel)
+			}
+			if got["msg"] != tt.wantMsg {
+				t.Errorf("msg = %v, want %v", got["msg"], tt.wantMsg)
+			}
+			for k, v := range tt.wantAttrs {
+				if got[k] != v {
+					t.Errorf("attr %s = %v, want %v", k, got[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestNewSlog_SetLevel(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewSlog(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	log.SetLevel(LevelWarn)
+	log.Info("hidden")
+	if buf.Len() != 0 {
+		t.Errorf("Info() after SetLevel(LevelWarn) wrote %q, want nothing", buf.String())
+	}
+	log.Warn("shown")
+	if !strings.Contains(buf.String(), "shown") {
+		t.Errorf("Warn() after SetLevel(LevelWarn) = %q, want message", buf.String())
+	}
+}
+
+func TestNewSlog_Enabled(t *testing.T) {
+	log := NewSlog(slog.New(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{Level: slog.LevelInfo})))
+
+	if log.Enabled(LevelDebug) {
+		t.Errorf("Enabled(LevelDebug) = true, want false (handler level is info)")
+	}
+	if !log.Enabled(LevelInfo) {
+		t.Errorf("Enabled(LevelInfo) = false, want true")
+	}
+	log.SetLevel(LevelError)
+	if log.Enabled(LevelWarn) {
+		t.Errorf("Enabled(LevelWarn) after SetLevel(LevelError) = true, want false")
+	}
+}
+
+func TestNewSlog_Sync(t *testing.T) {
+	if err := NewSlog(nil).Sync(); err != nil {
+		t.Errorf("Sync() error = %v, want nil", err)
+	}
+}
+
+func TestNewSlog_Panic(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewSlog(slog.New(slog.NewJSONHandler(&buf, nil)))
+
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Errorf("Panic() recovered %v, want boom", r)
+		}
+		if !strings.Contains(buf.String(), "ERROR+8") {
+			t.Errorf("Panic() output = %q, want level ERROR+8", buf.String())
+		}
+	}()
+	log.Panic("boom")
+}
+
+func TestAsSlogHandler(t *testing.T) {
+	var buf bytes.Buffer
+	zl := newBufferedZerolog(&buf, LevelDebug)
+	sl := slog.New(AsSlogHandler(zl))
+
+	tests := []struct {
+		name      string
+		fn        func()
+		wantLevel string
+		wantMsg   string
+		wantAttrs map[string]any
+	}{
+		{
+			name:      "debug",
+			fn:        func() { sl.Debug("d", "k", "v") },
+			wantLevel: "debug", wantMsg: "d",
+			wantAttrs: map[string]any{"k": "v"},
+		},
+		{
+			name:      "info with attrs and group",
+			fn:        func() { sl.With("svc", "api").WithGroup("http").Info("i", "status", 200) },
+			wantLevel: "info", wantMsg: "i",
+			wantAttrs: map[string]any{"svc": "api", "http.status": float64(200)},
+		},
+		{
+			name:      "warn with inline group attr",
+			fn:        func() { sl.Warn("w", slog.Group("db", slog.String("op", "select"))) },
+			wantLevel: "warn", wantMsg: "w",
+			wantAttrs: map[string]any{"db.op": "select"},
+		},
+		{
+			name: "error with context extraction",
+			fn: func() {
+				ctx := requestIDContext("req-2")
+				sl.ErrorContext(ctx, "e")
+			},
+			wantLevel: "error", wantMsg: "e",
+			wantAttrs: map[string]any{"request_id": "req-2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			tt.fn()
+			got := decodeLine(t, strings.TrimSpace(buf.String()))
+			if got["level"] != tt.wantLevel {
+				t.Errorf("level = %v, want %v", got["level"], tt.wantLevel)
+			}
+			if got["message"] != tt.wantMsg {
+				t.Errorf("message = %v, want %v", got["message"], tt.wantMsg)
+			}
+			for k, v := range tt.wantAttrs {
+				if got[k] != v {
+					t.Errorf("field %s = %v, want %v", k, got[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestAsSlogHandler_Enabled(t *testing.T) {
+	h := AsSlogHandler(newBufferedZerolog(&bytes.Buffer{}, LevelWarn))
+
+	tests := []struct {
+		level slog.Level
+		want  bool
+	}{
+		{slog.LevelDebug, false},
+		{slog.LevelInfo, false},
+		{slog.LevelWarn, true},
+		{slog.LevelError, true},
+		{slog.LevelError + 4, true},
+	}
+	for _, tt := range tests {
+		if got := h.Enabled(context.Background(), tt.level); got != tt.want {
+			t.Errorf("Enabled(%v) = %v, want %v", tt.level, got, tt.want)
+		}
+	}
+}
+
+func TestAsSlogHandler_respectsLoggerLevel(t *testing.T) {
+	var buf bytes.Buffer
+	sl := slog.New(AsSlogHandler(newBufferedZerolog(&buf, LevelWarn)))
+
+	sl.Info("hidden")
+	if buf.Len() != 0 {
+		t.Errorf("Info() through handler at warn level wrote %q, want nothing", buf.String())
+	}
+}
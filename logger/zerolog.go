@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sync/atomic"
+	"time"
 
 	"github.com/rs/zerolog"
 	"gopkg.in/natefinch/lumberjack.v2"
@@ -13,8 +15,11 @@ import (
 // zerologLogger implements the Logger interface using rs/zerolog as the backend.
 type zerologLogger struct {
 	logger           zerolog.Logger
+	level            *atomic.Int32 // Current zerolog.Level; shared so SetLevel affects every copy
 	contextExtractor ContextExtractor
-	fileWriter       *lumberjack.Logger // Keep reference for cleanup if needed
+	fileWriter       *lumberjack.Logger // Keep reference for cleanup and Sync
+	writer           io.Writer          // Custom writer from NewZerologWithWriter, synced by Sync if supported
+	exitFunc         func(int)          // Called by the fatal methods after logging; os.Exit by default
 }
 
 // NewZerolog creates a new Logger instance using zerolog as the backend.
@@ -23,12 +28,18 @@ type zerologLogger struct {
 //   - Level: LevelInfo
 //   - Output: OutputStdout
 //   - Format: FormatText
-//   - ContextExtractor: defaultContextExtractor (extracts request_id, user_id, trace_id)
+//   - ContextExtractor: DefaultContextExtractor (extracts request_id, user_id, trace_id)
+//   - Sampling: disabled
+//   - ExitFunc: os.Exit
 //
 // When Output is OutputFile, file rotation is automatically enabled with default settings
 // unless Rotation is explicitly configured. File output always uses JSON format regardless
 // of the Format setting.
 //
+// When Output is OutputMulti, logs are written to every destination listed in Outputs
+// (for example stdout and a rotating file). Format applies to the console destinations
+// only; the file destination still receives JSON and honors Rotation.
+//
 // Example:
 //
 //	// Basic usage with defaults
@@ -56,79 +67,186 @@ func NewZerolog(opts *Options) Logger {
 		}
 	}
 
+	writers, fileWriter := newSinkWriters(opts)
+
 	var writer io.Writer
-	var fileWriter *lumberjack.Logger
+	if len(writers) == 1 {
+		writer = writers[0]
+	} else {
+		writer = zerolog.MultiLevelWriter(writers...)
+	}
+	baseLogger := withTimestamp(zerolog.New(writer), opts)
 
-	// Determine output writer based on Output setting
-	switch opts.Output {
-	case OutputFile:
-		// File output with rotation
-		rotation := opts.Rotation
-		if rotation == nil {
-			rotation = &RotationConfig{
-				Filename:   "app.log",
-				MaxSize:    100,
-				MaxBackups: 5,
-				MaxAge:     30,
-				Compress:   true,
-				LocalTime:  true,
-			}
-		}
+	return newZerologLogger(baseLogger, opts, fileWriter)
+}
+
+// newSinkWriters returns one writer per configured output destination, and the
+// rotating file writer when file output is enabled. Console sinks are wrapped in a
+// zerolog.ConsoleWriter unless Format is FormatJSON; the file sink always receives JSON.
+// With OutputMulti, each entry of Outputs becomes a sink (duplicates are ignored);
+// an empty Outputs falls back to stdout.
+func newSinkWriters(opts *Options) ([]io.Writer, *lumberjack.Logger) {
+	outputs := []Output{opts.Output}
+	if opts.Output == OutputMulti {
+		outputs = opts.Outputs
+	}
 
-		// Set defaults for rotation config
-		if rotation.Filename == "" {
-			rotation.Filename = "app.log"
+	var writers []io.Writer
+	var fileWriter *lumberjack.Logger
+	seen := make(map[Output]bool, len(outputs))
+	for _, output := range outputs {
+		if output == OutputMulti || seen[output] {
+			continue
 		}
-		if rotation.MaxSize == 0 {
-			rotation.MaxSize = 100 // 100 MB default
+		seen[output] = true
+
+		switch output {
+		case OutputFile:
+			fileWriter = newFileWriter(opts.Rotation)
+			writers = append(writers, fileWriter)
+		case OutputStderr:
+			writers = append(writers, consoleWriter(os.Stderr, opts))
+		default: // OutputStdout
+			writers = append(writers, consoleWriter(os.Stdout, opts))
 		}
+	}
+
+	if len(writers) == 0 {
+		writers = append(writers, consoleWriter(os.Stdout, opts))
+	}
+	return writers, fileWriter
+}
 
-		fileWriter = &lumberjack.Logger{
-			Filename:   rotation.Filename,
-			MaxSize:    rotation.MaxSize,
-			MaxBackups: rotation.MaxBackups,
-			MaxAge:     rotation.MaxAge,
-			Compress:   rotation.Compress,
-			LocalTime:  rotation.LocalTime,
+// consoleWriter wraps w in a colored zerolog.ConsoleWriter unless Format is FormatJSON.
+// A custom TimeFieldName is mapped back to the console timestamp column.
+func consoleWriter(w io.Writer, opts *Options) io.Writer {
+	if opts.Format == FormatJSON {
+		return w
+	}
+	cw := zerolog.ConsoleWriter{Out: w, NoColor: false}
+	if name := opts.TimeFieldName; name != "" && name != zerolog.TimestampFieldName {
+		cw.FormatPrepare = func(evt map[string]any) error {
+			if v, ok := evt[name]; ok {
+				evt[zerolog.TimestampFieldName] = v
+				delete(evt, name)
+			}
+			return nil
 		}
-		writer = fileWriter
+	}
+	return cw
+}
 
-	case OutputStderr:
-		writer = os.Stderr
+// withTimestamp adds a timestamp to every event of zl. When TimeFieldName and
+// TimeFormat are empty, zerolog's defaults ("time", RFC3339) are used; otherwise
+// the timestamp is added with the configured key and layout, without touching
+// zerolog's package-level settings.
+func withTimestamp(zl zerolog.Logger, opts *Options) zerolog.Logger {
+	if opts.TimeFieldName == "" && opts.TimeFormat == "" {
+		return zl.With().Timestamp().Logger()
+	}
 
-	default: // OutputStdout
-		writer = os.Stdout
+	name := opts.TimeFieldName
+	if name == "" {
+		name = zerolog.TimestampFieldName
+	}
+	format := opts.TimeFormat
+	if format == "" {
+		format = zerolog.TimeFieldFormat
 	}
+	return zl.Hook(zerolog.HookFunc(func(e *zerolog.Event, _ zerolog.Level, _ string) {
+		e.Str(name, zerolog.TimestampFunc().Format(format))
+	}))
+}
 
-	// Configure zerolog with appropriate writer
-	var baseLogger zerolog.Logger
-	if opts.Format == FormatJSON {
-		baseLogger = zerolog.New(writer).With().Timestamp().Logger()
-	} else {
-		// For file output, always use JSON format for structured logging
-		// For console output, use pretty console writer
-		if opts.Output == OutputFile {
-			baseLogger = zerolog.New(writer).With().Timestamp().Logger()
-		} else {
-			output := zerolog.ConsoleWriter{Out: writer, NoColor: false}
-			baseLogger = zerolog.New(output).With().Timestamp().Logger()
+// newFileWriter creates the rotating file writer, applying default rotation settings.
+func newFileWriter(rotation *RotationConfig) *lumberjack.Logger {
+	if rotation == nil {
+		rotation = &RotationConfig{
+			Filename:   "app.log",
+			MaxSize:    100,
+			MaxBackups: 5,
+			MaxAge:     30,
+			Compress:   true,
+			LocalTime:  true,
 		}
 	}
 
-	// Set log level
-	level := parseZerologLevel(opts.Level)
-	baseLogger = baseLogger.Level(level)
+	// Set defaults for rotation config
+	if rotation.Filename == "" {
+		rotation.Filename = "app.log"
+	}
+	if rotation.MaxSize == 0 {
+		rotation.MaxSize = 100 // 100 MB default
+	}
+
+	return &lumberjack.Logger{
+		Filename:   rotation.Filename,
+		MaxSize:    rotation.MaxSize,
+		MaxBackups: rotation.MaxBackups,
+		MaxAge:     rotation.MaxAge,
+		Compress:   rotation.Compress,
+		LocalTime:  rotation.LocalTime,
+	}
+}
+
+// NewZerologWithWriter creates a new Logger that writes JSON log lines to w.
+//
+// It is intended for tests that need to assert on produced log lines without
+// touching the filesystem or stdout. The Output, Format, and Rotation options are
+// ignored; all other options (Level, ContextExtractor, Sampling, ...) apply as in
+// NewZerolog. If opts is nil, Level defaults to LevelInfo.
+//
+// Example:
+//
+//	var buf bytes.Buffer
+//	log := logger.NewZerologWithWriter(&buf, &logger.Options{Level: logger.LevelDebug})
+//	log.Info("hello", logger.F("user_id", 1))
+//	// buf contains {"level":"info","user_id":1,"time":"...","message":"hello"}
+func NewZerologWithWriter(w io.Writer, opts *Options) Logger {
+	if opts == nil {
+		opts = &Options{Level: LevelInfo}
+	}
+
+	baseLogger := withTimestamp(zerolog.New(w), opts)
+	l := newZerologLogger(baseLogger, opts, nil)
+	l.writer = w
+	return l
+}
+
+// newZerologLogger applies the writer-independent options to baseLogger.
+func newZerologLogger(baseLogger zerolog.Logger, opts *Options, fileWriter *lumberjack.Logger) *zerologLogger {
+	if opts.ReportCaller {
+		// Skip zerolog's own frames plus one for the zerologLogger method, so the
+		// reported location is the user's call site
+		skip := zerolog.CallerSkipFrameCount + 1 + max(opts.CallerSkipFrames, 0)
+		baseLogger = baseLogger.With().CallerWithSkipFrameCount(skip).Logger()
+	}
+
+	if opts.Sampling != nil {
+		baseLogger = baseLogger.Sample(newZerologSampler(opts.Sampling))
+	}
+
+	// Set log level; the level is held atomically so SetLevel can change it at runtime
+	level := &atomic.Int32{}
+	level.Store(int32(parseZerologLevel(opts.Level)))
 
 	// Set context extractor, default if not provided
 	contextExtractor := opts.ContextExtractor
 	if contextExtractor == nil {
-		contextExtractor = defaultContextExtractor
+		contextExtractor = DefaultContextExtractor
+	}
+
+	exitFunc := opts.ExitFunc
+	if exitFunc == nil {
+		exitFunc = os.Exit
 	}
 
 	return &zerologLogger{
 		logger:           baseLogger,
+		level:            level,
 		contextExtractor: contextExtractor,
 		fileWriter:       fileWriter,
+		exitFunc:         exitFunc,
 	}
 }
 
@@ -153,39 +271,77 @@ func parseZerologLevel(level Level) zerolog.Level {
 	}
 }
 
-// defaultContextExtractor extracts common context values for logging.
-// It extracts request_id, user_id, and trace_id from the context if present.
-// This can be overridden by providing a custom ContextExtractor in Options.
-func defaultContextExtractor(ctx context.Context) []Field {
-	var fields []Field
-
-	// Extract request ID if present
-	if reqID := ctx.Value("request_id"); reqID != nil {
-		fields = append(fields, Field{Key: "request_id", Value: reqID})
+// newZerologSampler builds a zerolog sampler from cfg. Each level from Debug to Error
+// gets its own burst counter; Fatal and Panic are not covered by LevelSampler and
+// are therefore never sampled.
+func newZerologSampler(cfg *SamplingConfig) zerolog.Sampler {
+	newLevelSampler := func() zerolog.Sampler {
+		tick := cfg.Tick
+		if tick <= 0 {
+			tick = time.Second
+		}
+		sampler := &zerolog.BurstSampler{
+			Burst:  uint32(max(cfg.Initial, 0)), //nolint:gosec // clamped to non-negative
+			Period: tick,
+		}
+		if cfg.Thereafter > 0 {
+			sampler.NextSampler = &zerolog.BasicSampler{N: uint32(cfg.Thereafter)} //nolint:gosec // checked positive
+		}
+		return sampler
 	}
 
-	// Extract user ID if present
-	if userID := ctx.Value("user_id"); userID != nil {
-		fields = append(fields, Field{Key: "user_id", Value: userID})
+	return zerolog.LevelSampler{
+		TraceSampler: newLevelSampler(),
+		DebugSampler: newLevelSampler(),
+		InfoSampler:  newLevelSampler(),
+		WarnSampler:  newLevelSampler(),
+		ErrorSampler: newLevelSampler(),
 	}
+}
+
+// DefaultContextExtractor is the ContextExtractor used when Options.ContextExtractor is nil.
+// It extracts request_id, user_id, and trace_id from the context if present, reading
+// the typed keys RequestIDKey, UserIDKey, and TraceIDKey first and falling back to the
+// plain string keys "request_id", "user_id", and "trace_id" for backward compatibility.
+// Custom extractors can call it to keep these fields and add their own.
+func DefaultContextExtractor(ctx context.Context) []Field {
+	var fields []Field
 
-	// Extract trace ID if present
-	if traceID := ctx.Value("trace_id"); traceID != nil {
-		fields = append(fields, Field{Key: "trace_id", Value: traceID})
+	for _, key := range []contextKey{RequestIDKey, UserIDKey, TraceIDKey} {
+		if value := contextValue(ctx, key); value != nil {
+			fields = append(fields, Field{Key: string(key), Value: value})
+		}
 	}
 
 	return fields
 }
 
+// contextValue returns the value stored under the typed key, or under its plain
+// string form if the typed key is not set.
+func contextValue(ctx context.Context, key contextKey) any {
+	if value := ctx.Value(key); value != nil {
+		return value
+	}
+	return ctx.Value(string(key))
+}
+
 // addFields adds structured fields to a zerolog event from a variadic Field slice.
-// If no fields are provided, the event is returned unchanged.
+// Values implementing zerolog.LogObjectMarshaler (such as those built by Err) are
+// written as nested objects, and Duration values as float milliseconds. If no fields are provided, the event is returned unchanged.
 func addFields(event *zerolog.Event, fields ...Field) *zerolog.Event {
 	if len(fields) == 0 {
 		return event
 	}
 
 	for _, field := range fields {
-		event = event.Interface(field.Key, field.Value)
+		switch v := field.Value.(type) {
+		case zerolog.LogObjectMarshaler:
+			event = event.Object(field.Key, v)
+		case durationValue:
+			event = event.Float64(field.Key, v.millis())
+		default:
+			event = event.Interface(field.Key, field.Value)
+		}
 	}
 
 	return event
@@ -202,81 +358,126 @@ func (l *zerologLogger) addContextFields(ctx context.Context, event *zerolog.Eve
 	return addFields(event, fields...)
 }
 
+// current returns the underlying zerolog logger at the current level.
+func (l *zerologLogger) current() *zerolog.Logger {
+	zl := l.logger.Level(zerolog.Level(l.level.Load()))
+	return &zl
+}
+
+// SetLevel atomically changes the minimum level. It is safe to call concurrently with logging.
+func (l *zerologLogger) SetLevel(level Level) {
+	l.level.Store(int32(parseZerologLevel(level)))
+}
+
+// exit flushes pending output and calls the configured exit function with code 1.
+// It runs after every fatal-level call, mirroring zerolog's Fatal.
+func (l *zerologLogger) exit() {
+	_ = l.Sync()
+	l.exitFunc(1)
+}
+
+// Enabled reports whether level is at or above the current minimum level.
+func (l *zerologLogger) Enabled(level Level) bool {
+	return parseZerologLevel(level) >= zerolog.Level(l.level.Load())
+}
+
+// Sync flushes pending log output.
+// For file output, the rotating file is closed so its contents are handed to the
+// operating system; it is reopened automatically on the next write. For a writer
+// passed to NewZerologWithWriter that has a Sync() error method (e.g. *os.File),
+// that method is called. Stdout and stderr are unbuffered and Sync returns nil.
+func (l *zerologLogger) Sync() error {
+	if l.fileWriter != nil {
+		if err := l.fileWriter.Close(); err != nil {
+			return fmt.Errorf("logger: sync file: %w", err)
+		}
+		return nil
+	}
+	if s, ok := l.writer.(interface{ Sync() error }); ok {
+		if err := s.Sync(); err != nil {
+			return fmt.Errorf("logger: sync writer: %w", err)
+		}
+	}
+	return nil
+}
+
 // Debug logs a debug message.
 func (l *zerologLogger) Debug(msg string, fields ...Field) {
-	event := l.logger.Debug()
+	event := l.current().Debug()
 	event = addFields(event, fields...)
 	event.Msg(msg)
 }
 
 // Info logs an info message.
 func (l *zerologLogger) Info(msg string, fields ...Field) {
-	event := l.logger.Info()
+	event := l.current().Info()
 	event = addFields(event, fields...)
 	event.Msg(msg)
 }
 
 // Warn logs a warning message.
 func (l *zerologLogger) Warn(msg string, fields ...Field) {
-	event := l.logger.Warn()
+	event := l.current().Warn()
 	event = addFields(event, fields...)
 	event.Msg(msg)
 }
 
 // Error logs an error message.
 func (l *zerologLogger) Error(msg string, fields ...Field) {
-	event := l.logger.Error()
+	event := l.current().Error()
 	event = addFields(event, fields...)
 	event.Msg(msg)
 }
 
 // Fatal logs a fatal message and exits.
 func (l *zerologLogger) Fatal(msg string, fields ...Field) {
-	event := l.logger.Fatal()
+	event := l.current().WithLevel(zerolog.FatalLevel)
 	event = addFields(event, fields...)
 	event.Msg(msg)
+	l.exit()
 }
 
 // Panic logs a panic message and panics.
 func (l *zerologLogger) Panic(msg string, fields ...Field) {
-	event := l.logger.Panic()
+	event := l.current().Panic()
 	event = addFields(event, fields...)
 	event.Msg(msg)
 }
 
 // Debugf logs a formatted debug message.
 func (l *zerologLogger) Debugf(format string, args ...any) {
-	l.logger.Debug().Msg(fmt.Sprintf(format, args...))
+	l.current().Debug().Msg(fmt.Sprintf(format, args...))
 }
 
 // Infof logs a formatted info message.
 func (l *zerologLogger) Infof(format string, args ...any) {
-	l.logger.Info().Msg(fmt.Sprintf(format, args...))
+	l.current().Info().Msg(fmt.Sprintf(format, args...))
 }
 
 // Warnf logs a formatted warning message.
 func (l *zerologLogger) Warnf(format string, args ...any) {
-	l.logger.Warn().Msg(fmt.Sprintf(format, args...))
+	l.current().Warn().Msg(fmt.Sprintf(format, args...))
 }
 
 // Errorf logs a formatted error message.
 func (l *zerologLogger) Errorf(format string, args ...any) {
-	l.logger.Error().Msg(fmt.Sprintf(format, args...))
+	l.current().Error().Msg(fmt.Sprintf(format, args...))
 }
 
 // Fatalf logs a formatted fatal message and exits.
 func (l *zerologLogger) Fatalf(format string, args ...any) {
-	l.logger.Fatal().Msg(fmt.Sprintf(format, args...))
+	l.current().WithLevel(zerolog.FatalLevel).Msg(fmt.Sprintf(format, args...))
+	l.exit()
 }
 
 // Panicf logs a formatted panic message and panics.
 func (l *zerologLogger) Panicf(format string, args ...any) {
-	l.logger.Panic().Msg(fmt.Sprintf(format, args...))
+	l.current().Panic().Msg(fmt.Sprintf(format, args...))
 }
 
 // DebugWithContext logs a debug message with context.
 func (l *zerologLogger) DebugWithContext(ctx context.Context, msg string, fields ...Field) {
-	event := l.logger.Debug()
+	event := l.current().Debug()
 	event = l.addContextFields(ctx, event)
 	event = addFields(event, fields...)
 	event.Msg(msg)
@@ -284,7 +485,7 @@ func (l *zerologLogger) DebugWithContext(ctx context.Context, msg string, fields
 
 // InfoWithContext logs an info message with context.
 func (l *zerologLogger) InfoWithContext(ctx context.Context, msg string, fields ...Field) {
-	event := l.logger.Info()
+	event := l.current().Info()
 	event = l.addContextFields(ctx, event)
 	event = addFields(event, fields...)
 	event.Msg(msg)
@@ -292,7 +493,7 @@ func (l *zerologLogger) InfoWithContext(ctx context.Context, msg string, fields
 
 // WarnWithContext logs a warning message with context.
 func (l *zerologLogger) WarnWithContext(ctx context.Context, msg string, fields ...Field) {
-	event := l.logger.Warn()
+	event := l.current().Warn()
 	event = l.addContextFields(ctx, event)
 	event = addFields(event, fields...)
 	event.Msg(msg)
@@ -300,7 +501,7 @@ func (l *zerologLogger) WarnWithContext(ctx context.Context, msg string, fields
 
 // ErrorWithContext logs an error message with context.
 func (l *zerologLogger) ErrorWithContext(ctx context.Context, msg string, fields ...Field) {
-	event := l.logger.Error()
+	event := l.current().Error()
 	event = l.addContextFields(ctx, event)
 	event = addFields(event, fields...)
 	event.Msg(msg)
@@ -308,15 +509,16 @@ func (l *zerologLogger) ErrorWithContext(ctx context.Context, msg string, fields
 
 // FatalWithContext logs a fatal message with context and exits.
 func (l *zerologLogger) FatalWithContext(ctx context.Context, msg string, fields ...Field) {
-	event := l.logger.Fatal()
+	event := l.current().WithLevel(zerolog.FatalLevel)
 	event = l.addContextFields(ctx, event)
 	event = addFields(event, fields...)
 	event.Msg(msg)
+	l.exit()
 }
 
 // PanicWithContext logs a panic message with context and panics.
 func (l *zerologLogger) PanicWithContext(ctx context.Context, msg string, fields ...Field) {
-	event := l.logger.Panic()
+	event := l.current().Panic()
 	event = l.addContextFields(ctx, event)
 	event = addFields(event, fields...)
 	event.Msg(msg)
@@ -324,42 +526,43 @@ func (l *zerologLogger) PanicWithContext(ctx context.Context, msg string, fields
 
 // DebugfWithContext logs a formatted debug message with context.
 func (l *zerologLogger) DebugfWithContext(ctx context.Context, format string, args ...any) {
-	event := l.logger.Debug()
+	event := l.current().Debug()
 	event = l.addContextFields(ctx, event)
 	event.Msg(fmt.Sprintf(format, args...))
 }
 
 // InfofWithContext logs a formatted info message with context.
 func (l *zerologLogger) InfofWithContext(ctx context.Context, format string, args ...any) {
-	event := l.logger.Info()
+	event := l.current().Info()
 	event = l.addContextFields(ctx, event)
 	event.Msg(fmt.Sprintf(format, args...))
 }
 
 // WarnfWithContext logs a formatted warning message with context.
 func (l *zerologLogger) WarnfWithContext(ctx context.Context, format string, args ...any) {
-	event := l.logger.Warn()
+	event := l.current().Warn()
 	event = l.addContextFields(ctx, event)
 	event.Msg(fmt.Sprintf(format, args...))
 }
 
 // ErrorfWithContext logs a formatted error message with context.
 func (l *zerologLogger) ErrorfWithContext(ctx context.Context, format string, args ...any) {
-	event := l.logger.Error()
+	event := l.current().Error()
 	event = l.addContextFields(ctx, event)
 	event.Msg(fmt.Sprintf(format, args...))
 }
 
 // FatalfWithContext logs a formatted fatal message with context and exits.
 func (l *zerologLogger) FatalfWithContext(ctx context.Context, format string, args ...any) {
-	event := l.logger.Fatal()
+	event := l.current().WithLevel(zerolog.FatalLevel)
 	event = l.addContextFields(ctx, event)
 	event.Msg(fmt.Sprintf(format, args...))
+	l.exit()
 }
 
 // PanicfWithContext logs a formatted panic message with context and panics.
 func (l *zerologLogger) PanicfWithContext(ctx context.Context, format string, args ...any) {
-	event := l.logger.Panic()
+	event := l.current().Panic()
 	event = l.addContextFields(ctx, event)
 	event.Msg(fmt.Sprintf(format, args...))
 }
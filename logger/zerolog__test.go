@@ -0,0 +1,565 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// newBufferedZerolog returns a JSON zerolog-backed Logger writing to buf.
+func newBufferedZerolog(buf *bytes.Buffer, level Level) *zerologLogger {
+	return NewZerologWithWriter(buf, &Options{Level: level}).(*zerologLogger)
+}
+
+// requestIDContext returns a context carrying id under RequestIDKey.
+func requestIDContext(id string) context.Context {
+	return context.WithValue(context.Background(), RequestIDKey, id)
+}
+
+// legacyContext returns a context carrying value under a plain string key.
+func legacyContext(key, value string) context.Context {
+	return context.WithValue(context.Background(), key, value) //nolint:staticcheck // legacy string key
+}
+
+func TestDefaultContextExtractor(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want []Field
+	}{
+		{"empty context", context.Background(), nil},
+		{
+			name: "typed keys",
+			ctx: context.WithValue(context.WithValue(context.WithValue(context.Background(),
+				RequestIDKey, "req-1"), UserIDKey, 7), TraceIDKey, "trace-1"),
+			want: []Field{F("request_id", "req-1"), F("user_id", 7), F("trace_id", "trace-1")},
+		},
+		{
+			name: "string keys for backward compatibility",
+			ctx:  legacyContext("request_id", "req-2"),
+			want: []Field{F("request_id", "req-2")},
+		},
+		{
+			name: "typed key wins over string key",
+			ctx:  context.WithValue(legacyContext("user_id", "legacy"), UserIDKey, "typed"),
+			want: []Field{F("user_id", "typed")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DefaultContextExtractor(tt.ctx); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DefaultContextExtractor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewZerologWithWriter(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewZerologWithWriter(&buf, &Options{
+		Level:  LevelDebug,
+		Output: OutputFile,
+		Format: FormatText,
+	})
+
+	ctx := requestIDContext("req-1")
+	log.DebugWithContext(ctx, "hello", F("user_id", 42))
+
+	got := decodeLine(t, strings.TrimSpace(buf.String()))
+	want := map[string]any{
+		"level":      "debug",
+		"message":    "hello",
+		"user_id":    float64(42),
+		"request_id": "req-1",
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("field %s = %v, want %v", k, got[k], v)
+		}
+	}
+	if _, ok := got["time"]; !ok {
+		t.Errorf("log line %v has no time field", got)
+	}
+}
+
+func TestNewZerologWithWriter_nilOptions(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewZerologWithWriter(&buf, nil)
+
+	log.Debug("hidden")
+	log.Info("shown")
+	if got := countLines(&buf); got != 1 {
+		t.Errorf("logged %d lines, want 1 (default level info)", got)
+	}
+}
+
+func TestZerologLogger_SetLevel(t *testing.T) {
+	var buf bytes.Buffer
+	log := newBufferedZerolog(&buf, LevelInfo)
+
+	log.Debug("hidden")
+	if buf.Len() != 0 {
+		t.Fatalf("Debug() at info level wrote %q, want nothing", buf.String())
+	}
+
+	log.SetLevel(LevelDebug)
+	log.Debug("visible")
+	if !strings.Contains(buf.String(), "visible") {
+		t.Errorf("Debug() after SetLevel(LevelDebug) = %q, want message", buf.String())
+	}
+
+	buf.Reset()
+	log.SetLevel(LevelError)
+	log.Warn("hidden")
+	log.Infof("hidden %d", 1)
+	if buf.Len() != 0 {
+		t.Errorf("Warn()/Infof() after SetLevel(LevelError) wrote %q, want nothing", buf.String())
+	}
+	log.Error("shown")
+	if !strings.Contains(buf.String(), "shown") {
+		t.Errorf("Error() after SetLevel(LevelError) = %q, want message", buf.String())
+	}
+}
+
+func TestZerologLogger_SetLevel_concurrent(t *testing.T) {
+	log := newBufferedZerolog(&bytes.Buffer{}, LevelInfo)
+	log.logger = zerolog.Nop()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				log.Info("msg")
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				log.SetLevel(LevelDebug)
+				log.SetLevel(LevelWarn)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func countLines(buf *bytes.Buffer) int {
+	return strings.Count(buf.String(), "\n")
+}
+
+func TestZerologLogger_Sampling(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      SamplingConfig
+		messages int
+		want     int
+	}{
+		{"initial then every third", SamplingConfig{Initial: 2, Thereafter: 3, Tick: time.Hour}, 10, 5},
+		{"initial only", SamplingConfig{Initial: 3, Tick: time.Hour}, 10, 3},
+		{"every message after zero initial", SamplingConfig{Thereafter: 1, Tick: time.Hour}, 10, 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			log := newBufferedZerolog(&buf, LevelDebug)
+			log.logger = log.logger.Sample(newZerologSampler(&tt.cfg))
+
+			for i := 0; i < tt.messages; i++ {
+				log.Info("msg")
+			}
+			if got := countLines(&buf); got != tt.want {
+				t.Errorf("logged %d of %d messages, want %d", got, tt.messages, tt.want)
+			}
+		})
+	}
+}
+
+func TestZerologLogger_Sampling_tickWindow(t *testing.T) {
+	var buf bytes.Buffer
+	log := newBufferedZerolog(&buf, LevelDebug)
+	log.logger = log.logger.Sample(newZerologSampler(&SamplingConfig{Initial: 2, Tick: 50 * time.Millisecond}))
+
+	for i := 0; i < 5; i++ {
+		log.Info("first window")
+	}
+	if got := countLines(&buf); got != 2 {
+		t.Fatalf("first window logged %d messages, want 2", got)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	for i := 0; i < 5; i++ {
+		log.Info("second window")
+	}
+	if got := countLines(&buf); got != 4 {
+		t.Errorf("after second window logged %d messages, want 4", got)
+	}
+}
+
+func TestZerologLogger_Sampling_levelsIndependent(t *testing.T) {
+	var buf bytes.Buffer
+	log := newBufferedZerolog(&buf, LevelDebug)
+	log.logger = log.logger.Sample(newZerologSampler(&SamplingConfig{Initial: 1, Tick: time.Hour}))
+
+	log.Info("info 1")
+	log.Info("info 2")
+	log.Error("error 1")
+	if got := countLines(&buf); got != 2 {
+		t.Errorf("logged %d messages, want 2 (one per level)", got)
+	}
+}
+
+func TestZerologLogger_Sampling_panicNeverSampled(t *testing.T) {
+	var buf bytes.Buffer
+	log := newBufferedZerolog(&buf, LevelDebug)
+	log.logger = log.logger.Sample(newZerologSampler(&SamplingConfig{Tick: time.Hour}))
+
+	for i := 0; i < 3; i++ {
+		func() {
+			defer func() { _ = recover() }()
+			log.Panic("panic")
+		}()
+	}
+	if got := countLines(&buf); got != 3 {
+		t.Errorf("logged %d panic messages, want 3", got)
+	}
+}
+
+func TestNewSinkWriters(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        *Options
+		wantWriters int
+		wantFile    bool
+	}{
+		{"stdout", &Options{Output: OutputStdout}, 1, false},
+		{"file", &Options{Output: OutputFile, Rotation: &RotationConfig{Filename: "x.log"}}, 1, true},
+		{
+			name: "multi stdout and file",
+			opts: &Options{
+				Output: OutputMulti, Outputs: []Output{OutputStdout, OutputFile},
+				Rotation: &RotationConfig{Filename: "x.log"},
+			},
+			wantWriters: 2, wantFile: true,
+		},
+		{
+			name:        "multi ignores duplicates",
+			opts:        &Options{Output: OutputMulti, Outputs: []Output{OutputStderr, OutputStderr, OutputMulti}},
+			wantWriters: 1, wantFile: false,
+		},
+		{"multi without outputs falls back to stdout", &Options{Output: OutputMulti}, 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writers, fileWriter := newSinkWriters(tt.opts)
+			if len(writers) != tt.wantWriters {
+				t.Errorf("newSinkWriters() returned %d writers, want %d", len(writers), tt.wantWriters)
+			}
+			if (fileWriter != nil) != tt.wantFile {
+				t.Errorf("newSinkWriters() fileWriter = %v, want file %v", fileWriter, tt.wantFile)
+			}
+		})
+	}
+}
+
+func TestNewSinkWriters_formatAppliesToConsoleOnly(t *testing.T) {
+	writers, fileWriter := newSinkWriters(&Options{
+		Output:   OutputMulti,
+		Outputs:  []Output{OutputStdout, OutputFile},
+		Format:   FormatText,
+		Rotation: &RotationConfig{Filename: "x.log"},
+	})
+	if _, ok := writers[0].(zerolog.ConsoleWriter); !ok {
+		t.Errorf("stdout sink = %T, want zerolog.ConsoleWriter", writers[0])
+	}
+	if writers[1] != io.Writer(fileWriter) {
+		t.Errorf("file sink = %T, want raw file writer (JSON)", writers[1])
+	}
+}
+
+func TestNewZerolog_multiOutputWritesFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "app.log")
+	log := NewZerolog(&Options{
+		Output:   OutputMulti,
+		Outputs:  []Output{OutputFile, OutputFile},
+		Format:   FormatJSON,
+		Rotation: &RotationConfig{Filename: filename},
+	})
+	log.Info("to file")
+	log.(*zerologLogger).fileWriter.Close()
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("os.ReadFile() error = %v", err)
+	}
+	if !strings.Contains(string(data), `"message":"to file"`) {
+		t.Errorf("file content = %q, want JSON message", data)
+	}
+}
+
+func TestNewZerologWithWriter_timeField(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       *Options
+		wantKey    string
+		wantLayout string
+	}{
+		{"defaults", &Options{}, "time", time.RFC3339},
+		{
+			name:    "custom name and format",
+			opts:    &Options{TimeFieldName: "@timestamp", TimeFormat: time.RFC3339Nano},
+			wantKey: "@timestamp", wantLayout: time.RFC3339Nano,
+		},
+		{"custom name only", &Options{TimeFieldName: "ts"}, "ts", time.RFC3339},
+		{"custom format only", &Options{TimeFormat: time.DateTime}, "time", time.DateTime},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			NewZerologWithWriter(&buf, tt.opts).Info("msg")
+
+			line := decodeLine(t, strings.TrimSpace(buf.String()))
+			raw, ok := line[tt.wantKey].(string)
+			if !ok {
+				t.Fatalf("log line %v has no %q field", line, tt.wantKey)
+			}
+			if _, err := time.Parse(tt.wantLayout, raw); err != nil {
+				t.Errorf("%s = %q does not match layout %q: %v", tt.wantKey, raw, tt.wantLayout, err)
+			}
+			if tt.wantKey != "time" {
+				if _, ok := line["time"]; ok {
+					t.Errorf("log line %v still has default time field", line)
+				}
+			}
+		})
+	}
+}
+
+func TestConsoleWriter_customTimeField(t *testing.T) {
+	var buf bytes.Buffer
+	opts := &Options{Format: FormatText, TimeFieldName: "@timestamp"}
+	zl := withTimestamp(zerolog.New(consoleWriter(&buf, opts)), opts)
+	zl.Info().Msg("console")
+
+	out := buf.String()
+	if strings.Contains(out, "@timestamp") {
+		t.Errorf("console output = %q, want timestamp rendered in time column", out)
+	}
+	if strings.Contains(out, "<nil>") {
+		t.Errorf("console output = %q, want timestamp value", out)
+	}
+}
+
+func TestNewZerologWithWriter_reportCaller(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		fn   func(Logger)
+	}{
+		{"Info", func(l Logger) { l.Info("msg") }},
+		{"Errorf", func(l Logger) { l.Errorf("msg %d", 1) }},
+		{"WarnWithContext", func(l Logger) { l.WarnWithContext(ctx, "msg") }},
+		{"DebugfWithContext", func(l Logger) { l.DebugfWithContext(ctx, "msg %d", 1) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			tt.fn(NewZerologWithWriter(&buf, &Options{Level: LevelDebug, ReportCaller: true}))
+
+			line := decodeLine(t, strings.TrimSpace(buf.String()))
+			caller, _ := line["caller"].(string)
+			if !strings.Contains(caller, "zerolog__test.go:") {
+				t.Errorf("caller = %q, want location in zerolog__test.go", caller)
+			}
+		})
+	}
+}
+
+// logViaHelper is a user-side wrapper used to verify CallerSkipFrames.
+func logViaHelper(l Logger) {
+	l.Info("via helper")
+}
+
+func TestNewZerologWithWriter_callerSkipFrames(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewZerologWithWriter(&buf, &Options{ReportCaller: true, CallerSkipFrames: 1})
+
+	_, _, wantLine, _ := runtime.Caller(0)
+	logViaHelper(log)
+
+	line := decodeLine(t, strings.TrimSpace(buf.String()))
+	want := fmt.Sprintf("zerolog__test.go:%d", wantLine+1)
+	if caller, _ := line["caller"].(string); !strings.HasSuffix(caller, want) {
+		t.Errorf("caller = %q, want suffix %q", caller, want)
+	}
+}
+
+func TestNewZerologWithWriter_noCallerByDefault(t *testing.T) {
+	var buf bytes.Buffer
+	NewZerologWithWriter(&buf, nil).Info("msg")
+
+	line := decodeLine(t, strings.TrimSpace(buf.String()))
+	if _, ok := line["caller"]; ok {
+		t.Errorf("log line %v has caller field, want none", line)
+	}
+}
+
+// syncBuffer is a bytes.Buffer that records Sync calls.
+type syncBuffer struct {
+	bytes.Buffer
+	syncs int
+	err   error
+}
+
+func (b *syncBuffer) Sync() error {
+	b.syncs++
+	return b.err
+}
+
+func TestZerologLogger_Sync(t *testing.T) {
+	t.Run("stdout returns nil", func(t *testing.T) {
+		if err := NewZerolog(nil).Sync(); err != nil {
+			t.Errorf("Sync() error = %v, want nil", err)
+		}
+	})
+
+	t.Run("plain writer returns nil", func(t *testing.T) {
+		if err := NewZerologWithWriter(&bytes.Buffer{}, nil).Sync(); err != nil {
+			t.Errorf("Sync() error = %v, want nil", err)
+		}
+	})
+
+	t.Run("syncer writer is synced", func(t *testing.T) {
+		w := &syncBuffer{}
+		if err := NewZerologWithWriter(w, nil).Sync(); err != nil {
+			t.Errorf("Sync() error = %v, want nil", err)
+		}
+		if w.syncs != 1 {
+			t.Errorf("writer Sync() called %d times, want 1", w.syncs)
+		}
+	})
+
+	t.Run("syncer error is returned", func(t *testing.T) {
+		w := &syncBuffer{err: errors.New("disk full")}
+		if err := NewZerologWithWriter(w, nil).Sync(); !errors.Is(err, w.err) {
+			t.Errorf("Sync() error = %v, want %v", err, w.err)
+		}
+	})
+}
+
+func TestZerologLogger_Sync_file(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "app.log")
+	log := NewZerolog(&Options{Output: OutputFile, Rotation: &RotationConfig{Filename: filename}})
+	t.Cleanup(func() { _ = log.Sync() })
+
+	log.Info("before sync")
+	if err := log.Sync(); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	log.Info("after sync")
+	if err := log.Sync(); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("os.ReadFile() error = %v", err)
+	}
+	for _, msg := range []string{"before sync", "after sync"} {
+		if !strings.Contains(string(data), msg) {
+			t.Errorf("file content = %q, want %q", data, msg)
+		}
+	}
+}
+
+func TestZerologLogger_Enabled(t *testing.T) {
+	log := NewZerologWithWriter(&bytes.Buffer{}, &Options{Level: LevelWarn})
+
+	tests := []struct {
+		level Level
+		want  bool
+	}{
+		{LevelDebug, false},
+		{LevelInfo, false},
+		{LevelWarn, true},
+		{LevelError, true},
+		{LevelFatal, true},
+		{LevelPanic, true},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.level), func(t *testing.T) {
+			if got := log.Enabled(tt.level); got != tt.want {
+				t.Errorf("Enabled(%v) = %v, want %v", tt.level, got, tt.want)
+			}
+		})
+	}
+
+	log.SetLevel(LevelDebug)
+	if !log.Enabled(LevelDebug) {
+		t.Errorf("Enabled(LevelDebug) after SetLevel(LevelDebug) = false, want true")
+	}
+}
+
+func TestZerologLogger_ExitFunc(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		fn   func(Logger)
+	}{
+		{"Fatal", func(l Logger) { l.Fatal("fatal msg", F("k", "v")) }},
+		{"Fatalf", func(l Logger) { l.Fatalf("fatal %s", "msg") }},
+		{"FatalWithContext", func(l Logger) { l.FatalWithContext(ctx, "fatal msg") }},
+		{"FatalfWithContext", func(l Logger) { l.FatalfWithContext(ctx, "fatal %s", "msg") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			var codes []int
+			log := NewZerologWithWriter(&buf, &Options{ExitFunc: func(code int) { codes = append(codes, code) }})
+
+			tt.fn(log)
+
+			if !reflect.DeepEqual(codes, []int{1}) {
+				t.Errorf("ExitFunc calls = %v, want [1]", codes)
+			}
+			line := decodeLine(t, strings.TrimSpace(buf.String()))
+			if line["level"] != "fatal" || line["message"] != "fatal msg" {
+				t.Errorf("log line = %v, want fatal level with message", line)
+			}
+		})
+	}
+}
+
+func TestZerologLogger_ExitFunc_calledWhenLevelDisabled(t *testing.T) {
+	var buf bytes.Buffer
+	exited := false
+	log := NewZerologWithWriter(&buf, &Options{Level: LevelPanic, ExitFunc: func(int) { exited = true }})
+
+	log.Fatal("suppressed")
+
+	if !exited {
+		t.Errorf("ExitFunc not called, want call even when fatal level is disabled")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Fatal() at panic level wrote %q, want nothing", buf.String())
+	}
+}
@@ -0,0 +1,32 @@
+package repository
+
+// AggregateFunc is an SQL aggregate function usable in an AggregateSpec.
+type AggregateFunc string
+
+const (
+	AggregateCount AggregateFunc = "COUNT"
+	AggregateSum   AggregateFunc = "SUM"
+	AggregateAvg   AggregateFunc = "AVG"
+	AggregateMin   AggregateFunc = "MIN"
+	AggregateMax   AggregateFunc = "MAX"
+)
+
+// Aggregation is one aggregate column, e.g. {Func: AggregateSum, Column: "amount"}.
+// Column may be "*" for AggregateCount. Alias names the result key; it defaults to
+// "<func>_<column>" in lower case (e.g. "sum_amount"), or "count" for COUNT(*).
+type Aggregation struct {
+	Func   AggregateFunc
+	Column string
+	Alias  string
+}
+
+// AggregateSpec describes an aggregate query: the aggregations to compute, the columns to group by
+// (results are ordered by them) and the rows to include.
+type AggregateSpec struct {
+	Aggregations []Aggregation
+	GroupBy      []string
+	Filter       Filter
+}
+
+// AggregateRow is one result row keyed by group-by column and aggregation alias.
+type AggregateRow map[string]any
@@ -0,0 +1,20 @@
+package repository
+
+import "context"
+
+type includeDeletedKey struct{}
+
+// WithIncludeDeleted returns a context that makes repositories with soft delete enabled
+// return soft-deleted rows from read operations (GetByID, List, Count, Exists).
+func WithIncludeDeleted(ctx context.Context) context.Context {
+	return context.WithValue(ctx, includeDeletedKey{}, true)
+}
+
+// IncludeDeleted reports whether the context was created by WithIncludeDeleted.
+func IncludeDeleted(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	include, _ := ctx.Value(includeDeletedKey{}).(bool)
+	return include
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// CursorKey is one sort key of a keyset cursor: the column, its direction and the
+// value of the last row on the page.
+type CursorKey struct {
+	Field     string          `json:"field"`
+	Value     json.RawMessage `json:"value"`
+	Direction SortDirection   `json:"direction"`
+}
+
+// NewCursorKey builds a CursorKey, encoding value as JSON.
+func NewCursorKey(field string, value any, direction SortDirection) (CursorKey, error) {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return CursorKey{}, fmt.Errorf("repository: encode cursor: %w", err)
+	}
+	return CursorKey{Field: field, Value: raw, Direction: direction}, nil
+}
+
+// EncodeCursor returns the opaque cursor for keys: URL-safe base64 of their JSON,
+// suitable for PagedResult.NextCursor. Repositories should use it so cursors from
+// every implementation have the same format.
+func EncodeCursor(keys []CursorKey) (string, error) {
+	data, err := json.Marshal(keys)
+	if err != nil {
+		return "", fmt.Errorf("repository: encode cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeCursor decodes a cursor produced by EncodeCursor. A malformed cursor
+// returns an error wrapping ErrInvalidCursor; callers should still check the
+// keys against the sorts of the current query.
+func DecodeCursor(cursor string) ([]CursorKey, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	var keys []CursorKey
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	return keys, nil
+}
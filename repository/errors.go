@@ -20,6 +20,15 @@ var (
 	// ErrConflict is returned when update conflicts with existing data.
 	ErrConflict = errors.New("repository: update conflict")
 
+	// ErrInvalidCursor is returned when a pagination cursor is malformed or does not match the sort order.
+	ErrInvalidCursor = errors.New("repository: invalid cursor")
+
+	// ErrInvalidQuery is returned when a query specification (e.g. an AggregateSpec) is not allowed.
+	ErrInvalidQuery = errors.New("repository: invalid query")
+
+	// ErrTransactionRequired is returned when an operation must run inside a transaction (e.g. GetByIDForUpdate).
+	ErrTransactionRequired = errors.New("repository: transaction required")
+
 	// ErrConnection is returned when database connection fails.
 	ErrConnection = errors.New("repository: connection error")
 )
@@ -0,0 +1,211 @@
+package mock
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/biairmal/go-sdk/repository"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// columnMapping maps lower-cased db tag names to struct field indexes (options after a comma are ignored).
+func columnMapping(typ reflect.Type) map[string]int {
+	m := make(map[string]int)
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("db"), ",")
+		name = strings.TrimSpace(name)
+		if name == "" || name == "-" {
+			continue
+		}
+		m[strings.ToLower(name)] = i
+	}
+	return m
+}
+
+// field returns the value of column in entity, or an error wrapping repository.ErrInvalidQuery
+// when column is not mapped by a db tag.
+func (m *Repository[TEntity, TID]) field(entity *TEntity, column string) (reflect.Value, error) {
+	idx, ok := m.columns[strings.ToLower(strings.TrimSpace(column))]
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("%w: mock: unknown column %q", repository.ErrInvalidQuery, column)
+	}
+	return reflect.ValueOf(entity).Elem().Field(idx), nil
+}
+
+// filter returns the stored entities matching filter, in insertion order.
+// Conditions are combined with AND; FilterGroups are not supported.
+func (m *Repository[TEntity, TID]) filter(filter repository.Filter) ([]*TEntity, error) {
+	if len(filter.Groups) > 0 {
+		return nil, fmt.Errorf("%w: mock: filter groups are not supported", repository.ErrInvalidQuery)
+	}
+	out := make([]*TEntity, 0, len(m.order))
+	for _, id := range m.order {
+		entity := m.items[id]
+		ok, err := m.matches(entity, filter.Conditions)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			out = append(out, entity)
+		}
+	}
+	return out, nil
+}
+
+// matches reports whether entity satisfies every condition.
+// Supported operators: eq, ne, in, not_in, is_null and is_not_null.
+func (m *Repository[TEntity, TID]) matches(entity *TEntity, conds []repository.FilterCondition) (bool, error) {
+	for _, c := range conds {
+		v, err := m.field(entity, c.Field)
+		if err != nil {
+			return false, err
+		}
+		var ok bool
+		switch c.Operator {
+		case repository.FilterOperatorEq:
+			ok = equalValue(v, c.Value)
+		case repository.FilterOperatorNe:
+			ok = !equalValue(v, c.Value)
+		case repository.FilterOperatorIn:
+			ok = slices.ContainsFunc(c.Values, func(want any) bool { return equalValue(v, want) })
+		case repository.FilterOperatorNotIn:
+			ok = !slices.ContainsFunc(c.Values, func(want any) bool { return equalValue(v, want) })
+		case repository.FilterOperatorIsNull:
+			ok = isNull(v)
+		case repository.FilterOperatorIsNotNull:
+			ok = !isNull(v)
+		default:
+			return false, fmt.Errorf("%w: mock: unsupported filter operator %q", repository.ErrInvalidQuery, c.Operator)
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// sort orders entities in place by sorts (stable, so ties keep insertion order).
+func (m *Repository[TEntity, TID]) sort(entities []*TEntity, sorts []repository.Sort) error {
+	for _, s := range sorts {
+		if _, ok := m.columns[strings.ToLower(strings.TrimSpace(s.Field))]; !ok {
+			return fmt.Errorf("%w: mock: unknown sort column %q", repository.ErrInvalidQuery, s.Field)
+		}
+	}
+	slices.SortStableFunc(entities, func(a, b *TEntity) int {
+		for _, s := range sorts {
+			va, _ := m.field(a, s.Field)
+			vb, _ := m.field(b, s.Field)
+			c := compareValues(va, vb)
+			if strings.EqualFold(string(s.Direction), string(repository.SortDesc)) {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+	return nil
+}
+
+// isNull reports whether v is a nil pointer, interface, slice or map.
+func isNull(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
+
+// equalValue reports whether the field value v equals want. Pointers are dereferenced
+// (a nil pointer equals only nil) and numbers of different types compare by value.
+func equalValue(v reflect.Value, want any) bool {
+	v = indirect(v)
+	if !v.IsValid() || want == nil {
+		return !v.IsValid() && want == nil
+	}
+	w := indirect(reflect.ValueOf(want))
+	if !w.IsValid() {
+		return false
+	}
+	if v.Type() == w.Type() {
+		if v.Type() == timeType {
+			return v.Interface().(time.Time).Equal(w.Interface().(time.Time))
+		}
+		return reflect.DeepEqual(v.Interface(), w.Interface())
+	}
+	if isNumber(v) && isNumber(w) {
+		return compareValues(v, w) == 0
+	}
+	return false
+}
+
+// compareValues orders two field values: numbers, strings, bools and time.Time by value;
+// nil sorts before everything else. Values of other types compare equal.
+func compareValues(a, b reflect.Value) int {
+	a, b = indirect(a), indirect(b)
+	switch {
+	case !a.IsValid() || !b.IsValid():
+		return cmp.Compare(boolInt(a.IsValid()), boolInt(b.IsValid()))
+	case isNumber(a) && isNumber(b):
+		return cmp.Compare(toFloat(a), toFloat(b))
+	case a.Kind() == reflect.String && b.Kind() == reflect.String:
+		return cmp.Compare(a.String(), b.String())
+	case a.Kind() == reflect.Bool && b.Kind() == reflect.Bool:
+		return cmp.Compare(boolInt(a.Bool()), boolInt(b.Bool()))
+	case a.Type() == timeType && b.Type() == timeType:
+		return a.Interface().(time.Time).Compare(b.Interface().(time.Time))
+	default:
+		return 0
+	}
+}
+
+// indirect dereferences pointers and interfaces; it returns the invalid Value for nil.
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func isNumber(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+func toFloat(v reflect.Value) float64 {
+	switch {
+	case v.CanInt():
+		return float64(v.Int())
+	case v.CanUint():
+		return float64(v.Uint())
+	default:
+		return v.Float()
+	}
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
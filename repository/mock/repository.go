@@ -0,0 +1,381 @@
+// Package mock provides an in-memory implementation of repository.Repository for tests.
+package mock
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/biairmal/go-sdk/repository"
+)
+
+var uuidType = reflect.TypeOf(uuid.UUID{})
+
+// Method names as recorded in Call.Method.
+const (
+	MethodCreate  = "Create"
+	MethodGetByID = "GetByID"
+	MethodUpdate  = "Update"
+	MethodDelete  = "Delete"
+	MethodList    = "List"
+	MethodCount   = "Count"
+	MethodExists  = "Exists"
+)
+
+// Call is one recorded method call. Args holds the arguments after the context, as passed
+// (entity pointers are not copied, so an entity written back by Create shows its generated ID).
+type Call struct {
+	Method string
+	Args   []any
+}
+
+// Option configures Repository.
+type Option[TEntity any, TID comparable] func(*Repository[TEntity, TID])
+
+// Repository is an in-memory repository.Repository keyed by the entity's ID field (db:"id" by default).
+// Entities are copied on write and on read, so callers cannot mutate stored state.
+// Errors set with the Set*Error methods are returned by every call of that method until cleared or Reset.
+// Every call is recorded (see Calls), so the mock doubles as a spy. Results queued with the Push*Result
+// methods are returned first-in first-out ahead of injected errors and stored data; once a method's queue
+// is empty it falls back to them. Safe for concurrent use.
+type Repository[TEntity any, TID comparable] struct {
+	mu        sync.Mutex
+	items     map[TID]*TEntity
+	order     []TID // insertion order, used when List has no sorts
+	idColumn  string
+	idIndex   int
+	lastID    int64 // highest integer ID seen, for generating the next one
+	columns   map[string]int
+	errs      map[string]error
+	calls     []Call
+	responses map[string][]response[TEntity] // Push*Result queues per method
+}
+
+// NewRepository creates an empty mock repository.
+// TEntity must be a struct whose ID column (see WithIDColumn) is a db-tagged field of type TID.
+func NewRepository[TEntity any, TID comparable](opts ...Option[TEntity, TID]) *Repository[TEntity, TID] {
+	var zero TEntity
+	typ := reflect.TypeOf(&zero).Elem()
+	if typ.Kind() != reflect.Struct {
+		panic("mock: TEntity must be a struct type")
+	}
+	m := &Repository[TEntity, TID]{
+		items:    make(map[TID]*TEntity),
+		idColumn: "id",
+		columns:  columnMapping(typ),
+		errs:     make(map[string]error),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	idIndex, ok := m.columns[strings.ToLower(m.idColumn)]
+	if !ok || typ.Field(idIndex).Type != reflect.TypeOf((*TID)(nil)).Elem() {
+		panic("mock: TEntity must have a db:\"" + m.idColumn + "\" field of type TID")
+	}
+	m.idIndex = idIndex
+	return m
+}
+
+// WithIDColumn sets the ID column name (default "id"), matched against the entity's db tags.
+func WithIDColumn[TEntity any, TID comparable](column string) Option[TEntity, TID] {
+	return func(m *Repository[TEntity, TID]) {
+		if column = strings.TrimSpace(column); column != "" {
+			m.idColumn = column
+		}
+	}
+}
+
+// SetCreateError makes Create return err (nil clears it).
+func (m *Repository[TEntity, TID]) SetCreateError(err error) { m.setError(MethodCreate, err) }
+
+// SetGetError makes GetByID return err (nil clears it).
+func (m *Repository[TEntity, TID]) SetGetError(err error) { m.setError(MethodGetByID, err) }
+
+// SetUpdateError makes Update return err (nil clears it).
+func (m *Repository[TEntity, TID]) SetUpdateError(err error) { m.setError(MethodUpdate, err) }
+
+// SetDeleteError makes Delete return err (nil clears it).
+func (m *Repository[TEntity, TID]) SetDeleteError(err error) { m.setError(MethodDelete, err) }
+
+// SetListError makes List return err (nil clears it).
+func (m *Repository[TEntity, TID]) SetListError(err error) { m.setError(MethodList, err) }
+
+// SetCountError makes Count return err (nil clears it).
+func (m *Repository[TEntity, TID]) SetCountError(err error) { m.setError(MethodCount, err) }
+
+// SetExistsError makes Exists return err (nil clears it).
+func (m *Repository[TEntity, TID]) SetExistsError(err error) { m.setError(MethodExists, err) }
+
+func (m *Repository[TEntity, TID]) setError(method string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.errs[method] = err
+}
+
+// Calls returns a copy of the recorded calls, oldest first.
+func (m *Repository[TEntity, TID]) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Call(nil), m.calls...)
+}
+
+// CallCount returns how many times method (e.g. MethodCreate) was called.
+func (m *Repository[TEntity, TID]) CallCount(method string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n := 0
+	for _, c := range m.calls {
+		if c.Method == method {
+			n++
+		}
+	}
+	return n
+}
+
+// CreateCallCount returns how many times Create was called.
+func (m *Repository[TEntity, TID]) CreateCallCount() int { return m.CallCount(MethodCreate) }
+
+// GetByIDCallCount returns how many times GetByID was called.
+func (m *Repository[TEntity, TID]) GetByIDCallCount() int { return m.CallCount(MethodGetByID) }
+
+// UpdateCallCount returns how many times Update was called.
+func (m *Repository[TEntity, TID]) UpdateCallCount() int { return m.CallCount(MethodUpdate) }
+
+// DeleteCallCount returns how many times Delete was called.
+func (m *Repository[TEntity, TID]) DeleteCallCount() int { return m.CallCount(MethodDelete) }
+
+// ListCallCount returns how many times List was called.
+func (m *Repository[TEntity, TID]) ListCallCount() int { return m.CallCount(MethodList) }
+
+// CountCallCount returns how many times Count was called.
+func (m *Repository[TEntity, TID]) CountCallCount() int { return m.CallCount(MethodCount) }
+
+// ExistsCallCount returns how many times Exists was called.
+func (m *Repository[TEntity, TID]) ExistsCallCount() int { return m.CallCount(MethodExists) }
+
+// record appends a call to the history; m.mu must be held.
+func (m *Repository[TEntity, TID]) record(method string, args ...any) {
+	m.calls = append(m.calls, Call{Method: method, Args: args})
+}
+
+// Reset removes all entities, injected errors, recorded calls and queued results.
+func (m *Repository[TEntity, TID]) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.items = make(map[TID]*TEntity)
+	m.order = nil
+	m.lastID = 0
+	m.calls = nil
+	m.responses = nil
+	m.errs = make(map[string]error)
+}
+
+// Create stores a copy of entity under its ID. A zero ID is generated first and written back to entity:
+// the next integer after the highest one seen for integer IDs, uuid.New() for uuid.UUID IDs.
+// Returns repository.ErrAlreadyExists when an entity with the same ID is stored, and an error wrapping
+// repository.ErrInvalidID when the ID is zero and cannot be generated for its type.
+func (m *Repository[TEntity, TID]) Create(_ context.Context, entity *TEntity) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.record(MethodCreate, entity)
+	if r, ok := m.next(MethodCreate); ok {
+		return r.err
+	}
+	if err := m.errs[MethodCreate]; err != nil {
+		return err
+	}
+	if entity == nil {
+		return repository.ErrInvalidEntity
+	}
+	id, err := m.assignID(entity)
+	if err != nil {
+		return err
+	}
+	if _, ok := m.items[id]; ok {
+		return repository.ErrAlreadyExists
+	}
+	m.items[id] = clone(entity)
+	m.order = append(m.order, id)
+	return nil
+}
+
+// GetByID returns a copy of the entity with the given ID, or repository.ErrNotFound.
+func (m *Repository[TEntity, TID]) GetByID(_ context.Context, id TID) (*TEntity, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.record(MethodGetByID, id)
+	if r, ok := m.next(MethodGetByID); ok {
+		return r.entity, r.err
+	}
+	if err := m.errs[MethodGetByID]; err != nil {
+		return nil, err
+	}
+	entity, ok := m.items[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return clone(entity), nil
+}
+
+// Update replaces the entity stored under id with a copy of entity, keeping id as its ID.
+// Returns repository.ErrNotFound when no entity has that ID.
+func (m *Repository[TEntity, TID]) Update(_ context.Context, id TID, entity *TEntity) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.record(MethodUpdate, id, entity)
+	if r, ok := m.next(MethodUpdate); ok {
+		return r.err
+	}
+	if err := m.errs[MethodUpdate]; err != nil {
+		return err
+	}
+	if entity == nil {
+		return repository.ErrInvalidEntity
+	}
+	if _, ok := m.items[id]; !ok {
+		return repository.ErrNotFound
+	}
+	stored := clone(entity)
+	reflect.ValueOf(stored).Elem().Field(m.idIndex).Set(reflect.ValueOf(id))
+	m.items[id] = stored
+	return nil
+}
+
+// Delete removes the entity with the given ID, or returns repository.ErrNotFound.
+func (m *Repository[TEntity, TID]) Delete(_ context.Context, id TID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.record(MethodDelete, id)
+	if r, ok := m.next(MethodDelete); ok {
+		return r.err
+	}
+	if err := m.errs[MethodDelete]; err != nil {
+		return err
+	}
+	if _, ok := m.items[id]; !ok {
+		return repository.ErrNotFound
+	}
+	delete(m.items, id)
+	for i, v := range m.order {
+		if v == id {
+			m.order = append(m.order[:i], m.order[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// List returns copies of the entities matching opts.Filter, ordered by opts.Sorts (insertion order when
+// there are none) and paginated by opts.Pagination (Limit 0 means no limit), plus the total before pagination.
+// Filter conditions support eq, ne, in, not_in, is_null and is_not_null; other operators, filter groups and
+// unknown columns return an error wrapping repository.ErrInvalidQuery.
+func (m *Repository[TEntity, TID]) List(_ context.Context, opts *repository.ListOptions) ([]*TEntity, int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.record(MethodList, opts)
+	if r, ok := m.next(MethodList); ok {
+		return r.entities, r.n, r.err
+	}
+	if err := m.errs[MethodList]; err != nil {
+		return nil, 0, err
+	}
+	if opts == nil {
+		opts = &repository.ListOptions{}
+	}
+	matched, err := m.filter(opts.Filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := m.sort(matched, opts.Sorts); err != nil {
+		return nil, 0, err
+	}
+	total := int64(len(matched))
+	matched = paginate(matched, opts.Pagination)
+	out := make([]*TEntity, len(matched))
+	for i, e := range matched {
+		out[i] = clone(e)
+	}
+	if opts.SkipCount {
+		total = 0
+	}
+	return out, total, nil
+}
+
+// Count returns the number of entities matching filter.
+func (m *Repository[TEntity, TID]) Count(_ context.Context, filter repository.Filter) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.record(MethodCount, filter)
+	if r, ok := m.next(MethodCount); ok {
+		return r.n, r.err
+	}
+	if err := m.errs[MethodCount]; err != nil {
+		return 0, err
+	}
+	matched, err := m.filter(filter)
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(matched)), nil
+}
+
+// Exists reports whether an entity with the given ID is stored.
+func (m *Repository[TEntity, TID]) Exists(_ context.Context, id TID) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.record(MethodExists, id)
+	if r, ok := m.next(MethodExists); ok {
+		return r.exists, r.err
+	}
+	if err := m.errs[MethodExists]; err != nil {
+		return false, err
+	}
+	_, ok := m.items[id]
+	return ok, nil
+}
+
+// assignID returns the ID of entity, generating and writing one back when it is zero.
+func (m *Repository[TEntity, TID]) assignID(entity *TEntity) (TID, error) {
+	field := reflect.ValueOf(entity).Elem().Field(m.idIndex)
+	if field.IsZero() {
+		switch {
+		case field.CanInt():
+			field.SetInt(m.lastID + 1)
+		case field.CanUint():
+			field.SetUint(uint64(m.lastID + 1))
+		case field.Type() == uuidType:
+			field.Set(reflect.ValueOf(uuid.New()))
+		default:
+			var zero TID
+			return zero, fmt.Errorf("%w: mock: cannot generate an ID of type %s", repository.ErrInvalidID, field.Type())
+		}
+	}
+	switch {
+	case field.CanInt():
+		m.lastID = max(m.lastID, field.Int())
+	case field.CanUint():
+		m.lastID = max(m.lastID, int64(field.Uint()))
+	}
+	return field.Interface().(TID), nil
+}
+
+// clone returns a shallow copy of entity.
+func clone[TEntity any](entity *TEntity) *TEntity {
+	c := *entity
+	return &c
+}
+
+// paginate applies offset and limit to items; a non-positive limit returns everything after the offset.
+func paginate[T any](items []T, p repository.Pagination) []T {
+	if p.Offset > 0 {
+		items = items[min(p.Offset, len(items)):]
+	}
+	if p.Limit > 0 && p.Limit < len(items) {
+		items = items[:p.Limit]
+	}
+	return items
+}
@@ -0,0 +1,282 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/biairmal/go-sdk/repository"
+)
+
+// The mock must be a drop-in replacement wherever a repository.Repository (or its read/write halves) is accepted.
+var (
+	_ repository.Repository[testUser, int64]      = (*Repository[testUser, int64])(nil)
+	_ repository.ReadRepository[testUser, int64]  = (*Repository[testUser, int64])(nil)
+	_ repository.WriteRepository[testUser, int64] = (*Repository[testUser, int64])(nil)
+)
+
+type testUser struct {
+	ID    int64   `db:"id"`
+	Name  string  `db:"name"`
+	Age   int     `db:"age"`
+	Email *string `db:"email"`
+}
+
+func seededRepo(t *testing.T) *Repository[testUser, int64] {
+	t.Helper()
+	email := "bob@example.com"
+	repo := NewRepository[testUser, int64]()
+	for _, u := range []testUser{
+		{ID: 1, Name: "carol", Age: 30},
+		{ID: 2, Name: "bob", Age: 25, Email: &email},
+		{ID: 3, Name: "alice", Age: 30},
+		{ID: 4, Name: "dave", Age: 41},
+	} {
+		if err := repo.Create(context.Background(), &u); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+	return repo
+}
+
+func ids(users []*testUser) []int64 {
+	out := make([]int64, len(users))
+	for i, u := range users {
+		out[i] = u.ID
+	}
+	return out
+}
+
+func TestRepository_ListFilterAndSort(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      *repository.ListOptions
+		wantIDs   []int64
+		wantTotal int64
+	}{
+		{name: "nil options", wantIDs: []int64{1, 2, 3, 4}, wantTotal: 4},
+		{
+			name: "equality",
+			opts: &repository.ListOptions{Filter: repository.Filter{Conditions: []repository.FilterCondition{
+				{Field: "age", Operator: repository.FilterOperatorEq, Value: 30},
+			}}},
+			wantIDs:   []int64{1, 3},
+			wantTotal: 2,
+		},
+		{
+			name: "conditions are ANDed",
+			opts: &repository.ListOptions{Filter: repository.Filter{Conditions: []repository.FilterCondition{
+				{Field: "AGE", Operator: repository.FilterOperatorEq, Value: int64(30)},
+				{Field: "name", Operator: repository.FilterOperatorNe, Value: "carol"},
+			}}},
+			wantIDs:   []int64{3},
+			wantTotal: 1,
+		},
+		{
+			name: "in and is_not_null",
+			opts: &repository.ListOptions{Filter: repository.Filter{Conditions: []repository.FilterCondition{
+				{Field: "name", Operator: repository.FilterOperatorIn, Values: []any{"bob", "dave"}},
+				{Field: "email", Operator: repository.FilterOperatorIsNotNull},
+			}}},
+			wantIDs:   []int64{2},
+			wantTotal: 1,
+		},
+		{
+			name:      "single sort",
+			opts:      &repository.ListOptions{Sorts: []repository.Sort{{Field: "name", Direction: repository.SortAsc}}},
+			wantIDs:   []int64{3, 2, 1, 4},
+			wantTotal: 4,
+		},
+		{
+			name: "multi sort",
+			opts: &repository.ListOptions{Sorts: []repository.Sort{
+				{Field: "age", Direction: repository.SortDesc},
+				{Field: "name", Direction: repository.SortAsc},
+			}},
+			wantIDs:   []int64{4, 3, 1, 2},
+			wantTotal: 4,
+		},
+		{
+			name: "paginated after sort",
+			opts: &repository.ListOptions{
+				Sorts:      []repository.Sort{{Field: "id", Direction: repository.SortDesc}},
+				Pagination: repository.Pagination{Limit: 2, Offset: 1},
+			},
+			wantIDs:   []int64{3, 2},
+			wantTotal: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, total, err := seededRepo(t).List(context.Background(), tt.opts)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			if !reflect.DeepEqual(ids(got), tt.wantIDs) || total != tt.wantTotal {
+				t.Errorf("List() = %v, %d, want %v, %d", ids(got), total, tt.wantIDs, tt.wantTotal)
+			}
+		})
+	}
+}
+
+func TestRepository_ListInvalidQuery(t *testing.T) {
+	tests := []struct {
+		name string
+		opts *repository.ListOptions
+	}{
+		{name: "unknown filter column", opts: &repository.ListOptions{Filter: repository.Filter{
+			Conditions: []repository.FilterCondition{{Field: "nickname", Operator: repository.FilterOperatorEq, Value: "x"}},
+		}}},
+		{name: "unsupported operator", opts: &repository.ListOptions{Filter: repository.Filter{
+			Conditions: []repository.FilterCondition{{Field: "age", Operator: repository.FilterOperatorGt, Value: 1}},
+		}}},
+		{name: "unknown sort column", opts: &repository.ListOptions{Sorts: []repository.Sort{{Field: "nope"}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := seededRepo(t).List(context.Background(), tt.opts)
+			if !errors.Is(err, repository.ErrInvalidQuery) {
+				t.Errorf("List() error = %v, want ErrInvalidQuery", err)
+			}
+		})
+	}
+}
+
+func TestRepository_CountAndInjectedError(t *testing.T) {
+	repo := seededRepo(t)
+	n, err := repo.Count(context.Background(), repository.Filter{Conditions: []repository.FilterCondition{
+		{Field: "email", Operator: repository.FilterOperatorIsNull},
+	}})
+	if err != nil || n != 3 {
+		t.Errorf("Count() = %d, %v, want 3, nil", n, err)
+	}
+
+	boom := errors.New("boom")
+	repo.SetListError(boom)
+	if _, _, err := repo.List(context.Background(), nil); !errors.Is(err, boom) {
+		t.Errorf("List() error = %v, want %v", err, boom)
+	}
+	repo.Reset()
+	if got, total, err := repo.List(context.Background(), nil); err != nil || len(got) != 0 || total != 0 {
+		t.Errorf("List() after Reset = %v, %d, %v, want empty", got, total, err)
+	}
+}
+
+func TestRepository_CreateAssignsID(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRepository[testUser, int64]()
+
+	explicit := &testUser{ID: 10, Name: "ann"}
+	generated := &testUser{Name: "bob"}
+	for _, u := range []*testUser{explicit, generated} {
+		if err := repo.Create(ctx, u); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+	if generated.ID != 11 {
+		t.Errorf("generated ID = %d, want 11", generated.ID)
+	}
+	got, err := repo.GetByID(ctx, generated.ID)
+	if err != nil || got.Name != "bob" {
+		t.Errorf("GetByID(%d) = %+v, %v, want bob", generated.ID, got, err)
+	}
+	if err := repo.Create(ctx, &testUser{ID: 10}); !errors.Is(err, repository.ErrAlreadyExists) {
+		t.Errorf("Create() duplicate error = %v, want ErrAlreadyExists", err)
+	}
+}
+
+func TestRepository_CreateAssignsUUID(t *testing.T) {
+	type document struct {
+		Key   uuid.UUID `db:"doc_key"`
+		Title string    `db:"title"`
+	}
+	ctx := context.Background()
+	repo := NewRepository[document, uuid.UUID](WithIDColumn[document, uuid.UUID]("doc_key"))
+
+	doc := &document{Title: "spec"}
+	if err := repo.Create(ctx, doc); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if doc.Key == uuid.Nil {
+		t.Fatal("Create() did not write back a generated UUID")
+	}
+	if got, err := repo.GetByID(ctx, doc.Key); err != nil || got.Title != "spec" {
+		t.Errorf("GetByID() = %+v, %v, want spec", got, err)
+	}
+}
+
+func TestRepository_CreateUngeneratableID(t *testing.T) {
+	type tag struct {
+		Name string `db:"id"`
+	}
+	repo := NewRepository[tag, string]()
+	if err := repo.Create(context.Background(), &tag{}); !errors.Is(err, repository.ErrInvalidID) {
+		t.Errorf("Create() error = %v, want ErrInvalidID", err)
+	}
+}
+
+func TestRepository_AsRepositoryInterface(t *testing.T) {
+	ctx := context.Background()
+	var repo repository.Repository[testUser, int64] = seededRepo(t)
+
+	if err := repo.Update(ctx, 2, &testUser{Name: "robert", Age: 26}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	got, total, err := repo.List(ctx, &repository.ListOptions{
+		Filter: repository.Filter{Conditions: []repository.FilterCondition{
+			{Field: "name", Operator: repository.FilterOperatorEq, Value: "robert"},
+		}},
+		Pagination: repository.Pagination{Limit: 1},
+	})
+	if err != nil || total != 1 || len(got) != 1 || got[0].ID != 2 {
+		t.Fatalf("List() = %v, %d, %v, want user 2 with total 1", ids(got), total, err)
+	}
+	if err := repo.Delete(ctx, 2); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if ok, err := repo.Exists(ctx, 2); ok || err != nil {
+		t.Errorf("Exists() after Delete = %v, %v, want false, nil", ok, err)
+	}
+	if err := repo.Delete(ctx, 2); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("Delete() twice error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestRepository_RecordsCalls(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRepository[testUser, int64]()
+	repo.SetGetError(errors.New("boom"))
+
+	user := &testUser{Name: "ann"}
+	_ = repo.Create(ctx, user)
+	_, _ = repo.GetByID(ctx, 1)
+	_, _, _ = repo.List(ctx, nil)
+
+	want := []Call{
+		{Method: MethodCreate, Args: []any{user}},
+		{Method: MethodGetByID, Args: []any{int64(1)}},
+		{Method: MethodList, Args: []any{(*repository.ListOptions)(nil)}},
+	}
+	if got := repo.Calls(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Calls() = %+v, want %+v", got, want)
+	}
+	if got := repo.CreateCallCount(); got != 1 {
+		t.Errorf("CreateCallCount() = %d, want 1", got)
+	}
+	if got := repo.GetByIDCallCount(); got != 1 {
+		t.Errorf("GetByIDCallCount() = %d, want 1 (failed calls are recorded)", got)
+	}
+	if created := repo.Calls()[0].Args[0].(*testUser); created.ID != 1 || created.Name != "ann" {
+		t.Errorf("recorded Create entity = %+v, want ann with ID 1", created)
+	}
+
+	repo.Reset()
+	if got := repo.Calls(); len(got) != 0 {
+		t.Errorf("Calls() after Reset = %+v, want none", got)
+	}
+}
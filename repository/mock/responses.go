@@ -0,0 +1,64 @@
+package mock
+
+// response is one queued result; only the fields used by its method are set.
+type response[TEntity any] struct {
+	entity   *TEntity   // GetByID
+	entities []*TEntity // List
+	n        int64      // List total, Count
+	exists   bool       // Exists
+	err      error
+}
+
+// PushCreateResult queues the result of a future Create call; the entity is not stored.
+func (m *Repository[TEntity, TID]) PushCreateResult(err error) {
+	m.push(MethodCreate, response[TEntity]{err: err})
+}
+
+// PushGetResult queues the result of a future GetByID call.
+func (m *Repository[TEntity, TID]) PushGetResult(entity *TEntity, err error) {
+	m.push(MethodGetByID, response[TEntity]{entity: entity, err: err})
+}
+
+// PushUpdateResult queues the result of a future Update call; the stored entity is left unchanged.
+func (m *Repository[TEntity, TID]) PushUpdateResult(err error) {
+	m.push(MethodUpdate, response[TEntity]{err: err})
+}
+
+// PushDeleteResult queues the result of a future Delete call; no entity is removed.
+func (m *Repository[TEntity, TID]) PushDeleteResult(err error) {
+	m.push(MethodDelete, response[TEntity]{err: err})
+}
+
+// PushListResult queues the result of a future List call.
+func (m *Repository[TEntity, TID]) PushListResult(entities []*TEntity, total int64, err error) {
+	m.push(MethodList, response[TEntity]{entities: entities, n: total, err: err})
+}
+
+// PushCountResult queues the result of a future Count call.
+func (m *Repository[TEntity, TID]) PushCountResult(count int64, err error) {
+	m.push(MethodCount, response[TEntity]{n: count, err: err})
+}
+
+// PushExistsResult queues the result of a future Exists call.
+func (m *Repository[TEntity, TID]) PushExistsResult(exists bool, err error) {
+	m.push(MethodExists, response[TEntity]{exists: exists, err: err})
+}
+
+func (m *Repository[TEntity, TID]) push(method string, r response[TEntity]) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.responses == nil {
+		m.responses = make(map[string][]response[TEntity])
+	}
+	m.responses[method] = append(m.responses[method], r)
+}
+
+// next pops the oldest queued response for method; m.mu must be held.
+func (m *Repository[TEntity, TID]) next(method string) (response[TEntity], bool) {
+	queue := m.responses[method]
+	if len(queue) == 0 {
+		return response[TEntity]{}, false
+	}
+	m.responses[method] = queue[1:]
+	return queue[0], true
+}
@@ -0,0 +1,70 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/biairmal/go-sdk/repository"
+)
+
+func TestRepository_QueuedResults(t *testing.T) {
+	ctx := context.Background()
+	repo := seededRepo(t)
+	boom := errors.New("boom")
+	repo.PushGetResult(nil, boom)
+	repo.PushGetResult(&testUser{ID: 99, Name: "stub"}, nil)
+
+	if _, err := repo.GetByID(ctx, 1); !errors.Is(err, boom) {
+		t.Errorf("first GetByID() error = %v, want %v", err, boom)
+	}
+	if got, err := repo.GetByID(ctx, 1); err != nil || got.Name != "stub" {
+		t.Errorf("second GetByID() = %+v, %v, want stub", got, err)
+	}
+	if got, err := repo.GetByID(ctx, 1); err != nil || got.Name != "carol" {
+		t.Errorf("third GetByID() = %+v, %v, want stored carol", got, err)
+	}
+	if got := repo.GetByIDCallCount(); got != 3 {
+		t.Errorf("GetByIDCallCount() = %d, want 3", got)
+	}
+}
+
+func TestRepository_QueuedResultsPrecedeInjectedErrors(t *testing.T) {
+	ctx := context.Background()
+	repo := seededRepo(t)
+	sticky := errors.New("sticky")
+	repo.SetCountError(sticky)
+	repo.PushCountResult(42, nil)
+	repo.PushListResult([]*testUser{{ID: 7}}, 10, nil)
+	repo.PushExistsResult(false, nil)
+	repo.PushCreateResult(repository.ErrAlreadyExists)
+	repo.PushDeleteResult(nil)
+
+	if n, err := repo.Count(ctx, repository.Filter{}); n != 42 || err != nil {
+		t.Errorf("Count() = %d, %v, want 42, nil", n, err)
+	}
+	if _, err := repo.Count(ctx, repository.Filter{}); !errors.Is(err, sticky) {
+		t.Errorf("Count() after queue error = %v, want %v", err, sticky)
+	}
+	if got, total, err := repo.List(ctx, nil); err != nil || total != 10 || len(got) != 1 || got[0].ID != 7 {
+		t.Errorf("List() = %v, %d, %v, want [7], 10", ids(got), total, err)
+	}
+	if ok, err := repo.Exists(ctx, 1); ok || err != nil {
+		t.Errorf("Exists() = %v, %v, want queued false", ok, err)
+	}
+	if err := repo.Create(ctx, &testUser{ID: 50}); !errors.Is(err, repository.ErrAlreadyExists) {
+		t.Errorf("Create() error = %v, want queued ErrAlreadyExists", err)
+	}
+	if err := repo.Delete(ctx, 1); err != nil {
+		t.Errorf("Delete() error = %v, want queued nil", err)
+	}
+	if ok, _ := repo.Exists(ctx, 1); !ok {
+		t.Error("queued Delete() removed the stored entity")
+	}
+
+	repo.PushUpdateResult(sticky)
+	repo.Reset()
+	if err := repo.Update(ctx, 1, &testUser{}); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("Update() after Reset error = %v, want ErrNotFound", err)
+	}
+}
@@ -6,16 +6,18 @@ type ListOptions struct {
 	Filter     Filter     // Filtering criteria
 	Sorts      []Sort     // Sort by multiple columns (order preserved)
 	SkipCount  bool       // Skip count query
+	// IncludeDeleted returns soft-deleted rows too (see also WithIncludeDeleted).
+	IncludeDeleted bool
 }
 
 // FilterCondition specifies one filter: field, operator, and value(s).
-// Use Value for single-value operators (eq, ne, gt, gte, lt, lte, like).
-// Use Values for the "in" operator.
+// Use Value for single-value operators (eq, ne, gt, gte, lt, lte, like, ilike).
+// Use Values for the "in" and "not_in" operators, and exactly two Values (low, high) for "between".
 type FilterCondition struct {
 	Field    string         // Column name
 	Operator FilterOperator // Operator
 	Value    any            // Value for single-value operators
-	Values   []any          // Values for the "in" operator
+	Values   []any          // Values for the "in", "not_in" and "between" operators
 }
 
 // FilterOperator represents filter operator.
@@ -29,15 +31,48 @@ const (
 	FilterOperatorLt        FilterOperator = "lt"
 	FilterOperatorLte       FilterOperator = "lte"
 	FilterOperatorLike      FilterOperator = "like"
+	FilterOperatorILike     FilterOperator = "ilike"
 	FilterOperatorIn        FilterOperator = "in"
+	FilterOperatorNotIn     FilterOperator = "not_in"
+	FilterOperatorBetween   FilterOperator = "between"
 	FilterOperatorIsNull    FilterOperator = "is_null"
 	FilterOperatorIsNotNull FilterOperator = "is_not_null"
 )
 
+// Valid reports whether op is one of the supported operators above.
+func (op FilterOperator) Valid() bool {
+	switch op {
+	case FilterOperatorEq, FilterOperatorNe, FilterOperatorGt, FilterOperatorGte,
+		FilterOperatorLt, FilterOperatorLte, FilterOperatorLike, FilterOperatorILike,
+		FilterOperatorIn, FilterOperatorNotIn, FilterOperatorBetween,
+		FilterOperatorIsNull, FilterOperatorIsNotNull:
+		return true
+	}
+	return false
+}
+
 // Filter provides generic filtering options.
 // Conditions is a list of predicate conditions (combined with AND).
+// Groups adds nested AND/OR groups, also combined with AND alongside Conditions.
 type Filter struct {
 	Conditions []FilterCondition
+	Groups     []FilterGroup
+}
+
+// FilterCombinator joins the members of a FilterGroup.
+type FilterCombinator string
+
+const (
+	FilterAnd FilterCombinator = "AND"
+	FilterOr  FilterCombinator = "OR"
+)
+
+// FilterGroup is a parenthesized group of conditions and nested groups joined by Combinator.
+// An empty Combinator means AND.
+type FilterGroup struct {
+	Combinator FilterCombinator
+	Conditions []FilterCondition
+	Groups     []FilterGroup
 }
 
 // Pagination provides pagination settings.
@@ -35,6 +35,34 @@ type Repository[TEntity any, TID comparable] interface {
 	Exists(ctx context.Context, id TID) (bool, error)
 }
 
+// PagedRepository is implemented by repositories that return results one page at a time,
+// including keyset (cursor) pagination via Pagination.Cursor and PagedResult.NextCursor.
+type PagedRepository[TEntity any] interface {
+	ListPage(ctx context.Context, opts *ListOptions) (*PagedResult[TEntity], error)
+}
+
+// PartialUpdateRepository is implemented by repositories that support PATCH-style updates,
+// writing only the non-zero fields of the entity.
+type PartialUpdateRepository[TEntity any, TID comparable] interface {
+	UpdatePartial(ctx context.Context, id TID, entity *TEntity) error
+}
+
+// BatchRepository is implemented by repositories that can insert many entities in one round trip.
+type BatchRepository[TEntity any] interface {
+	CreateMany(ctx context.Context, entities []*TEntity) error
+}
+
+// LockingRepository is implemented by repositories that can lock a row for the rest of a transaction.
+type LockingRepository[TEntity any, TID comparable] interface {
+	// GetByIDForUpdate retrieves an entity and locks its row; ctx must carry a transaction.
+	GetByIDForUpdate(ctx context.Context, id TID) (*TEntity, error)
+}
+
+// AggregateRepository is implemented by repositories that can compute grouped aggregates (SUM, AVG, ...).
+type AggregateRepository interface {
+	Aggregate(ctx context.Context, spec AggregateSpec) ([]AggregateRow, error)
+}
+
 // ReadRepository is a read-only repository interface.
 // Use case: When repository should only allow reads,
 // for follower-only database access, or
@@ -66,3 +94,11 @@ type TransactionalRepository[TEntity any, TID comparable] interface {
 	// Use this when you need the repository to participate in an existing transaction.
 	WithTx(tx *sql.Tx) Repository[TEntity, TID]
 }
+
+// KeyedRepository is implemented by repositories that address rows by a (possibly composite) primary key,
+// given as column name to value.
+type KeyedRepository[TEntity any] interface {
+	GetByKey(ctx context.Context, key map[string]any) (*TEntity, error)
+	UpdateByKey(ctx context.Context, key map[string]any, entity *TEntity) error
+	DeleteByKey(ctx context.Context, key map[string]any) error
+}
@@ -0,0 +1,35 @@
+package repository
+
+// PagedResult is one page of entities returned by a paged list operation.
+type PagedResult[T any] struct {
+	Items      []*T   // Entities in this page
+	Total      int64  // Total matching entities; 0 when ListOptions.SkipCount is set
+	Limit      int    // Page size used for the query
+	Offset     int    // Offset used for the query; 0 for cursor pages
+	NextCursor string // Opaque cursor for the next page; empty when there are no more rows or no sort is set
+	HasMore    bool   // Whether more rows follow this page
+}
+
+// NewPagedResult builds an offset-based PagedResult from items, total and the effective pagination.
+// HasMore is derived from total, so it is only meaningful when the count was not skipped.
+func NewPagedResult[T any](items []*T, total int64, pagination Pagination) *PagedResult[T] {
+	return &PagedResult[T]{
+		Items:   items,
+		Total:   total,
+		Limit:   pagination.Limit,
+		Offset:  pagination.Offset,
+		HasMore: int64(pagination.Offset+len(items)) < total,
+	}
+}
+
+// NewCursorPagedResult builds a cursor-based PagedResult from items, the cursor for the
+// next page (empty on the last page, see EncodeCursor) and the page size used.
+// Total is left 0; set it when the count was queried.
+func NewCursorPagedResult[T any](items []*T, nextCursor string, limit int) *PagedResult[T] {
+	return &PagedResult[T]{
+		Items:      items,
+		Limit:      limit,
+		NextCursor: nextCursor,
+		HasMore:    nextCursor != "",
+	}
+}
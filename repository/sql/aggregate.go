@@ -0,0 +1,137 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/biairmal/go-sdk/repository"
+)
+
+// Aggregate functions accepted in an AggregateSpec (whitelist for safety).
+var supportedAggregates = map[repository.AggregateFunc]bool{
+	repository.AggregateCount: true,
+	repository.AggregateSum:   true,
+	repository.AggregateAvg:   true,
+	repository.AggregateMin:   true,
+	repository.AggregateMax:   true,
+}
+
+var aggregateAliasPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// BuildAggregateQuery builds SELECT <group columns>, <aggregates> FROM table [WHERE ...] [GROUP BY ... ORDER BY ...].
+// Column names are sanitized, aggregate functions are whitelisted and aliases must be plain identifiers;
+// anything else returns an error wrapping repository.ErrInvalidQuery.
+func BuildAggregateQuery(
+	table string, dialect Dialect, spec repository.AggregateSpec,
+) (aggregateQuery string, aggregateArgs []any, err error) {
+	if len(spec.Aggregations) == 0 {
+		return "", nil, fmt.Errorf("%w: no aggregations", repository.ErrInvalidQuery)
+	}
+	groupBy := make([]string, len(spec.GroupBy))
+	for i, col := range spec.GroupBy {
+		ident, ok := columnIdent(dialect, SanitizeColumnName(col))
+		if ident == "" || !ok {
+			return "", nil, fmt.Errorf("%w: invalid group-by column %q", repository.ErrInvalidQuery, col)
+		}
+		groupBy[i] = ident
+	}
+	selects := append([]string(nil), groupBy...)
+	for _, a := range spec.Aggregations {
+		expr, err := aggregateExpr(dialect, a)
+		if err != nil {
+			return "", nil, err
+		}
+		selects = append(selects, expr)
+	}
+	query := "SELECT " + strings.Join(selects, ", ") + " FROM " + table
+	whereClause, args := BuildWhereClause(dialect, spec.Filter)
+	if whereClause != "" {
+		query += " " + whereClause
+	}
+	if len(groupBy) > 0 {
+		cols := strings.Join(groupBy, ", ")
+		query += " GROUP BY " + cols + " ORDER BY " + cols
+	}
+	return query, args, nil
+}
+
+// aggregateExpr renders one aggregation as "FUNC(column) AS alias".
+func aggregateExpr(dialect Dialect, a repository.Aggregation) (string, error) {
+	fn := repository.AggregateFunc(strings.ToUpper(string(a.Func)))
+	if !supportedAggregates[fn] {
+		return "", fmt.Errorf("%w: unsupported aggregate function %q", repository.ErrInvalidQuery, a.Func)
+	}
+	col := SanitizeColumnName(a.Column)
+	if col == "" || (col == "*" && fn != repository.AggregateCount) {
+		return "", fmt.Errorf("%w: invalid aggregate column %q", repository.ErrInvalidQuery, a.Column)
+	}
+	ident := col
+	if col != "*" {
+		var ok bool
+		if ident, ok = columnIdent(dialect, col); !ok {
+			return "", fmt.Errorf("%w: invalid aggregate column %q", repository.ErrInvalidQuery, a.Column)
+		}
+	}
+	alias := a.Alias
+	if alias == "" {
+		alias = strings.ToLower(string(fn))
+		if col != "*" {
+			alias += "_" + strings.ReplaceAll(col, ".", "_")
+		}
+	}
+	if !aggregateAliasPattern.MatchString(alias) {
+		return "", fmt.Errorf("%w: invalid aggregate alias %q", repository.ErrInvalidQuery, alias)
+	}
+	return string(fn) + "(" + ident + ") AS " + alias, nil
+}
+
+// Aggregate runs the aggregate query described by spec on the read connection and returns one row per group
+// (a single row without GroupBy). Soft-deleted rows are excluded as in Count.
+// Values are returned as the driver scans them, except []byte, which is converted to string.
+func (r *SQLRepository[TEntity, TID]) Aggregate(
+	ctx context.Context, spec repository.AggregateSpec,
+) ([]repository.AggregateRow, error) {
+	if r.excludeDeleted(ctx, false) {
+		spec.Filter = r.notDeletedFilter(spec.Filter)
+	}
+	query, args, err := BuildAggregateQuery(r.TableName(), r.getDialect(), spec)
+	if err != nil {
+		return nil, err
+	}
+	conn := r.GetReadConnection(ctx)
+	r.logQuery(ctx, query, args)
+	rows, err := conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, ConvertSQLError(err)
+	}
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	var out []repository.AggregateRow
+	for rows.Next() {
+		values := make([]any, len(columns))
+		dest := make([]any, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, ConvertSQLError(err)
+		}
+		row := make(repository.AggregateRow, len(columns))
+		for i, col := range columns {
+			if b, ok := values[i].([]byte); ok {
+				values[i] = string(b)
+			}
+			row[col] = values[i]
+		}
+		out = append(out, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, ConvertSQLError(err)
+	}
+	return out, nil
+}
@@ -0,0 +1,112 @@
+package sql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/biairmal/go-sdk/repository"
+)
+
+func TestBuildAggregateQuery(t *testing.T) {
+	paid := repository.Filter{Conditions: []repository.FilterCondition{
+		{Field: "status", Operator: repository.FilterOperatorEq, Value: "paid"},
+	}}
+
+	tests := []struct {
+		name     string
+		spec     repository.AggregateSpec
+		want     string
+		wantArgs []any
+		wantErr  bool
+	}{
+		{
+			name: "sum grouped with filter",
+			spec: repository.AggregateSpec{
+				Aggregations: []repository.Aggregation{{Func: repository.AggregateSum, Column: "amount"}},
+				GroupBy:      []string{"currency"},
+				Filter:       paid,
+			},
+			want: "SELECT currency, SUM(amount) AS sum_amount FROM orders WHERE status = $1 " +
+				"GROUP BY currency ORDER BY currency",
+			wantArgs: []any{"paid"},
+		},
+		{
+			name: "count star and alias",
+			spec: repository.AggregateSpec{Aggregations: []repository.Aggregation{
+				{Func: repository.AggregateCount, Column: "*"},
+				{Func: "max", Column: "amount", Alias: "largest"},
+			}},
+			want: "SELECT COUNT(*) AS count, MAX(amount) AS largest FROM orders",
+		},
+		{name: "no aggregations", spec: repository.AggregateSpec{}, wantErr: true},
+		{
+			name:    "unsupported function",
+			spec:    repository.AggregateSpec{Aggregations: []repository.Aggregation{{Func: "STRING_AGG", Column: "id"}}},
+			wantErr: true,
+		},
+		{
+			name:    "star only for count",
+			spec:    repository.AggregateSpec{Aggregations: []repository.Aggregation{{Func: repository.AggregateSum, Column: "*"}}},
+			wantErr: true,
+		},
+		{
+			name: "unsafe alias",
+			spec: repository.AggregateSpec{Aggregations: []repository.Aggregation{
+				{Func: repository.AggregateSum, Column: "amount", Alias: "x; DROP TABLE orders"},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "unsafe group by",
+			spec: repository.AggregateSpec{
+				Aggregations: []repository.Aggregation{{Func: repository.AggregateCount, Column: "*"}},
+				GroupBy:      []string{"a);--"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, args, err := BuildAggregateQuery("orders", Postgres{}, tt.spec)
+			if tt.wantErr {
+				if !errors.Is(err, repository.ErrInvalidQuery) {
+					t.Errorf("BuildAggregateQuery() error = %v, want ErrInvalidQuery", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("BuildAggregateQuery() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("BuildAggregateQuery() = %q, want %q", got, tt.want)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("BuildAggregateQuery() args = %v, want %v", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestSQLRepository_Aggregate(t *testing.T) {
+	backend, repo := newTestRepo(t)
+	backend.queryFn = func(string) ([]string, [][]driver.Value) {
+		return []string{"name", "count"}, [][]driver.Value{{[]byte("ann"), int64(2)}, {"bob", int64(1)}}
+	}
+	spec := repository.AggregateSpec{
+		Aggregations: []repository.Aggregation{{Func: repository.AggregateCount, Column: "*"}},
+		GroupBy:      []string{"name"},
+	}
+
+	got, err := repo.(repository.AggregateRepository).Aggregate(context.Background(), spec)
+	if err != nil {
+		t.Fatalf("Aggregate() error = %v", err)
+	}
+	want := []repository.AggregateRow{{"name": "ann", "count": int64(2)}, {"name": "bob", "count": int64(1)}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Aggregate() = %v, want %v", got, want)
+	}
+}
@@ -11,7 +11,8 @@ import (
 type BaseRepository struct {
 	db        *sqlkit.DB
 	tableName string
-	idColumn  string // Usually "id"
+	idColumn  string  // Usually "id"
+	tx        *sql.Tx // Bound by WithTx; used instead of the context transaction
 }
 
 // NewBaseRepository creates a new base repository.
@@ -29,6 +30,23 @@ func (r *BaseRepository) WithIDColumn(column string) *BaseRepository {
 	return r
 }
 
+// WithTx returns a copy of the base repository bound to tx: every connection it hands
+// out is tx, whatever the context carries. The receiver is not modified.
+func (r *BaseRepository) WithTx(tx *sql.Tx) *BaseRepository {
+	bound := *r
+	bound.tx = tx
+	return &bound
+}
+
+// Tx returns the transaction for ctx: the one bound by WithTx, otherwise the one
+// in the context (sqlkit.ExtractTx).
+func (r *BaseRepository) Tx(ctx context.Context) (*sql.Tx, bool) {
+	if r.tx != nil {
+		return r.tx, true
+	}
+	return sqlkit.ExtractTx(ctx)
+}
+
 // TableName returns the table name.
 func (r *BaseRepository) TableName() string {
 	return r.tableName
@@ -42,40 +60,98 @@ func (r *BaseRepository) IDColumn() string {
 // Connection is an interface for database operations.
 type Connection interface {
 	ExecContext(context.Context, string, ...interface{}) (sql.Result, error)
-	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
-	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
+	QueryContext(context.Context, string, ...interface{}) (Rows, error)
+	QueryRowContext(context.Context, string, ...interface{}) Row
 }
 
 // ReadConnection is an interface for read-only database operations.
 type ReadConnection interface {
-	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
-	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
+	QueryContext(context.Context, string, ...interface{}) (Rows, error)
+	QueryRowContext(context.Context, string, ...interface{}) Row
+}
+
+// Rows is the result set returned by Connection.QueryContext: a *sql.Rows inside a
+// transaction, a *sqlkit.Rows otherwise. Always Close it.
+type Rows interface {
+	Next() bool
+	NextResultSet() bool
+	Scan(dest ...any) error
+	Columns() ([]string, error)
+	ColumnTypes() ([]*sql.ColumnType, error)
+	Err() error
+	Close() error
+}
+
+// Row is the single-row result returned by Connection.QueryRowContext:
+// a *sql.Row inside a transaction, a *sqlkit.Row otherwise.
+type Row interface {
+	Scan(dest ...any) error
+	Err() error
+}
+
+// txConnection adapts *sql.Tx to Connection.
+type txConnection struct{ tx *sql.Tx }
+
+func (c txConnection) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return c.tx.ExecContext(ctx, query, args...)
+}
+
+func (c txConnection) QueryContext(ctx context.Context, query string, args ...any) (Rows, error) {
+	rows, err := c.tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (c txConnection) QueryRowContext(ctx context.Context, query string, args ...any) Row {
+	return c.tx.QueryRowContext(ctx, query, args...)
+}
+
+// dbConnection adapts *sqlkit.Conn to Connection.
+type dbConnection struct{ conn *sqlkit.Conn }
+
+func (c dbConnection) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return c.conn.ExecContext(ctx, query, args...)
+}
+
+func (c dbConnection) QueryContext(ctx context.Context, query string, args ...any) (Rows, error) {
+	rows, err := c.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (c dbConnection) QueryRowContext(ctx context.Context, query string, args ...any) Row {
+	return c.conn.QueryRowContext(ctx, query, args...)
 }
 
 // GetConnection returns appropriate database connection for write operations.
 // Behavior:
-// 1. Check if transaction exists in context (sqlkit.ExtractTx).
+// 1. Check if the repository is bound to a transaction (WithTx) or one exists in context (sqlkit.ExtractTx).
 // 2. If yes, return transaction.
-// 3. If no, return db.Leader().
+// 3. If no, return db.LeaderConn() (so sqlkit.Config.OnQuery fires).
 // Thread-safe: Yes.
 // Use: All write operations (CREATE, UPDATE, DELETE).
 func (r *BaseRepository) GetConnection(ctx context.Context) Connection {
-	if tx, ok := sqlkit.ExtractTx(ctx); ok {
-		return tx
+	if tx, ok := r.Tx(ctx); ok {
+		return txConnection{tx: tx}
 	}
-	return r.db.Leader()
+	return dbConnection{conn: r.db.LeaderConn()}
 }
 
 // GetReadConnection returns appropriate database connection for read operations.
 // Behavior:
-// 1. Check if transaction exists in context.
+// 1. Check if the repository is bound to a transaction or one exists in context.
 // 2. If yes, return transaction (for read consistency).
-// 3. If no, return db.Follower().
+// 3. If no, return db.ReaderConn(ctx), which uses a follower unless the context
+// carries a leader read preference (sqlkit.WithReadPreference).
 // Thread-safe: Yes.
 // Use: All read operations (SELECT).
 func (r *BaseRepository) GetReadConnection(ctx context.Context) ReadConnection {
-	if tx, ok := sqlkit.ExtractTx(ctx); ok {
-		return tx
+	if tx, ok := r.Tx(ctx); ok {
+		return txConnection{tx: tx}
 	}
-	return r.db.Follower()
+	return dbConnection{conn: r.db.ReaderConn(ctx)}
 }
@@ -0,0 +1,109 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/biairmal/go-sdk/repository"
+)
+
+// maxBatchInsertParams caps the bind parameters of one multi-row INSERT (the Postgres and MySQL limit).
+const maxBatchInsertParams = 65535
+
+// CreateMany inserts entities using multi-row INSERT statements.
+// Entities with a zero ID omit the ID column (DB default) and entities with an ID keep it, as in Create;
+// the two groups are inserted with separate statements, each split to stay within the bind parameter limit.
+// Generated IDs are written back when the dialect implements ReturningDialect (e.g. Postgres) via RETURNING,
+// in row order. The inserts run in the context's transaction, or in a new one when there is none.
+// Oracle does not support multi-row VALUES, so entities are inserted one by one with Create.
+func (r *SQLRepository[TEntity, TID]) CreateMany(ctx context.Context, entities []*TEntity) error {
+	if len(entities) == 0 {
+		return nil
+	}
+	for i, entity := range entities {
+		if entity == nil {
+			return fmt.Errorf("%w: entity %d is nil", repository.ErrInvalidEntity, i)
+		}
+	}
+	if _, ok := r.Tx(ctx); ok {
+		return r.createMany(ctx, entities)
+	}
+	return r.db.WithTransaction(ctx, func(txCtx context.Context) error {
+		return r.createMany(txCtx, entities)
+	})
+}
+
+func (r *SQLRepository[TEntity, TID]) createMany(ctx context.Context, entities []*TEntity) error {
+	if _, ok := unwrapDialect(r.getDialect()).(Oracle); ok {
+		for _, entity := range entities {
+			if err := r.Create(ctx, entity); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	var generated, explicit []*TEntity
+	for _, entity := range entities {
+		if IsEntityIDZero(entity, r.IDColumn()) {
+			generated = append(generated, entity)
+		} else {
+			explicit = append(explicit, entity)
+		}
+	}
+	if err := r.insertBatches(ctx, generated, true); err != nil {
+		return err
+	}
+	return r.insertBatches(ctx, explicit, false)
+}
+
+// insertBatches inserts entities in chunks that fit maxBatchInsertParams.
+func (r *SQLRepository[TEntity, TID]) insertBatches(ctx context.Context, entities []*TEntity, excludeID bool) error {
+	if len(entities) == 0 {
+		return nil
+	}
+	perRow := len(insertValues(entities[0], r.IDColumn(), excludeID, r.insertStamps()))
+	if perRow == 0 {
+		return fmt.Errorf("%w: no columns to insert", repository.ErrInvalidEntity)
+	}
+	chunk := max(maxBatchInsertParams/perRow, 1)
+	for start := 0; start < len(entities); start += chunk {
+		end := min(start+chunk, len(entities))
+		if err := r.insertBatch(ctx, entities[start:end], excludeID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// insertBatch runs one multi-row INSERT and writes generated IDs back when supported.
+func (r *SQLRepository[TEntity, TID]) insertBatch(ctx context.Context, entities []*TEntity, excludeID bool) error {
+	conn := r.GetConnection(ctx)
+	d := r.getDialect()
+	idColumn := r.IDColumn()
+	stamped := r.insertStamps()
+	query := buildInsertQuery(r.TableName(), idColumn, d, r.entityType, excludeID, stamped, len(entities))
+	args := make([]any, 0, len(entities))
+	for _, entity := range entities {
+		args = append(args, insertValues(entity, idColumn, excludeID, stamped)...)
+	}
+
+	rd, ok := d.(ReturningDialect)
+	if !excludeID || !ok || !rd.SupportsReturning() {
+		r.logQuery(ctx, query, args)
+		_, err := conn.ExecContext(ctx, query, args...)
+		return ConvertSQLError(err)
+	}
+	query += " RETURNING " + trustedIdent(d, idColumn)
+	r.logQuery(ctx, query, args)
+	rows, err := conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return ConvertSQLError(err)
+	}
+	defer rows.Close()
+	for i := 0; rows.Next() && i < len(entities); i++ {
+		if err := ScanReturnedIDAndSetEntity(entities[i], idColumn, rows); err != nil {
+			return ConvertSQLError(err)
+		}
+	}
+	return ConvertSQLError(rows.Err())
+}
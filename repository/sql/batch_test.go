@@ -0,0 +1,103 @@
+package sql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/biairmal/go-sdk/repository"
+)
+
+func TestBuildBatchInsertQuery(t *testing.T) {
+	typ := reflect.TypeOf(testUser{})
+
+	tests := []struct {
+		name      string
+		dialect   Dialect
+		excludeID bool
+		rows      int
+		want      string
+	}{
+		{
+			name: "postgres with id", dialect: Postgres{}, rows: 2,
+			want: "INSERT INTO users (id, name, deleted_at) VALUES ($1, $2, $3), ($4, $5, $6)",
+		},
+		{
+			name: "mysql without id", dialect: MySQL{}, excludeID: true, rows: 2,
+			want: "INSERT INTO users (name, deleted_at) VALUES (?, ?), (?, ?)",
+		},
+		{name: "no rows", dialect: Postgres{}, rows: 0, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BuildBatchInsertQuery("users", "id", tt.dialect, typ, tt.excludeID, tt.rows)
+			if got != tt.want {
+				t.Errorf("BuildBatchInsertQuery() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSQLRepository_CreateManyMixedIDs(t *testing.T) {
+	backend, repo := newTestRepo(t)
+	backend.queryFn = func(string) ([]string, [][]driver.Value) {
+		return []string{"id"}, [][]driver.Value{{int64(101)}, {int64(102)}}
+	}
+	entities := []*testUser{{Name: "ann"}, {ID: 7, Name: "bob"}, {Name: "cat"}}
+
+	if err := repo.(repository.BatchRepository[testUser]).CreateMany(context.Background(), entities); err != nil {
+		t.Fatalf("CreateMany() error = %v", err)
+	}
+
+	var got []string
+	for _, st := range backend.Statements() {
+		got = append(got, st.Query)
+	}
+	want := []string{
+		"BEGIN",
+		"INSERT INTO users (name, deleted_at) VALUES ($1, $2), ($3, $4) RETURNING id",
+		"INSERT INTO users (id, name, deleted_at) VALUES ($1, $2, $3)",
+		"COMMIT",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CreateMany() statements = %q, want %q", got, want)
+	}
+	if entities[0].ID != 101 || entities[1].ID != 7 || entities[2].ID != 102 {
+		t.Errorf("CreateMany() IDs = %d, %d, %d, want 101, 7, 102", entities[0].ID, entities[1].ID, entities[2].ID)
+	}
+}
+
+func TestSQLRepository_CreateManyWithoutReturning(t *testing.T) {
+	backend, repo := newTestRepo(t, WithDialect[testUser, int64](MySQL{}))
+	entities := []*testUser{{Name: "ann"}, {Name: "bob"}}
+
+	if err := repo.(repository.BatchRepository[testUser]).CreateMany(context.Background(), entities); err != nil {
+		t.Fatalf("CreateMany() error = %v", err)
+	}
+	stmts := backend.Statements()
+	if len(stmts) != 3 || stmts[1].Query != "INSERT INTO users (name, deleted_at) VALUES (?, ?), (?, ?)" {
+		t.Errorf("CreateMany() statements = %v, want one multi-row INSERT in a transaction", stmts)
+	}
+	if len(stmts) == 3 && len(stmts[1].Args) != 4 {
+		t.Errorf("CreateMany() args = %v, want 4 values", stmts[1].Args)
+	}
+}
+
+func TestSQLRepository_CreateManyInvalid(t *testing.T) {
+	backend, repo := newTestRepo(t)
+	batch := repo.(repository.BatchRepository[testUser])
+
+	if err := batch.CreateMany(context.Background(), nil); err != nil {
+		t.Errorf("CreateMany(nil) error = %v, want nil", err)
+	}
+	err := batch.CreateMany(context.Background(), []*testUser{{Name: "ann"}, nil})
+	if !errors.Is(err, repository.ErrInvalidEntity) {
+		t.Errorf("CreateMany() with nil entity error = %v, want ErrInvalidEntity", err)
+	}
+	if n := len(backend.Statements()); n != 0 {
+		t.Errorf("CreateMany() ran %d statements for invalid input, want 0", n)
+	}
+}
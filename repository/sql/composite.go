@@ -0,0 +1,121 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/biairmal/go-sdk/repository"
+)
+
+// keyColumns returns the primary key columns: the WithCompositeID columns, or the ID column alone.
+func (r *SQLRepository[TEntity, TID]) keyColumns() []string {
+	if len(r.compositeID) > 0 {
+		return r.compositeID
+	}
+	return []string{r.IDColumn()}
+}
+
+// immutableColumns returns the columns never written by Update/UpdateByKey: the ID column, the key columns
+// and the WithTimestamps created column.
+func (r *SQLRepository[TEntity, TID]) immutableColumns() []string {
+	cols := []string{r.IDColumn()}
+	for _, col := range r.compositeID {
+		if !containsFold(cols, col) {
+			cols = append(cols, col)
+		}
+	}
+	if r.createdAt != "" && !containsFold(cols, r.createdAt) {
+		cols = append(cols, r.createdAt)
+	}
+	return cols
+}
+
+// keyCondition returns "k1 = $n AND k2 = $n+1 ..." over all key columns (placeholders start at argIdx)
+// and the matching args taken from key. key must hold exactly the key columns (case-insensitive);
+// otherwise an error wrapping repository.ErrInvalidID is returned.
+func (r *SQLRepository[TEntity, TID]) keyCondition(
+	key map[string]any, argIdx int,
+) (cond string, args []any, err error) {
+	columns := r.keyColumns()
+	if len(key) != len(columns) {
+		return "", nil, fmt.Errorf("%w: key must have exactly the columns %v", repository.ErrInvalidID, columns)
+	}
+	d := r.getDialect()
+	conds := make([]string, len(columns))
+	for i, col := range columns {
+		value, ok := lookupFold(key, col)
+		if !ok {
+			return "", nil, fmt.Errorf("%w: key is missing column %q", repository.ErrInvalidID, col)
+		}
+		conds[i] = trustedIdent(d, col) + " = " + d.Placeholder(argIdx+i)
+		args = append(args, value)
+	}
+	return strings.Join(conds, " AND "), args, nil
+}
+
+// lookupFold returns the value of key whose name equals column, ignoring case.
+func lookupFold(key map[string]any, column string) (any, bool) {
+	if v, ok := key[column]; ok {
+		return v, true
+	}
+	for k, v := range key {
+		if strings.EqualFold(k, column) {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// GetByKey retrieves an entity by its full primary key, e.g. {"tenant_id": 1, "user_id": 7} with
+// WithCompositeID("tenant_id", "user_id"). Without WithCompositeID the key is the ID column alone.
+// Returns repository.ErrNotFound when no row matches (soft-deleted rows are skipped as in GetByID).
+func (r *SQLRepository[TEntity, TID]) GetByKey(ctx context.Context, key map[string]any) (*TEntity, error) {
+	cond, args, err := r.keyCondition(key, 1)
+	if err != nil {
+		return nil, err
+	}
+	if r.excludeDeleted(ctx, false) {
+		cond += " AND " + trustedIdent(r.getDialect(), r.softDelete) + " IS NULL"
+	}
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s", r.selectClause(), r.TableName(), cond)
+	return r.queryOne(ctx, r.GetReadConnection(ctx), query, args)
+}
+
+// UpdateByKey updates the entity identified by its full primary key. Key columns and the ID column
+// are not updated. Returns repository.ErrNotFound when no row matches.
+func (r *SQLRepository[TEntity, TID]) UpdateByKey(ctx context.Context, key map[string]any, entity *TEntity) error {
+	excluded, stamped := r.immutableColumns(), r.updateStamps()
+	query := buildUpdateQuery(r.TableName(), r.getDialect(), r.entityType, excluded, stamped, r.keyColumns())
+	if query == "" {
+		return fmt.Errorf("repository: no fields to update")
+	}
+	args := updateValues(entity, append(excluded, stamped...))
+	_, keyArgs, err := r.keyCondition(key, len(args)+1)
+	if err != nil {
+		return err
+	}
+	return r.execAffectingOne(ctx, query, append(args, keyArgs...))
+}
+
+// DeleteByKey removes the entity identified by its full primary key, honouring WithSoftDelete like Delete.
+// Returns repository.ErrNotFound when no row matches.
+func (r *SQLRepository[TEntity, TID]) DeleteByKey(ctx context.Context, key map[string]any) error {
+	d := r.getDialect()
+	if r.softDelete == "" {
+		cond, args, err := r.keyCondition(key, 1)
+		if err != nil {
+			return err
+		}
+		return r.execAffectingOne(ctx, fmt.Sprintf("DELETE FROM %s WHERE %s", r.TableName(), cond), args)
+	}
+	cond, args, err := r.keyCondition(key, 2)
+	if err != nil {
+		return err
+	}
+	softDelete := trustedIdent(d, r.softDelete)
+	query := fmt.Sprintf("UPDATE %s SET %s = %s WHERE %s AND %s IS NULL",
+		r.TableName(), softDelete, d.Placeholder(1), cond, softDelete)
+	return r.execAffectingOne(ctx, query, append([]any{time.Now().UTC()}, args...))
+}
@@ -0,0 +1,133 @@
+package sql
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/biairmal/go-sdk/repository"
+)
+
+type membership struct {
+	ID       int64  `db:"id"`
+	TenantID int64  `db:"tenant_id"`
+	UserID   int64  `db:"user_id"`
+	Role     string `db:"role"`
+}
+
+func newMembershipRepo(
+	t *testing.T, opts ...SQLRepositoryOption[membership, int64],
+) (*fakeBackend, repository.Repository[membership, int64]) {
+	t.Helper()
+	backend, db := newFakeRepoDB(t)
+	opts = append([]SQLRepositoryOption[membership, int64]{
+		WithCompositeID[membership, int64]("tenant_id", "user_id"),
+	}, opts...)
+	return backend, NewSQLRepository[membership, int64](nil, db, "memberships", opts...)
+}
+
+func TestBuildUpdateQueryByKey(t *testing.T) {
+	typ := reflect.TypeOf(membership{})
+	got := BuildUpdateQueryByKey("memberships", []string{"tenant_id", "user_id"}, Postgres{}, typ)
+	want := "UPDATE memberships SET id = $1, role = $2 WHERE tenant_id = $3 AND user_id = $4"
+	if got != want {
+		t.Errorf("BuildUpdateQueryByKey() = %q, want %q", got, want)
+	}
+	if got := BuildUpdateQuery("memberships", "id", Postgres{}, typ); got !=
+		"UPDATE memberships SET tenant_id = $1, user_id = $2, role = $3 WHERE id = $4" {
+		t.Errorf("BuildUpdateQuery() = %q", got)
+	}
+}
+
+func TestSQLRepository_KeyedOperations(t *testing.T) {
+	key := map[string]any{"user_id": int64(7), "TENANT_ID": int64(1)}
+	tests := []struct {
+		name     string
+		opts     []SQLRepositoryOption[membership, int64]
+		run      func(repository.KeyedRepository[membership]) error
+		want     string
+		wantArgs int
+	}{
+		{
+			name: "get",
+			run: func(r repository.KeyedRepository[membership]) error {
+				_, err := r.GetByKey(context.Background(), key)
+				return err
+			},
+			want:     "SELECT id, tenant_id, user_id, role FROM memberships WHERE tenant_id = $1 AND user_id = $2",
+			wantArgs: 2,
+		},
+		{
+			name: "update",
+			run: func(r repository.KeyedRepository[membership]) error {
+				return r.UpdateByKey(context.Background(), key, &membership{Role: "admin"})
+			},
+			want:     "UPDATE memberships SET role = $1 WHERE tenant_id = $2 AND user_id = $3",
+			wantArgs: 3,
+		},
+		{
+			name:     "delete",
+			run:      func(r repository.KeyedRepository[membership]) error { return r.DeleteByKey(context.Background(), key) },
+			want:     "DELETE FROM memberships WHERE tenant_id = $1 AND user_id = $2",
+			wantArgs: 2,
+		},
+		{
+			name:     "soft delete",
+			opts:     []SQLRepositoryOption[membership, int64]{WithSoftDelete[membership, int64]("role")},
+			run:      func(r repository.KeyedRepository[membership]) error { return r.DeleteByKey(context.Background(), key) },
+			want:     "UPDATE memberships SET role = $1 WHERE tenant_id = $2 AND user_id = $3 AND role IS NULL",
+			wantArgs: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend, repo := newMembershipRepo(t, tt.opts...)
+			err := tt.run(repo.(repository.KeyedRepository[membership]))
+			if err != nil && !errors.Is(err, repository.ErrNotFound) {
+				t.Fatalf("unexpected error = %v", err)
+			}
+			got := backend.Last()
+			if got.Query != tt.want {
+				t.Errorf("query = %q, want %q", got.Query, tt.want)
+			}
+			if len(got.Args) != tt.wantArgs {
+				t.Fatalf("args = %v, want %d args", got.Args, tt.wantArgs)
+			}
+			if got.Args[tt.wantArgs-2] != int64(1) || got.Args[tt.wantArgs-1] != int64(7) {
+				t.Errorf("key args = %v, want [1 7] last", got.Args)
+			}
+		})
+	}
+}
+
+func TestSQLRepository_GetByKeyInvalidKey(t *testing.T) {
+	_, repo := newMembershipRepo(t)
+	keyed := repo.(repository.KeyedRepository[membership])
+
+	for _, key := range []map[string]any{
+		{"tenant_id": 1},
+		{"tenant_id": 1, "role": "admin"},
+		{"tenant_id": 1, "user_id": 7, "role": "admin"},
+	} {
+		if _, err := keyed.GetByKey(context.Background(), key); !errors.Is(err, repository.ErrInvalidID) {
+			t.Errorf("GetByKey(%v) error = %v, want ErrInvalidID", key, err)
+		}
+	}
+}
+
+func TestSQLRepository_UpdateExcludesKeyColumns(t *testing.T) {
+	backend, repo := newMembershipRepo(t)
+
+	if err := repo.Update(context.Background(), 3, &membership{Role: "admin"}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	got := backend.Last()
+	if want := "UPDATE memberships SET role = $1 WHERE id = $2"; got.Query != want {
+		t.Errorf("Update() query = %q, want %q", got.Query, want)
+	}
+	if want := []any{"admin", int64(3)}; !reflect.DeepEqual(got.Args, want) {
+		t.Errorf("Update() args = %v, want %v", got.Args, want)
+	}
+}
@@ -9,10 +9,13 @@ import (
 	"github.com/google/uuid"
 )
 
-// orderedColumn holds column name and struct field index for stable ordering.
+// orderedColumn holds column name and struct field index path for stable ordering.
+// Index is a reflect.Value.FieldByIndex path, longer than one for fields of embedded structs.
+// JSON is set by the tag option `db:"name,json"`.
 type orderedColumn struct {
 	Name  string
-	Index int
+	Index []int
+	JSON  bool
 }
 
 var orderedColumnsCache sync.Map // map[reflect.Type][]orderedColumn
@@ -23,6 +26,11 @@ var (
 )
 
 // getOrderedColumns returns db-tagged columns in struct field order.
+// Anonymous embedded structs (or pointers to structs) without a db tag are flattened, so
+// shared columns can live in an embedded base struct; their columns appear where the
+// struct is embedded. When several fields map to the same column (case-insensitive),
+// the least nested one wins, so an entity can override a column of its base; at equal
+// depth the first in field order wins.
 func getOrderedColumns(typ reflect.Type) []orderedColumn {
 	if typ.Kind() != reflect.Struct {
 		return nil
@@ -31,27 +39,116 @@ func getOrderedColumns(typ reflect.Type) []orderedColumn {
 	if v, ok := orderedColumnsCache.Load(key); ok {
 		return v.([]orderedColumn)
 	}
+	var found []orderedColumn
+	collectColumns(typ, nil, map[reflect.Type]bool{typ: true}, &found)
+	winner := make(map[string]int, len(found))
+	for i, c := range found {
+		name := strings.ToLower(c.Name)
+		if w, ok := winner[name]; !ok || len(c.Index) < len(found[w].Index) {
+			winner[name] = i
+		}
+	}
 	var cols []orderedColumn
+	for i, c := range found {
+		if winner[strings.ToLower(c.Name)] == i {
+			cols = append(cols, c)
+		}
+	}
+	orderedColumnsCache.Store(key, cols)
+	return cols
+}
+
+// collectColumns appends the db-tagged fields of typ to found, depth first, recursing into
+// untagged anonymous struct fields. prefix is the index path of typ; seen holds the struct
+// types on the current path, so self-referencing embeddings (type T struct{ *T }) stop.
+func collectColumns(typ reflect.Type, prefix []int, seen map[reflect.Type]bool, found *[]orderedColumn) {
 	for i := 0; i < typ.NumField(); i++ {
 		f := typ.Field(i)
-		if f.PkgPath != "" {
+		index := append(append([]int(nil), prefix...), i)
+		tag := f.Tag.Get("db")
+		if tag == "-" {
 			continue
 		}
-		tag := f.Tag.Get("db")
-		if tag == "" || tag == "-" {
+		if tag == "" {
+			if embedded, ok := embeddedStruct(f); ok && !seen[embedded] {
+				seen[embedded] = true
+				collectColumns(embedded, index, seen, found)
+				delete(seen, embedded)
+			}
 			continue
 		}
-		name := strings.TrimSpace(tag)
-		if idx := strings.Index(name, ","); idx >= 0 {
-			name = strings.TrimSpace(name[:idx])
+		if !f.IsExported() {
+			continue
 		}
+		name, opts, _ := strings.Cut(tag, ",")
+		name = strings.TrimSpace(name)
 		if name == "" {
 			continue
 		}
-		cols = append(cols, orderedColumn{Name: name, Index: i})
+		*found = append(*found, orderedColumn{Name: name, Index: index, JSON: hasTagOption(opts, "json")})
 	}
-	orderedColumnsCache.Store(key, cols)
-	return cols
+}
+
+// embeddedStruct returns the struct type of an anonymous field whose promoted fields
+// can be read and set: an exported struct or pointer to struct, or an unexported
+// struct (like encoding/json, whose exported fields are still promoted).
+func embeddedStruct(f reflect.StructField) (reflect.Type, bool) {
+	if !f.Anonymous {
+		return nil, false
+	}
+	t := f.Type
+	if t.Kind() == reflect.Ptr {
+		if !f.IsExported() {
+			return nil, false // cannot allocate an unexported pointer when scanning
+		}
+		t = t.Elem()
+	}
+	return t, t.Kind() == reflect.Struct
+}
+
+// findColumn returns the column of typ named name (case-insensitive).
+func findColumn(typ reflect.Type, name string) (orderedColumn, bool) {
+	for _, c := range getOrderedColumns(typ) {
+		if strings.EqualFold(c.Name, name) {
+			return c, true
+		}
+	}
+	return orderedColumn{}, false
+}
+
+// fieldByIndex returns the field of v at index like v.FieldByIndex, but returns the
+// zero value of the field instead of panicking when index passes a nil embedded pointer.
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	f, err := v.FieldByIndexErr(index)
+	if err != nil {
+		return reflect.Zero(v.Type().FieldByIndex(index).Type)
+	}
+	return f
+}
+
+// settableFieldByIndex returns the field of v at index, allocating nil embedded
+// pointers on the way so the field can be set. v must be addressable.
+func settableFieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+// hasTagOption reports whether the comma-separated tag options contain opt.
+func hasTagOption(opts, opt string) bool {
+	for _, o := range strings.Split(opts, ",") {
+		if strings.TrimSpace(o) == opt {
+			return true
+		}
+	}
+	return false
 }
 
 // isFieldZero returns true if v is the zero value for its type (nil ptr, zero int, uuid.Nil, empty string, etc.).
@@ -85,53 +182,80 @@ func IsEntityIDZero[T any](entity *T, idColumn string) bool {
 	if entity == nil || idColumn == "" {
 		return true
 	}
-	typ := reflect.TypeOf(entity).Elem()
 	val := reflect.ValueOf(entity).Elem()
-	idColLower := strings.ToLower(idColumn)
-	for i := 0; i < typ.NumField(); i++ {
-		f := typ.Field(i)
-		tag := f.Tag.Get("db")
-		if tag == "" || tag == "-" {
-			continue
-		}
-		name := strings.TrimSpace(tag)
-		if idx := strings.Index(name, ","); idx >= 0 {
-			name = strings.TrimSpace(name[:idx])
-		}
-		if strings.ToLower(name) != idColLower {
-			continue
-		}
-		return isFieldZero(val.Field(i))
+	c, ok := findColumn(val.Type(), idColumn)
+	if !ok {
+		return true
 	}
-	return true
+	return isFieldZero(fieldByIndex(val, c.Index))
 }
 
 // BuildInsertQuery builds INSERT INTO table (cols...) VALUES (placeholders) using dialect.
 // When excludeIDColumn is true, the column matching idColumn is omitted (for DB default).
 func BuildInsertQuery(table, idColumn string, dialect Dialect, typ reflect.Type, excludeIDColumn bool) string {
+	return buildInsertQuery(table, idColumn, dialect, typ, excludeIDColumn, nil, 1)
+}
+
+// BuildBatchInsertQuery builds INSERT INTO table (cols...) VALUES (...), (...) for rowCount rows using dialect,
+// with placeholders numbered row by row. When excludeIDColumn is true, the column matching idColumn is omitted.
+func BuildBatchInsertQuery(
+	table, idColumn string, dialect Dialect, typ reflect.Type, excludeIDColumn bool, rowCount int,
+) string {
+	return buildInsertQuery(table, idColumn, dialect, typ, excludeIDColumn, nil, rowCount)
+}
+
+// buildInsertQuery builds the INSERT of BuildBatchInsertQuery, additionally setting the stamped columns to the
+// dialect's current timestamp (see WithTimestamps). Stamped columns are listed last, whether or not typ maps them;
+// typ's own fields for them are not bound.
+func buildInsertQuery(
+	table, idColumn string, dialect Dialect, typ reflect.Type, excludeIDColumn bool, stamped []string, rowCount int,
+) string {
 	if dialect == nil {
 		dialect = DefaultDialect
 	}
-	cols := getOrderedColumns(typ)
-	if len(cols) == 0 {
+	if rowCount <= 0 {
 		return ""
 	}
-	idColLower := strings.ToLower(idColumn)
 	var names []string
-	var placeholders []string
-	argIdx := 1
-	for _, c := range cols {
-		if excludeIDColumn && strings.ToLower(c.Name) == idColLower {
-			continue
-		}
-		names = append(names, c.Name)
-		placeholders = append(placeholders, dialect.Placeholder(argIdx))
-		argIdx++
+	for _, c := range insertColumns(typ, idColumn, excludeIDColumn, stamped) {
+		names = append(names, trustedIdent(dialect, c.Name))
+	}
+	bound := len(names)
+	for _, col := range stamped {
+		names = append(names, trustedIdent(dialect, col))
 	}
 	if len(names) == 0 {
 		return ""
 	}
-	return "INSERT INTO " + table + " (" + strings.Join(names, ", ") + ") VALUES (" + strings.Join(placeholders, ", ") + ")"
+	now := currentTimestamp(dialect)
+	rows := make([]string, rowCount)
+	values := make([]string, len(names))
+	argIdx := 1
+	for i := range rows {
+		for j := range values {
+			if j >= bound {
+				values[j] = now
+				continue
+			}
+			values[j] = dialect.Placeholder(argIdx)
+			argIdx++
+		}
+		rows[i] = "(" + strings.Join(values, ", ") + ")"
+	}
+	return "INSERT INTO " + table + " (" + strings.Join(names, ", ") + ") VALUES " + strings.Join(rows, ", ")
+}
+
+// insertColumns returns the columns of typ bound by INSERT, in field order: every db-tagged column except
+// the ID column (when excludeIDColumn is true) and the skipped ones.
+func insertColumns(typ reflect.Type, idColumn string, excludeIDColumn bool, skipped []string) []orderedColumn {
+	var cols []orderedColumn
+	for _, c := range getOrderedColumns(typ) {
+		if (excludeIDColumn && strings.EqualFold(c.Name, idColumn)) || containsFold(skipped, c.Name) {
+			continue
+		}
+		cols = append(cols, c)
+	}
+	return cols
 }
 
 // fieldValueToAny converts a struct field value to a value suitable for SQL (INSERT/UPDATE).
@@ -156,22 +280,22 @@ func fieldValueToAny(v reflect.Value) any {
 // ExtractInsertValues returns values for INSERT in the same order as columns (optionally excluding ID).
 // When excludeIDColumn is true, the value for the column matching idColumn is omitted (for DB default).
 func ExtractInsertValues[T any](entity *T, idColumn string, excludeIDColumn bool) []any {
+	return insertValues(entity, idColumn, excludeIDColumn, nil)
+}
+
+// insertValues returns the values bound by buildInsertQuery for entity, skipping the stamped columns.
+func insertValues[T any](entity *T, idColumn string, excludeIDColumn bool, stamped []string) []any {
 	if entity == nil {
 		return nil
 	}
-	typ := reflect.TypeOf(entity).Elem()
-	cols := getOrderedColumns(typ)
+	val := reflect.ValueOf(entity).Elem()
+	cols := insertColumns(val.Type(), idColumn, excludeIDColumn, stamped)
 	if len(cols) == 0 {
 		return nil
 	}
-	idColLower := strings.ToLower(idColumn)
-	val := reflect.ValueOf(entity).Elem()
 	out := make([]any, 0, len(cols))
 	for _, c := range cols {
-		if excludeIDColumn && strings.ToLower(c.Name) == idColLower {
-			continue
-		}
-		out = append(out, fieldValueToAny(val.Field(c.Index)))
+		out = append(out, columnValueToAny(fieldByIndex(val, c.Index), c))
 	}
 	return out
 }
@@ -190,29 +314,17 @@ func scanUUID(row RowScanner) (uuid.UUID, error) {
 	return uuid.Parse(s)
 }
 
-// getEntityIDFieldInfo returns the ID field index and type for the entity's column matching idColumn.
-func getEntityIDFieldInfo[T any](entity *T, idColumn string) (fieldIndex int, fieldType reflect.Type, ok bool) {
+// getEntityIDFieldInfo returns the ID field index path and type for the entity's column matching idColumn.
+func getEntityIDFieldInfo[T any](entity *T, idColumn string) (fieldIndex []int, fieldType reflect.Type, ok bool) {
 	if entity == nil || idColumn == "" {
-		return 0, nil, false
+		return nil, nil, false
 	}
 	typ := reflect.TypeOf(entity).Elem()
-	idColLower := strings.ToLower(idColumn)
-	for i := 0; i < typ.NumField(); i++ {
-		f := typ.Field(i)
-		tag := f.Tag.Get("db")
-		if tag == "" || tag == "-" {
-			continue
-		}
-		name := strings.TrimSpace(tag)
-		if idx := strings.Index(name, ","); idx >= 0 {
-			name = strings.TrimSpace(name[:idx])
-		}
-		if strings.ToLower(name) != idColLower {
-			continue
-		}
-		return i, f.Type, true
+	c, ok := findColumn(typ, idColumn)
+	if !ok {
+		return nil, nil, false
 	}
-	return 0, nil, false
+	return c.Index, typ.FieldByIndex(c.Index).Type, true
 }
 
 // IsEntityIDFieldInt64 returns true if the entity's ID field is int64 or *int64 (so LastInsertId can be used).
@@ -241,7 +353,7 @@ func ScanReturnedIDAndSetEntity[T any](entity *T, idColumn string, row RowScanne
 		return nil
 	}
 	val := reflect.ValueOf(entity).Elem()
-	field := val.Field(idx)
+	field := settableFieldByIndex(val, idx)
 	if !field.CanSet() {
 		return nil
 	}
@@ -295,63 +407,124 @@ func SetEntityID[T any](entity *T, id int64, idColumn string) error {
 	if entity == nil || idColumn == "" {
 		return nil
 	}
-	typ := reflect.TypeOf(entity).Elem()
 	val := reflect.ValueOf(entity).Elem()
-	idColLower := strings.ToLower(idColumn)
-	for i := 0; i < typ.NumField(); i++ {
-		f := typ.Field(i)
-		tag := f.Tag.Get("db")
-		if tag == "" || tag == "-" {
-			continue
-		}
-		name := strings.TrimSpace(tag)
-		if idx := strings.Index(name, ","); idx >= 0 {
-			name = strings.TrimSpace(name[:idx])
-		}
-		if strings.ToLower(name) != idColLower {
-			continue
-		}
-		field := val.Field(i)
-		if field.Kind() == reflect.Ptr {
-			if field.Type().Elem().Kind() != reflect.Int64 {
-				return nil
-			}
-			field.Set(reflect.ValueOf(&id))
-			return nil
-		}
-		if field.Kind() == reflect.Int64 && field.CanSet() {
-			field.SetInt(id)
+	c, ok := findColumn(val.Type(), idColumn)
+	if !ok {
+		return nil
+	}
+	field := settableFieldByIndex(val, c.Index)
+	if field.Kind() == reflect.Ptr {
+		if field.Type().Elem().Kind() != reflect.Int64 {
 			return nil
 		}
+		field.Set(reflect.ValueOf(&id))
 		return nil
 	}
+	if field.Kind() == reflect.Int64 && field.CanSet() {
+		field.SetInt(id)
+	}
 	return nil
 }
 
 // BuildUpdateQuery builds UPDATE table SET col1=ph1, ... WHERE idCol=phN using dialect.
 // idColumn is excluded from SET and used in WHERE.
 func BuildUpdateQuery(table, idColumn string, dialect Dialect, typ reflect.Type) string {
+	return buildUpdateQuery(table, dialect, typ, []string{idColumn}, nil, []string{idColumn})
+}
+
+// BuildUpdateQueryByKey builds UPDATE table SET ... WHERE k1=ph AND k2=ph ... for a composite key.
+// All keyColumns are excluded from SET; WHERE placeholders follow the SET placeholders in keyColumns order.
+func BuildUpdateQueryByKey(table string, keyColumns []string, dialect Dialect, typ reflect.Type) string {
+	return buildUpdateQuery(table, dialect, typ, keyColumns, nil, keyColumns)
+}
+
+// buildUpdateQuery builds an UPDATE setting every db-tagged column except excluded and stamped, matching on
+// whereColumns. Stamped columns are set to the dialect's current timestamp after the bound ones (see WithTimestamps).
+func buildUpdateQuery(
+	table string, dialect Dialect, typ reflect.Type, excluded, stamped, whereColumns []string,
+) string {
 	if dialect == nil {
 		dialect = DefaultDialect
 	}
-	cols := getOrderedColumns(typ)
-	idColLower := strings.ToLower(idColumn)
-	var setCols []orderedColumn
-	for _, c := range cols {
-		if strings.ToLower(c.Name) == idColLower {
+	var parts []string
+	argIdx := 1
+	for _, c := range getOrderedColumns(typ) {
+		if containsFold(excluded, c.Name) || containsFold(stamped, c.Name) {
 			continue
 		}
-		setCols = append(setCols, c)
+		parts = append(parts, trustedIdent(dialect, c.Name)+" = "+dialect.Placeholder(argIdx))
+		argIdx++
 	}
-	if len(setCols) == 0 {
+	if len(parts) == 0 || len(whereColumns) == 0 {
 		return ""
 	}
-	parts := make([]string, len(setCols))
-	for i, c := range setCols {
-		parts[i] = c.Name + " = " + dialect.Placeholder(i+1)
+	parts = append(parts, stampAssignments(dialect, stamped)...)
+	conds := make([]string, len(whereColumns))
+	for i, col := range whereColumns {
+		conds[i] = trustedIdent(dialect, col) + " = " + dialect.Placeholder(argIdx+i)
+	}
+	return "UPDATE " + table + " SET " + strings.Join(parts, ", ") + " WHERE " + strings.Join(conds, " AND ")
+}
+
+// stampAssignments returns "col = <current timestamp>" for each stamped column.
+func stampAssignments(dialect Dialect, stamped []string) []string {
+	now := currentTimestamp(dialect)
+	parts := make([]string, len(stamped))
+	for i, col := range stamped {
+		parts[i] = trustedIdent(dialect, col) + " = " + now
+	}
+	return parts
+}
+
+// containsFold reports whether names contains name, ignoring case.
+func containsFold(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// BuildPartialUpdateQuery builds UPDATE table SET ... WHERE idCol=phN including only the non-zero fields of entity
+// (see isFieldZero); idColumn is excluded from SET. Returns the query and its args (SET values, then idVal),
+// or an empty query when entity has no non-zero fields.
+func BuildPartialUpdateQuery[T any](
+	table, idColumn string, dialect Dialect, entity *T, idVal any,
+) (updateQuery string, updateArgs []any) {
+	return buildPartialUpdateQuery(table, idColumn, dialect, entity, idVal, []string{idColumn}, nil)
+}
+
+// buildPartialUpdateQuery implements BuildPartialUpdateQuery, skipping the excluded columns and setting the
+// stamped ones to the dialect's current timestamp. Stamped columns alone do not make an update.
+func buildPartialUpdateQuery[T any](
+	table, idColumn string, dialect Dialect, entity *T, idVal any, excluded, stamped []string,
+) (updateQuery string, updateArgs []any) {
+	if dialect == nil {
+		dialect = DefaultDialect
+	}
+	if entity == nil {
+		return "", nil
+	}
+	val := reflect.ValueOf(entity).Elem()
+	var parts []string
+	var args []any
+	for _, c := range getOrderedColumns(val.Type()) {
+		field := fieldByIndex(val, c.Index)
+		if containsFold(excluded, c.Name) || containsFold(stamped, c.Name) || isFieldZero(field) {
+			continue
+		}
+		args = append(args, columnValueToAny(field, c))
+		parts = append(parts, trustedIdent(dialect, c.Name)+" = "+dialect.Placeholder(len(args)))
 	}
-	whereArgIdx := len(setCols) + 1
-	return "UPDATE " + table + " SET " + strings.Join(parts, ", ") + " WHERE " + idColumn + " = " + dialect.Placeholder(whereArgIdx)
+	if len(parts) == 0 {
+		return "", nil
+	}
+	parts = append(parts, stampAssignments(dialect, stamped)...)
+	args = append(args, idVal)
+	query := "UPDATE " + table + " SET " + strings.Join(parts, ", ") +
+		" WHERE " + trustedIdent(dialect, idColumn) + " = " + dialect.Placeholder(len(args))
+	return query, args
 }
 
 // ExtractUpdateValues returns values for UPDATE SET clause in column order (excluding id), then appends idVal.
@@ -359,17 +532,21 @@ func ExtractUpdateValues[T any](entity *T, idVal any, idColumn string) []any {
 	if entity == nil {
 		return nil
 	}
-	typ := reflect.TypeOf(entity).Elem()
-	cols := getOrderedColumns(typ)
-	idColLower := strings.ToLower(idColumn)
+	return append(updateValues(entity, []string{idColumn}), idVal)
+}
+
+// updateValues returns the values for the SET clause of buildUpdateQuery in column order, skipping excluded.
+func updateValues[T any](entity *T, excluded []string) []any {
+	if entity == nil {
+		return nil
+	}
 	val := reflect.ValueOf(entity).Elem()
 	var out []any
-	for _, c := range cols {
-		if strings.ToLower(c.Name) == idColLower {
+	for _, c := range getOrderedColumns(val.Type()) {
+		if containsFold(excluded, c.Name) {
 			continue
 		}
-		out = append(out, fieldValueToAny(val.Field(c.Index)))
+		out = append(out, columnValueToAny(fieldByIndex(val, c.Index), c))
 	}
-	out = append(out, idVal)
 	return out
 }
@@ -0,0 +1,149 @@
+package sql
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type patchEntity struct {
+	ID        int64      `db:"id"`
+	Name      string     `db:"name"`
+	Age       int        `db:"age"`
+	OwnerID   uuid.UUID  `db:"owner_id"`
+	DeletedAt *time.Time `db:"deleted_at"`
+}
+
+func TestBuildPartialUpdateQuery(t *testing.T) {
+	owner := uuid.New()
+
+	tests := []struct {
+		name     string
+		dialect  Dialect
+		entity   *patchEntity
+		want     string
+		wantArgs []any
+	}{
+		{
+			name:     "only non-zero fields",
+			entity:   &patchEntity{ID: 9, Name: "ann"},
+			want:     "UPDATE users SET name = $1 WHERE id = $2",
+			wantArgs: []any{"ann", int64(1)},
+		},
+		{
+			name:     "uuid converted",
+			dialect:  MySQL{},
+			entity:   &patchEntity{Age: 30, OwnerID: owner},
+			want:     "UPDATE users SET age = ?, owner_id = ? WHERE id = ?",
+			wantArgs: []any{30, owner.String(), int64(1)},
+		},
+		{name: "no non-zero fields", entity: &patchEntity{ID: 9}, want: "", wantArgs: nil},
+		{name: "nil entity", entity: nil, want: "", wantArgs: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, args := BuildPartialUpdateQuery("users", "id", tt.dialect, tt.entity, int64(1))
+			if got != tt.want {
+				t.Errorf("BuildPartialUpdateQuery() = %q, want %q", got, tt.want)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("BuildPartialUpdateQuery() args = %v, want %v", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+type auditBase struct {
+	ID        int64     `db:"id"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+type embeddedEntity struct {
+	auditBase
+	Name      string `db:"name"`
+	UpdatedAt string `db:"UPDATED_AT"` // shadows auditBase.UpdatedAt
+	Ignored   string
+}
+
+// AuditBase is exported so it can be embedded by pointer.
+type AuditBase struct {
+	ID        int64     `db:"id"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type pointerEmbeddedEntity struct {
+	*AuditBase
+	Name string `db:"name"`
+}
+
+type unexportedPointerEntity struct {
+	*auditBase        // cannot be allocated by reflection, so skipped
+	Name       string `db:"name"`
+}
+
+// TreeNode embeds itself by pointer.
+type TreeNode struct {
+	ID int64 `db:"id"`
+	*TreeNode
+}
+
+type recursiveEntity struct {
+	*TreeNode
+	Name string `db:"name"`
+}
+
+func TestGetOrderedColumns_Embedded(t *testing.T) {
+	tests := []struct {
+		name      string
+		typ       reflect.Type
+		wantNames []string
+		wantIndex [][]int
+	}{
+		{
+			name:      "embedded base flattened, outer field wins collision",
+			typ:       reflect.TypeOf(embeddedEntity{}),
+			wantNames: []string{"id", "created_at", "name", "UPDATED_AT"},
+			wantIndex: [][]int{{0, 0}, {0, 1}, {1}, {2}},
+		},
+		{
+			name:      "embedded pointer",
+			typ:       reflect.TypeOf(pointerEmbeddedEntity{}),
+			wantNames: []string{"id", "created_at", "name"},
+			wantIndex: [][]int{{0, 0}, {0, 1}, {1}},
+		},
+		{
+			name:      "unexported embedded pointer skipped",
+			typ:       reflect.TypeOf(unexportedPointerEntity{}),
+			wantNames: []string{"name"},
+			wantIndex: [][]int{{1}},
+		},
+		{
+			name:      "self-referencing embedding stops",
+			typ:       reflect.TypeOf(recursiveEntity{}),
+			wantNames: []string{"id", "name"},
+			wantIndex: [][]int{{0, 0}, {1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cols := getOrderedColumns(tt.typ)
+			var names []string
+			var indexes [][]int
+			for _, c := range cols {
+				names = append(names, c.Name)
+				indexes = append(indexes, c.Index)
+			}
+			if !reflect.DeepEqual(names, tt.wantNames) {
+				t.Errorf("getOrderedColumns() names = %v, want %v", names, tt.wantNames)
+			}
+			if !reflect.DeepEqual(indexes, tt.wantIndex) {
+				t.Errorf("getOrderedColumns() indexes = %v, want %v", indexes, tt.wantIndex)
+			}
+		})
+	}
+}
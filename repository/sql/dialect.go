@@ -1,6 +1,9 @@
 package sql
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // Dialect abstracts SQL dialect differences (placeholders, pagination, optional quoting).
 type Dialect interface {
@@ -11,11 +14,64 @@ type Dialect interface {
 	// PaginationClause returns the SQL fragment for LIMIT/OFFSET and the two args (limit, offset).
 	// Postgres/MySQL: "LIMIT ? OFFSET ?"; Oracle: "OFFSET ? ROWS FETCH NEXT ? ROWS ONLY"
 	PaginationClause(limitArgIndex, offsetArgIndex int) string
+
+	// ForUpdateClause returns the row-locking suffix for SELECT statements (e.g. "FOR UPDATE"),
+	// or "" when the database has no row-level locks.
+	ForUpdateClause() string
+}
+
+// ReturningDialect is an optional Dialect extension for databases that support INSERT ... RETURNING.
+// CreateMany uses it to write generated IDs back to a batch of entities.
+type ReturningDialect interface {
+	SupportsReturning() bool
+}
+
+// ILikeDialect is an optional Dialect extension for databases with a native case-insensitive LIKE.
+// Dialects without it get LOWER(column) LIKE LOWER(placeholder) for the "ilike" filter operator.
+type ILikeDialect interface {
+	ILikeClause(column, placeholder string) string
+}
+
+// TimestampDialect is an optional Dialect extension returning the SQL expression for the current
+// timestamp, used by WithTimestamps. Dialects without it get CURRENT_TIMESTAMP, which PostgreSQL,
+// MySQL, SQLite and Oracle all support.
+type TimestampDialect interface {
+	CurrentTimestamp() string
+}
+
+// WindowCountDialect is an optional Dialect extension for databases that support COUNT(*) OVER(),
+// used by WithWindowCount. Dialects without it keep the separate count query.
+type WindowCountDialect interface {
+	SupportsWindowCount() bool
+}
+
+// supportsWindowCount reports whether dialect implements WindowCountDialect and supports it.
+func supportsWindowCount(dialect Dialect) bool {
+	d, ok := dialect.(WindowCountDialect)
+	return ok && d.SupportsWindowCount()
+}
+
+// currentTimestamp returns the current timestamp expression of dialect.
+func currentTimestamp(dialect Dialect) string {
+	if d, ok := dialect.(TimestampDialect); ok {
+		return d.CurrentTimestamp()
+	}
+	return "CURRENT_TIMESTAMP"
 }
 
 // Postgres dialect (placeholder $1, $2, ...).
 type Postgres struct{}
 
+// SupportsReturning implements ReturningDialect.
+func (Postgres) SupportsReturning() bool {
+	return true
+}
+
+// SupportsWindowCount implements WindowCountDialect.
+func (Postgres) SupportsWindowCount() bool {
+	return true
+}
+
 func (Postgres) Placeholder(index int) string {
 	return fmt.Sprintf("$%d", index)
 }
@@ -24,6 +80,20 @@ func (Postgres) PaginationClause(limitArgIndex, offsetArgIndex int) string {
 	return fmt.Sprintf("LIMIT %s OFFSET %s", fmt.Sprintf("$%d", limitArgIndex), fmt.Sprintf("$%d", offsetArgIndex))
 }
 
+func (Postgres) ForUpdateClause() string {
+	return "FOR UPDATE"
+}
+
+// ILikeClause implements ILikeDialect.
+func (Postgres) ILikeClause(column, placeholder string) string {
+	return column + " ILIKE " + placeholder
+}
+
+// QuoteIdentifier implements IdentifierQuoter.
+func (Postgres) QuoteIdentifier(name string) string {
+	return doubleQuote(name)
+}
+
 // MySQL dialect (placeholder ?).
 type MySQL struct{}
 
@@ -35,6 +105,35 @@ func (MySQL) PaginationClause(limitArgIndex, offsetArgIndex int) string {
 	return "LIMIT ? OFFSET ?"
 }
 
+func (MySQL) ForUpdateClause() string {
+	return "FOR UPDATE"
+}
+
+// QuoteIdentifier implements IdentifierQuoter.
+func (MySQL) QuoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+// SQLite dialect (placeholder ?). SQLite locks the whole database for writes, so it has no FOR UPDATE clause.
+type SQLite struct{}
+
+func (SQLite) Placeholder(index int) string {
+	return "?"
+}
+
+func (SQLite) PaginationClause(limitArgIndex, offsetArgIndex int) string {
+	return "LIMIT ? OFFSET ?"
+}
+
+func (SQLite) ForUpdateClause() string {
+	return ""
+}
+
+// QuoteIdentifier implements IdentifierQuoter.
+func (SQLite) QuoteIdentifier(name string) string {
+	return doubleQuote(name)
+}
+
 // Oracle dialect (placeholder :1, :2, ...). Pagination uses OFFSET/FETCH (12c+).
 type Oracle struct{}
 
@@ -48,5 +147,19 @@ func (Oracle) PaginationClause(limitArgIndex, offsetArgIndex int) string {
 	return fmt.Sprintf("OFFSET %s ROWS FETCH NEXT %s ROWS ONLY", fmt.Sprintf(":%d", offsetArgIndex), fmt.Sprintf(":%d", limitArgIndex))
 }
 
+func (Oracle) ForUpdateClause() string {
+	return "FOR UPDATE"
+}
+
+// QuoteIdentifier implements IdentifierQuoter. Note that quoted Oracle identifiers are case-sensitive.
+func (Oracle) QuoteIdentifier(name string) string {
+	return doubleQuote(name)
+}
+
+// doubleQuote quotes name with ANSI double quotes, doubling embedded quotes.
+func doubleQuote(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
 // DefaultDialect is used when no dialect is set (Postgres for backward compatibility).
 var DefaultDialect Dialect = Postgres{}
@@ -0,0 +1,163 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/biairmal/go-sdk/sqlkit"
+)
+
+// fakeStatement is one statement received by fakeBackend.
+type fakeStatement struct {
+	Query string
+	Args  []any
+	Conn  int // Number of the driver connection that ran it, from 1
+}
+
+// fakeBackend is an in-memory database/sql driver used by repository tests.
+// It records every statement (including BEGIN/COMMIT/ROLLBACK) and answers queries from queryFn.
+type fakeBackend struct {
+	mu           sync.Mutex
+	statements   []fakeStatement
+	rowsAffected int64
+	execErr      error // Returned by every Exec when set
+	conns        int
+	queryFn      func(query string) (columns []string, rows [][]driver.Value)
+}
+
+// newFakeRepoDB returns a sqlkit.DB whose leader is backed by a new fakeBackend.
+func newFakeRepoDB(t *testing.T) (*fakeBackend, *sqlkit.DB) {
+	t.Helper()
+	backend := &fakeBackend{rowsAffected: 1}
+	conn := sql.OpenDB(backend)
+	db, err := sqlkit.NewWithConns(context.Background(), conn, nil, nil)
+	if err != nil {
+		t.Fatalf("NewWithConns() error = %v", err)
+	}
+	t.Cleanup(func() {
+		_ = db.Close()
+		_ = conn.Close()
+	})
+	return backend, db
+}
+
+// Statements returns the statements executed so far.
+func (b *fakeBackend) Statements() []fakeStatement {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]fakeStatement(nil), b.statements...)
+}
+
+// Last returns the most recent statement.
+func (b *fakeBackend) Last() fakeStatement {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.statements) == 0 {
+		return fakeStatement{}
+	}
+	return b.statements[len(b.statements)-1]
+}
+
+func (b *fakeBackend) record(conn int, query string, args []driver.NamedValue) {
+	values := make([]any, len(args))
+	for i, a := range args {
+		values[i] = a.Value
+	}
+	b.mu.Lock()
+	b.statements = append(b.statements, fakeStatement{Query: query, Args: values, Conn: conn})
+	b.mu.Unlock()
+}
+
+// Connect implements driver.Connector.
+func (b *fakeBackend) Connect(context.Context) (driver.Conn, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.conns++
+	return &fakeConn{backend: b, id: b.conns}, nil
+}
+
+// Driver implements driver.Connector.
+func (b *fakeBackend) Driver() driver.Driver {
+	return fakeDriver{}
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("fake: use the connector")
+}
+
+type fakeConn struct {
+	backend *fakeBackend
+	id      int
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("fake: prepare not supported")
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *fakeConn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	c.backend.record(c.id, "BEGIN", nil)
+	return fakeTx{conn: c}, nil
+}
+
+type fakeTx struct {
+	conn *fakeConn
+}
+
+func (tx fakeTx) Commit() error {
+	tx.conn.backend.record(tx.conn.id, "COMMIT", nil)
+	return nil
+}
+
+func (tx fakeTx) Rollback() error {
+	tx.conn.backend.record(tx.conn.id, "ROLLBACK", nil)
+	return nil
+}
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.backend.record(c.id, query, args)
+	if c.backend.execErr != nil {
+		return nil, c.backend.execErr
+	}
+	return driver.RowsAffected(c.backend.rowsAffected), nil
+}
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.backend.record(c.id, query, args)
+	rows := &fakeRows{}
+	if c.backend.queryFn != nil {
+		rows.columns, rows.values = c.backend.queryFn(query)
+	}
+	return rows, nil
+}
+
+type fakeRows struct {
+	columns []string
+	values  [][]driver.Value
+	pos     int
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+
+func (r *fakeRows) Close() error { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.values) {
+		return io.EOF
+	}
+	copy(dest, r.values[r.pos])
+	r.pos++
+	return nil
+}
@@ -1,91 +1,158 @@
 package sql
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/biairmal/go-sdk/repository"
 	"github.com/biairmal/go-sdk/sqlkit"
 )
 
-// Supported filter operators (whitelist for safety).
-var supportedOps = map[string]bool{
-	"eq": true, "ne": true, "gt": true, "gte": true, "lt": true, "lte": true,
-	"like": true, "in": true, "is_null": true, "is_not_null": true,
-}
-
 // BuildWhereClause builds WHERE clause from filter using the given dialect for placeholders.
+// Conditions and Groups are combined with AND; each group is rendered in parentheses with its
+// own combinator. Placeholders are numbered sequentially from 1 in the order they appear;
+// use BuildWhereClauseFrom to embed the clause in a query that already has args.
 func BuildWhereClause(dialect Dialect, filter repository.Filter) (whereClause string, whereArgs []any) {
+	whereClause, whereArgs, _ = BuildWhereClauseFrom(dialect, filter, 1)
+	return whereClause, whereArgs
+}
+
+// BuildWhereClauseFrom is like BuildWhereClause but numbers the placeholders from startIndex,
+// so the clause can follow the args of a hand-written query (startIndex = len(args)+1).
+// nextIndex is the first placeholder index left free, to pass to the next builder, e.g.
+// BuildPaginationClauseFrom. Without conditions it returns "", nil and startIndex.
+func BuildWhereClauseFrom(
+	dialect Dialect, filter repository.Filter, startIndex int,
+) (whereClause string, whereArgs []any, nextIndex int) {
 	if dialect == nil {
 		dialect = DefaultDialect
 	}
-	var conditions []string
-	var args []any
-	argIdx := 1
+	w := &whereBuilder{dialect: dialect, argIdx: startIndex}
+	root := repository.FilterGroup{
+		Combinator: repository.FilterAnd,
+		Conditions: filter.Conditions,
+		Groups:     filter.Groups,
+	}
+	parts := w.groupParts(root)
+	if len(parts) == 0 {
+		return "", nil, startIndex
+	}
+	return "WHERE " + strings.Join(parts, " AND "), w.args, w.argIdx
+}
 
-	for _, c := range filter.Conditions {
-		field := SanitizeColumnName(c.Field)
-		if field == "" {
-			continue
+// whereBuilder renders filter conditions while tracking placeholder indices and args.
+type whereBuilder struct {
+	dialect Dialect
+	args    []any
+	argIdx  int
+}
+
+// groupParts renders the conditions of g, then its nested groups, skipping invalid or empty ones.
+func (w *whereBuilder) groupParts(g repository.FilterGroup) []string {
+	var parts []string
+	for _, c := range g.Conditions {
+		if cond := w.condition(c); cond != "" {
+			parts = append(parts, cond)
 		}
-		op := strings.ToLower(string(c.Operator))
-		if !supportedOps[op] {
-			continue
+	}
+	for _, sub := range g.Groups {
+		subParts := w.groupParts(sub)
+		if len(subParts) == 1 {
+			parts = append(parts, subParts[0])
+		} else if len(subParts) > 1 {
+			parts = append(parts, "("+strings.Join(subParts, " "+groupCombinator(sub)+" ")+")")
 		}
-		switch op {
-		case "eq":
-			conditions = append(conditions, field+" = "+dialect.Placeholder(argIdx))
-			args = append(args, c.Value)
-			argIdx++
-		case "ne":
-			conditions = append(conditions, field+" <> "+dialect.Placeholder(argIdx))
-			args = append(args, c.Value)
-			argIdx++
-		case "gt":
-			conditions = append(conditions, field+" > "+dialect.Placeholder(argIdx))
-			args = append(args, c.Value)
-			argIdx++
-		case "gte":
-			conditions = append(conditions, field+" >= "+dialect.Placeholder(argIdx))
-			args = append(args, c.Value)
-			argIdx++
-		case "lt":
-			conditions = append(conditions, field+" < "+dialect.Placeholder(argIdx))
-			args = append(args, c.Value)
-			argIdx++
-		case "lte":
-			conditions = append(conditions, field+" <= "+dialect.Placeholder(argIdx))
-			args = append(args, c.Value)
-			argIdx++
-		case "like":
-			conditions = append(conditions, field+" LIKE "+dialect.Placeholder(argIdx))
-			args = append(args, c.Value)
-			argIdx++
-		case "in":
-			if len(c.Values) == 0 {
-				continue
-			}
-			placeholders := make([]string, len(c.Values))
-			for i := range c.Values {
-				placeholders[i] = dialect.Placeholder(argIdx)
-				argIdx++
-			}
-			args = append(args, c.Values...)
-			conditions = append(conditions, field+" IN ("+strings.Join(placeholders, ", ")+")")
-		case "is_null":
-			conditions = append(conditions, field+" IS NULL")
-		case "is_not_null":
-			conditions = append(conditions, field+" IS NOT NULL")
+	}
+	return parts
+}
+
+// groupCombinator returns the SQL keyword for g's combinator; anything other than OR means AND.
+func groupCombinator(g repository.FilterGroup) string {
+	if strings.EqualFold(string(g.Combinator), string(repository.FilterOr)) {
+		return "OR"
+	}
+	return "AND"
+}
+
+// Comparison operators that take a single value, keyed by filter operator.
+var comparisonOps = map[string]string{
+	"eq": "=", "ne": "<>", "gt": ">", "gte": ">=", "lt": "<", "lte": "<=", "like": "LIKE",
+}
+
+// condition renders one condition, or returns "" when the field or operator is not allowed.
+func (w *whereBuilder) condition(c repository.FilterCondition) string {
+	field, ok := columnIdent(w.dialect, SanitizeColumnName(c.Field))
+	if field == "" || !ok {
+		return ""
+	}
+	op := strings.ToLower(string(c.Operator))
+	if !repository.FilterOperator(op).Valid() {
+		return ""
+	}
+	switch op {
+	case "in":
+		return w.inList(field, "IN", c.Values)
+	case "not_in":
+		return w.inList(field, "NOT IN", c.Values)
+	case "between":
+		if len(c.Values) != 2 {
+			return ""
 		}
+		cond := field + " BETWEEN " + w.placeholder() + " AND " + w.placeholder()
+		w.args = append(w.args, c.Values...)
+		return cond
+	case "ilike":
+		cond := iLikeCondition(w.dialect, field, w.placeholder())
+		w.args = append(w.args, c.Value)
+		return cond
+	case "is_null":
+		return field + " IS NULL"
+	case "is_not_null":
+		return field + " IS NOT NULL"
+	default:
+		cond := field + " " + comparisonOps[op] + " " + w.placeholder()
+		w.args = append(w.args, c.Value)
+		return cond
 	}
+}
 
-	if len(conditions) == 0 {
-		return "", nil
+// inList renders "field IN (...)" or "field NOT IN (...)", or "" when values is empty.
+func (w *whereBuilder) inList(field, keyword string, values []any) string {
+	if len(values) == 0 {
+		return ""
 	}
-	return "WHERE " + strings.Join(conditions, " AND "), args
+	placeholders := make([]string, len(values))
+	for i := range values {
+		placeholders[i] = w.placeholder()
+	}
+	w.args = append(w.args, values...)
+	return field + " " + keyword + " (" + strings.Join(placeholders, ", ") + ")"
+}
+
+// iLikeCondition renders a case-insensitive LIKE using the dialect's ILikeDialect support,
+// falling back to LOWER(field) LIKE LOWER(placeholder).
+func iLikeCondition(dialect Dialect, field, placeholder string) string {
+	if d, ok := dialect.(ILikeDialect); ok {
+		return d.ILikeClause(field, placeholder)
+	}
+	return "LOWER(" + field + ") LIKE LOWER(" + placeholder + ")"
+}
+
+// placeholder returns the next placeholder and advances the index.
+func (w *whereBuilder) placeholder() string {
+	p := w.dialect.Placeholder(w.argIdx)
+	w.argIdx++
+	return p
 }
 
 // BuildOrderByClause builds ORDER BY clause from multiple sorts.
 func BuildOrderByClause(sorts []repository.Sort) string {
+	return BuildOrderByClauseFor(nil, sorts)
+}
+
+// BuildOrderByClauseFor is like BuildOrderByClause but quotes columns when dialect
+// was wrapped with QuoteIdentifiers. A nil dialect never quotes.
+func BuildOrderByClauseFor(dialect Dialect, sorts []repository.Sort) string {
 	if len(sorts) == 0 {
 		return ""
 	}
@@ -94,8 +161,8 @@ func BuildOrderByClause(sorts []repository.Sort) string {
 		if s.Field == "" {
 			continue
 		}
-		field := SanitizeColumnName(s.Field)
-		if field == "" {
+		field, ok := columnIdent(dialect, SanitizeColumnName(s.Field))
+		if field == "" || !ok {
 			continue
 		}
 		dir := string(s.Direction)
@@ -111,10 +178,29 @@ func BuildOrderByClause(sorts []repository.Sort) string {
 }
 
 // BuildPaginationClause returns the pagination SQL fragment and args [limit, offset] using dialect.
+// Placeholders are numbered 1 and 2, so use it only for queries without other args;
+// otherwise use BuildPaginationClauseFrom.
 func BuildPaginationClause(dialect Dialect, pagination repository.Pagination) (clause string, args []any) {
+	return BuildPaginationClauseFrom(dialect, pagination, 1)
+}
+
+// BuildPaginationClauseFrom is like BuildPaginationClause but numbers the limit and offset placeholders
+// from firstArgIdx, so they follow the args of a preceding WHERE clause (the nextIndex returned by
+// BuildWhereClauseFrom). It always uses two placeholders, so the next free index is firstArgIdx+2.
+func BuildPaginationClauseFrom(
+	dialect Dialect, pagination repository.Pagination, firstArgIdx int,
+) (clause string, args []any) {
 	if dialect == nil {
 		dialect = DefaultDialect
 	}
+	pagination = normalizePagination(pagination)
+	clause = dialect.PaginationClause(firstArgIdx, firstArgIdx+1)
+	args = []any{pagination.Limit, pagination.Offset}
+	return clause, args
+}
+
+// normalizePagination applies the default (20) and maximum (100) limit and clamps a negative offset to 0.
+func normalizePagination(pagination repository.Pagination) repository.Pagination {
 	if pagination.Limit <= 0 {
 		pagination.Limit = 20
 	}
@@ -124,9 +210,7 @@ func BuildPaginationClause(dialect Dialect, pagination repository.Pagination) (c
 	if pagination.Offset < 0 {
 		pagination.Offset = 0
 	}
-	clause = dialect.PaginationClause(1, 2)
-	args = []any{pagination.Limit, pagination.Offset}
-	return clause, args
+	return pagination
 }
 
 // SanitizeColumnName validates and sanitizes column names.
@@ -139,13 +223,26 @@ func SanitizeColumnName(column string) string {
 }
 
 // ConvertSQLError converts database-specific errors to repository errors.
+// Uses sqlkit.ClassifyError to recognize errors from any supported driver:
+// no rows -> ErrNotFound; unique violation -> ErrAlreadyExists; foreign key
+// violation, deadlock or serialization failure -> ErrConflict; not-null or check
+// violation -> ErrInvalidEntity.
+// Except for ErrNotFound, the driver error stays in the chain. Other errors are
+// returned unchanged.
 func ConvertSQLError(err error) error {
 	if err == nil {
 		return nil
 	}
-	if sqlkit.IsNoRows(err) {
+	switch sqlkit.ClassifyError("", err) {
+	case sqlkit.ClassNoRows:
 		return repository.ErrNotFound
+	case sqlkit.ClassUniqueViolation:
+		return fmt.Errorf("%w: %w", repository.ErrAlreadyExists, err)
+	case sqlkit.ClassForeignKeyViolation, sqlkit.ClassDeadlock, sqlkit.ClassSerializationFailure:
+		return fmt.Errorf("%w: %w", repository.ErrConflict, err)
+	case sqlkit.ClassNotNullViolation, sqlkit.ClassCheckViolation:
+		return fmt.Errorf("%w: %w", repository.ErrInvalidEntity, err)
+	default:
+		return err
 	}
-	// TODO: map MySQL 1062, Oracle ORA-00001 to ErrAlreadyExists
-	return err
 }
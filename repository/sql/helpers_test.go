@@ -0,0 +1,310 @@
+package sql
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/biairmal/go-sdk/repository"
+)
+
+// pgError mimics lib/pq and pgconn errors, which expose SQLState().
+type pgError struct{ code string }
+
+func (e *pgError) Error() string    { return "pq: " + e.code }
+func (e *pgError) SQLState() string { return e.code }
+
+// mysqlError mimics go-sql-driver/mysql MySQLError.
+type mysqlError struct{ Number uint16 }
+
+func (e *mysqlError) Error() string { return "mysql error" }
+
+func TestConvertSQLError(t *testing.T) {
+	plain := errors.New("boom")
+
+	tests := []struct {
+		name      string
+		err       error
+		want      error
+		keepCause bool
+	}{
+		{name: "nil", err: nil, want: nil},
+		{name: "no rows", err: sql.ErrNoRows, want: repository.ErrNotFound},
+		{name: "postgres unique", err: &pgError{code: "23505"}, want: repository.ErrAlreadyExists, keepCause: true},
+		{name: "mysql duplicate", err: &mysqlError{Number: 1062}, want: repository.ErrAlreadyExists, keepCause: true},
+		{
+			name: "oracle unique", err: errors.New("ORA-00001: unique constraint violated"),
+			want: repository.ErrAlreadyExists, keepCause: true,
+		},
+		{name: "foreign key", err: &pgError{code: "23503"}, want: repository.ErrConflict, keepCause: true},
+		{name: "deadlock", err: &mysqlError{Number: 1213}, want: repository.ErrConflict, keepCause: true},
+		{name: "serialization failure", err: &pgError{code: "40001"}, want: repository.ErrConflict, keepCause: true},
+		{
+			name: "sqlite not null", err: errors.New("NOT NULL constraint failed: users.name"),
+			want: repository.ErrInvalidEntity, keepCause: true,
+		},
+		{name: "postgres check", err: &pgError{code: "23514"}, want: repository.ErrInvalidEntity, keepCause: true},
+		{name: "mysql check", err: &mysqlError{Number: 3819}, want: repository.ErrInvalidEntity, keepCause: true},
+		{name: "unknown unchanged", err: plain, want: plain},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ConvertSQLError(tt.err)
+			if !errors.Is(got, tt.want) || (tt.want == nil && got != nil) {
+				t.Errorf("ConvertSQLError() = %v, want %v", got, tt.want)
+			}
+			if tt.keepCause && !errors.Is(got, tt.err) {
+				t.Errorf("ConvertSQLError() = %v, want driver error %v in chain", got, tt.err)
+			}
+		})
+	}
+}
+
+func TestBuildWhereClause(t *testing.T) {
+	eq := func(field string, v any) repository.FilterCondition {
+		return repository.FilterCondition{Field: field, Operator: repository.FilterOperatorEq, Value: v}
+	}
+
+	tests := []struct {
+		name     string
+		dialect  Dialect
+		filter   repository.Filter
+		want     string
+		wantArgs []any
+	}{
+		{name: "empty", filter: repository.Filter{}, want: "", wantArgs: nil},
+		{
+			name: "flat conditions",
+			filter: repository.Filter{Conditions: []repository.FilterCondition{
+				eq("a", 1),
+				{Field: "b", Operator: repository.FilterOperatorIn, Values: []any{2, 3}},
+				{Field: "c", Operator: repository.FilterOperatorIsNull},
+			}},
+			want:     "WHERE a = $1 AND b IN ($2, $3) AND c IS NULL",
+			wantArgs: []any{1, 2, 3},
+		},
+		{
+			name: "or group and condition",
+			filter: repository.Filter{
+				Conditions: []repository.FilterCondition{eq("c", 3)},
+				Groups: []repository.FilterGroup{{
+					Combinator: repository.FilterOr,
+					Conditions: []repository.FilterCondition{eq("a", 1), eq("b", 2)},
+				}},
+			},
+			want:     "WHERE c = $1 AND (a = $2 OR b = $3)",
+			wantArgs: []any{3, 1, 2},
+		},
+		{
+			name: "nested groups",
+			filter: repository.Filter{Groups: []repository.FilterGroup{{
+				Combinator: repository.FilterOr,
+				Conditions: []repository.FilterCondition{eq("a", 1)},
+				Groups: []repository.FilterGroup{{
+					Conditions: []repository.FilterCondition{
+						eq("b", 2),
+						{Field: "c", Operator: repository.FilterOperatorGt, Value: 3},
+					},
+				}},
+			}}},
+			want:     "WHERE (a = $1 OR (b = $2 AND c > $3))",
+			wantArgs: []any{1, 2, 3},
+		},
+		{
+			name: "single-member and empty groups are flattened",
+			filter: repository.Filter{Groups: []repository.FilterGroup{
+				{Combinator: repository.FilterOr, Conditions: []repository.FilterCondition{eq("a", 1)}},
+				{Combinator: repository.FilterOr},
+				{Conditions: []repository.FilterCondition{{Field: "b;", Operator: repository.FilterOperatorEq}}},
+			}},
+			want:     "WHERE a = $1",
+			wantArgs: []any{1},
+		},
+		{
+			name:    "mysql placeholders",
+			dialect: MySQL{},
+			filter: repository.Filter{Groups: []repository.FilterGroup{{
+				Combinator: repository.FilterOr,
+				Conditions: []repository.FilterCondition{eq("a", 1), eq("b", 2)},
+			}}},
+			want:     "WHERE (a = ? OR b = ?)",
+			wantArgs: []any{1, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, args := BuildWhereClause(tt.dialect, tt.filter)
+			if got != tt.want {
+				t.Errorf("BuildWhereClause() = %q, want %q", got, tt.want)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("BuildWhereClause() args = %v, want %v", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestBuildWhereClauseFrom(t *testing.T) {
+	filter := repository.Filter{Conditions: []repository.FilterCondition{
+		{Field: "status", Operator: repository.FilterOperatorEq, Value: "paid"},
+		{Field: "region", Operator: repository.FilterOperatorIn, Values: []any{"eu", "us"}},
+	}}
+
+	tests := []struct {
+		name     string
+		dialect  Dialect
+		filter   repository.Filter
+		start    int
+		want     string
+		wantArgs []any
+		wantNext int
+	}{
+		{
+			name: "postgres from 3", dialect: Postgres{}, filter: filter, start: 3,
+			want: "WHERE status = $3 AND region IN ($4, $5)", wantArgs: []any{"paid", "eu", "us"}, wantNext: 6,
+		},
+		{
+			name: "oracle from 1", dialect: Oracle{}, filter: filter, start: 1,
+			want: "WHERE status = :1 AND region IN (:2, :3)", wantArgs: []any{"paid", "eu", "us"}, wantNext: 4,
+		},
+		{
+			name: "mysql from 2", dialect: MySQL{}, filter: filter, start: 2,
+			want: "WHERE status = ? AND region IN (?, ?)", wantArgs: []any{"paid", "eu", "us"}, wantNext: 5,
+		},
+		{
+			name: "empty filter keeps index", dialect: Postgres{}, start: 4, wantNext: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, args, next := BuildWhereClauseFrom(tt.dialect, tt.filter, tt.start)
+			if got != tt.want {
+				t.Errorf("BuildWhereClauseFrom() = %q, want %q", got, tt.want)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("BuildWhereClauseFrom() args = %v, want %v", args, tt.wantArgs)
+			}
+			if next != tt.wantNext {
+				t.Errorf("BuildWhereClauseFrom() next = %d, want %d", next, tt.wantNext)
+			}
+		})
+	}
+}
+
+func TestBuildWhereClauseFrom_composesWithPagination(t *testing.T) {
+	filter := repository.Filter{Conditions: []repository.FilterCondition{
+		{Field: "status", Operator: repository.FilterOperatorEq, Value: "paid"},
+	}}
+	where, whereArgs, next := BuildWhereClauseFrom(Postgres{}, filter, 2)
+	page, pageArgs := BuildPaginationClauseFrom(Postgres{}, repository.Pagination{Limit: 10}, next)
+
+	if got, want := where+" "+page, "WHERE status = $2 LIMIT $3 OFFSET $4"; got != want {
+		t.Errorf("composed clause = %q, want %q", got, want)
+	}
+	if got := append(whereArgs, pageArgs...); !reflect.DeepEqual(got, []any{"paid", 10, 0}) {
+		t.Errorf("composed args = %v, want [paid 10 0]", got)
+	}
+}
+
+func TestBuildPaginationClauseFrom(t *testing.T) {
+	tests := []struct {
+		name       string
+		dialect    Dialect
+		pagination repository.Pagination
+		firstIdx   int
+		want       string
+		wantArgs   []any
+	}{
+		{
+			name: "postgres after where args", dialect: Postgres{}, firstIdx: 3,
+			pagination: repository.Pagination{Limit: 10, Offset: 5},
+			want:       "LIMIT $3 OFFSET $4", wantArgs: []any{10, 5},
+		},
+		{
+			name: "oracle after where args", dialect: Oracle{}, firstIdx: 2,
+			pagination: repository.Pagination{Limit: 500, Offset: -1},
+			want:       "OFFSET :3 ROWS FETCH NEXT :2 ROWS ONLY", wantArgs: []any{100, 0},
+		},
+		{
+			name: "mysql defaults", dialect: MySQL{}, firstIdx: 4,
+			want: "LIMIT ? OFFSET ?", wantArgs: []any{20, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, args := BuildPaginationClauseFrom(tt.dialect, tt.pagination, tt.firstIdx)
+			if got != tt.want {
+				t.Errorf("BuildPaginationClauseFrom() = %q, want %q", got, tt.want)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("BuildPaginationClauseFrom() args = %v, want %v", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestBuildWhereClause_RangeAndCaseInsensitiveOperators(t *testing.T) {
+	filter := repository.Filter{Conditions: []repository.FilterCondition{
+		{Field: "a", Operator: repository.FilterOperatorEq, Value: 1},
+		{Field: "age", Operator: repository.FilterOperatorBetween, Values: []any{18, 65}},
+		{Field: "status", Operator: repository.FilterOperatorNotIn, Values: []any{"x", "y"}},
+		{Field: "name", Operator: repository.FilterOperatorILike, Value: "%ann%"},
+	}}
+	wantArgs := []any{1, 18, 65, "x", "y", "%ann%"}
+
+	tests := []struct {
+		name    string
+		dialect Dialect
+		want    string
+	}{
+		{
+			name: "postgres", dialect: Postgres{},
+			want: "WHERE a = $1 AND age BETWEEN $2 AND $3 AND status NOT IN ($4, $5) AND name ILIKE $6",
+		},
+		{
+			name: "mysql", dialect: MySQL{},
+			want: "WHERE a = ? AND age BETWEEN ? AND ? AND status NOT IN (?, ?) AND LOWER(name) LIKE LOWER(?)",
+		},
+		{
+			name: "sqlite", dialect: SQLite{},
+			want: "WHERE a = ? AND age BETWEEN ? AND ? AND status NOT IN (?, ?) AND LOWER(name) LIKE LOWER(?)",
+		},
+		{
+			name: "oracle", dialect: Oracle{},
+			want: "WHERE a = :1 AND age BETWEEN :2 AND :3 AND status NOT IN (:4, :5) AND LOWER(name) LIKE LOWER(:6)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, args := BuildWhereClause(tt.dialect, filter)
+			if got != tt.want {
+				t.Errorf("BuildWhereClause() = %q, want %q", got, tt.want)
+			}
+			if !reflect.DeepEqual(args, wantArgs) {
+				t.Errorf("BuildWhereClause() args = %v, want %v", args, wantArgs)
+			}
+		})
+	}
+}
+
+func TestBuildWhereClause_SkipsInvalidRangeOperators(t *testing.T) {
+	filter := repository.Filter{Conditions: []repository.FilterCondition{
+		{Field: "age", Operator: repository.FilterOperatorBetween, Values: []any{18}},
+		{Field: "status", Operator: repository.FilterOperatorNotIn},
+		{Field: "a", Operator: "regexp", Value: ".*"},
+		{Field: "b", Operator: repository.FilterOperatorEq, Value: 2},
+	}}
+	got, args := BuildWhereClause(Postgres{}, filter)
+	if want := "WHERE b = $1"; got != want {
+		t.Errorf("BuildWhereClause() = %q, want %q", got, want)
+	}
+	if !reflect.DeepEqual(args, []any{2}) {
+		t.Errorf("BuildWhereClause() args = %v, want [2]", args)
+	}
+}
@@ -0,0 +1,52 @@
+package sql
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// jsonValue marshals a field tagged `db:"name,json"` to a JSON string when it is bound as a query arg.
+// A string is used rather than []byte so that drivers do not send the value as bytea/BLOB.
+type jsonValue struct {
+	v any
+}
+
+// Value implements driver.Valuer.
+func (j jsonValue) Value() (driver.Value, error) {
+	b, err := json.Marshal(j.v)
+	if err != nil {
+		return nil, fmt.Errorf("repository: marshal json column: %w", err)
+	}
+	return string(b), nil
+}
+
+// columnValueToAny converts the field for column c to a query arg.
+// JSON columns are marshaled (nil pointers, maps and slices become NULL); others use fieldValueToAny.
+func columnValueToAny(v reflect.Value, c orderedColumn) any {
+	if !c.JSON {
+		return fieldValueToAny(v)
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+	}
+	return jsonValue{v: v.Interface()}
+}
+
+// setJSONFields unmarshals the raw JSON scanned for each JSON column into its target field.
+// Columns without a target are skipped; NULL columns (nil raw) leave the field at its zero value.
+func setJSONFields(columns []string, raws [][]byte, targets []reflect.Value) error {
+	for i, target := range targets {
+		if !target.IsValid() || raws[i] == nil {
+			continue
+		}
+		if err := json.Unmarshal(raws[i], target.Addr().Interface()); err != nil {
+			return fmt.Errorf("repository: scan json column %s: %w", columns[i], err)
+		}
+	}
+	return nil
+}
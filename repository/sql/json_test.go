@@ -0,0 +1,84 @@
+package sql
+
+import (
+	"context"
+	"database/sql/driver"
+	"reflect"
+	"testing"
+)
+
+type jsonSettings struct {
+	Theme string `json:"theme"`
+}
+
+type jsonEntity struct {
+	ID       int64          `db:"id"`
+	Payload  map[string]any `db:"payload,json"`
+	Settings *jsonSettings  `db:"settings, json"`
+	Tags     []string       `db:"tags,json"`
+}
+
+func TestColumnValueToAny_JSON(t *testing.T) {
+	cols := getOrderedColumns(reflect.TypeOf(jsonEntity{}))
+	entity := jsonEntity{ID: 1, Payload: map[string]any{"a": 1}}
+	val := reflect.ValueOf(entity)
+
+	tests := []struct {
+		name string
+		col  orderedColumn
+		want driver.Value
+	}{
+		{name: "plain column", col: cols[0], want: int64(1)},
+		{name: "map marshaled", col: cols[1], want: `{"a":1}`},
+		{name: "nil pointer is NULL", col: cols[2], want: nil},
+		{name: "nil slice is NULL", col: cols[3], want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := columnValueToAny(val.FieldByIndex(tt.col.Index), tt.col)
+			if valuer, ok := got.(driver.Valuer); ok {
+				var err error
+				if got, err = valuer.Value(); err != nil {
+					t.Fatalf("Value() error = %v", err)
+				}
+			}
+			if got != tt.want {
+				t.Errorf("columnValueToAny() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSQLRepository_JSONColumns(t *testing.T) {
+	backend, db := newFakeRepoDB(t)
+	repo := NewSQLRepository[jsonEntity, int64](nil, db, "docs")
+	backend.queryFn = func(string) ([]string, [][]driver.Value) {
+		return []string{"id", "payload", "settings", "tags"},
+			[][]driver.Value{{int64(1), []byte(`{"a":"b"}`), `{"theme":"dark"}`, nil}}
+	}
+
+	got, err := repo.GetByID(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	want := &jsonEntity{ID: 1, Payload: map[string]any{"a": "b"}, Settings: &jsonSettings{Theme: "dark"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetByID() = %+v, want %+v", got, want)
+	}
+
+	entity := &jsonEntity{ID: 2, Tags: []string{"x"}}
+	if err := repo.Create(context.Background(), entity); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if args, want := backend.Last().Args, []any{int64(2), nil, nil, `["x"]`}; !reflect.DeepEqual(args, want) {
+		t.Errorf("Create() args = %v, want %v", args, want)
+	}
+
+	backend.queryFn = func(string) ([]string, [][]driver.Value) {
+		return []string{"id", "payload"}, [][]driver.Value{{int64(1), "not json"}}
+	}
+	if _, err := repo.GetByID(context.Background(), 1); err == nil {
+		t.Error("GetByID() with invalid JSON error = nil, want error")
+	}
+}
@@ -0,0 +1,116 @@
+package sql
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/biairmal/go-sdk/repository"
+)
+
+// keysetSorts returns the sanitized sorts used for keyset pagination, with the ID column appended
+// (ascending) as a tie-breaker when it is not already sorted on. Returns nil when no valid sort is given.
+func keysetSorts(sorts []repository.Sort, idColumn string) []repository.Sort {
+	var out []repository.Sort
+	hasID := false
+	for _, s := range sorts {
+		field := SanitizeColumnName(s.Field)
+		if field == "" {
+			continue
+		}
+		dir := repository.SortDirection(strings.ToUpper(string(s.Direction)))
+		if dir != repository.SortDesc {
+			dir = repository.SortAsc
+		}
+		if strings.EqualFold(field, idColumn) {
+			hasID = true
+		}
+		out = append(out, repository.Sort{Field: field, Direction: dir})
+	}
+	if len(out) > 0 && !hasID {
+		out = append(out, repository.Sort{Field: idColumn, Direction: repository.SortAsc})
+	}
+	return out
+}
+
+// sortFieldIndexes returns the struct field index path of each sort column in typ.
+// Every sort column must be mapped by a db tag so cursor values can be read from and decoded into it.
+func sortFieldIndexes(typ reflect.Type, sorts []repository.Sort) ([][]int, error) {
+	mapping := getColumnMapping(typ)
+	indexes := make([][]int, len(sorts))
+	for i, s := range sorts {
+		c, ok := mapping[strings.ToLower(s.Field)]
+		if !ok {
+			return nil, fmt.Errorf("%w: sort column %q is not mapped to an entity field", repository.ErrInvalidCursor, s.Field)
+		}
+		indexes[i] = c.Index
+	}
+	return indexes, nil
+}
+
+// encodeCursor returns the opaque cursor pointing after entity for the given sorts.
+func encodeCursor(entity reflect.Value, sorts []repository.Sort, indexes [][]int) (string, error) {
+	keys := make([]repository.CursorKey, len(sorts))
+	for i, s := range sorts {
+		key, err := repository.NewCursorKey(s.Field, fieldByIndex(entity, indexes[i]).Interface(), s.Direction)
+		if err != nil {
+			return "", err
+		}
+		keys[i] = key
+	}
+	return repository.EncodeCursor(keys)
+}
+
+// decodeCursor decodes cursor into query args, one per sort, typed like the entity fields.
+// The cursor must have been produced for the same sorts.
+func decodeCursor(cursor string, typ reflect.Type, sorts []repository.Sort, indexes [][]int) ([]any, error) {
+	keys, err := repository.DecodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) != len(sorts) {
+		return nil, fmt.Errorf("%w: cursor does not match the sort order", repository.ErrInvalidCursor)
+	}
+	values := make([]any, len(sorts))
+	for i, s := range sorts {
+		if !strings.EqualFold(keys[i].Field, s.Field) || keys[i].Direction != s.Direction {
+			return nil, fmt.Errorf("%w: cursor does not match the sort order", repository.ErrInvalidCursor)
+		}
+		v := reflect.New(typ.FieldByIndex(indexes[i]).Type)
+		if err := json.Unmarshal(keys[i].Value, v.Interface()); err != nil {
+			return nil, fmt.Errorf("%w: %w", repository.ErrInvalidCursor, err)
+		}
+		values[i] = fieldValueToAny(v.Elem())
+		if values[i] == nil {
+			return nil, fmt.Errorf("%w: NULL sort values are not supported", repository.ErrInvalidCursor)
+		}
+	}
+	return values, nil
+}
+
+// keysetCondition returns the predicate selecting rows after values in sort order, e.g. for (a ASC, id ASC):
+// (a > $n OR (a = $n+1 AND id > $n+2)). Placeholders start at argIdx.
+func keysetCondition(dialect Dialect, sorts []repository.Sort, values []any, argIdx int) (cond string, args []any) {
+	ors := make([]string, len(sorts))
+	for i, s := range sorts {
+		ands := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			ands = append(ands, trustedIdent(dialect, sorts[j].Field)+" = "+dialect.Placeholder(argIdx))
+			args = append(args, values[j])
+			argIdx++
+		}
+		op := " > "
+		if s.Direction == repository.SortDesc {
+			op = " < "
+		}
+		ands = append(ands, trustedIdent(dialect, s.Field)+op+dialect.Placeholder(argIdx))
+		args = append(args, values[i])
+		argIdx++
+		ors[i] = strings.Join(ands, " AND ")
+		if len(ands) > 1 && len(sorts) > 1 {
+			ors[i] = "(" + ors[i] + ")"
+		}
+	}
+	return "(" + strings.Join(ors, " OR ") + ")", args
+}
@@ -0,0 +1,162 @@
+package sql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/biairmal/go-sdk/repository"
+)
+
+func TestKeysetSorts(t *testing.T) {
+	tests := []struct {
+		name  string
+		sorts []repository.Sort
+		want  []repository.Sort
+	}{
+		{name: "no sorts", sorts: nil, want: nil},
+		{
+			name:  "appends id tie-breaker",
+			sorts: []repository.Sort{{Field: "name", Direction: "desc"}},
+			want: []repository.Sort{
+				{Field: "name", Direction: repository.SortDesc},
+				{Field: "id", Direction: repository.SortAsc},
+			},
+		},
+		{
+			name: "keeps explicit id",
+			sorts: []repository.Sort{
+				{Field: "id", Direction: repository.SortDesc},
+				{Field: "bad;", Direction: repository.SortAsc},
+			},
+			want: []repository.Sort{{Field: "id", Direction: repository.SortDesc}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := keysetSorts(tt.sorts, "id"); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("keysetSorts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeysetCondition(t *testing.T) {
+	sorts := []repository.Sort{
+		{Field: "name", Direction: repository.SortAsc},
+		{Field: "id", Direction: repository.SortDesc},
+	}
+	cond, args := keysetCondition(Postgres{}, sorts, []any{"ann", int64(5)}, 2)
+	if want := "(name > $2 OR (name = $3 AND id < $4))"; cond != want {
+		t.Errorf("keysetCondition() = %q, want %q", cond, want)
+	}
+	if want := []any{"ann", "ann", int64(5)}; !reflect.DeepEqual(args, want) {
+		t.Errorf("keysetCondition() args = %v, want %v", args, want)
+	}
+
+	cond, _ = keysetCondition(MySQL{}, sorts[1:], []any{int64(5)}, 1)
+	if want := "(id < ?)"; cond != want {
+		t.Errorf("keysetCondition() = %q, want %q", cond, want)
+	}
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	typ := reflect.TypeOf(testUser{})
+	sorts := keysetSorts([]repository.Sort{{Field: "name"}}, "id")
+	indexes, err := sortFieldIndexes(typ, sorts)
+	if err != nil {
+		t.Fatalf("sortFieldIndexes() error = %v", err)
+	}
+	cursor, err := encodeCursor(reflect.ValueOf(testUser{ID: 9, Name: "bob"}), sorts, indexes)
+	if err != nil {
+		t.Fatalf("encodeCursor() error = %v", err)
+	}
+	values, err := decodeCursor(cursor, typ, sorts, indexes)
+	if err != nil {
+		t.Fatalf("decodeCursor() error = %v", err)
+	}
+	if want := []any{"bob", int64(9)}; !reflect.DeepEqual(values, want) {
+		t.Errorf("decodeCursor() = %v, want %v", values, want)
+	}
+
+	descSorts := keysetSorts([]repository.Sort{{Field: "name", Direction: repository.SortDesc}}, "id")
+	if _, err := decodeCursor(cursor, typ, descSorts, indexes); !errors.Is(err, repository.ErrInvalidCursor) {
+		t.Errorf("decodeCursor() with other sorts error = %v, want ErrInvalidCursor", err)
+	}
+	if _, err := decodeCursor("not base64!", typ, sorts, indexes); !errors.Is(err, repository.ErrInvalidCursor) {
+		t.Errorf("decodeCursor() malformed error = %v, want ErrInvalidCursor", err)
+	}
+	_, err = sortFieldIndexes(typ, []repository.Sort{{Field: "missing"}})
+	if !errors.Is(err, repository.ErrInvalidCursor) {
+		t.Errorf("sortFieldIndexes() unmapped error = %v, want ErrInvalidCursor", err)
+	}
+}
+
+func TestSQLRepository_ListPage(t *testing.T) {
+	backend, repo := newTestRepo(t)
+	backend.queryFn = func(query string) ([]string, [][]driver.Value) {
+		if strings.HasPrefix(query, "SELECT COUNT") {
+			return []string{"count"}, [][]driver.Value{{int64(3)}}
+		}
+		return []string{"id", "name"}, [][]driver.Value{{int64(1), "ann"}, {int64(2), "bob"}, {int64(3), "cat"}}
+	}
+	pager := repo.(repository.PagedRepository[testUser])
+	filter := repository.Filter{Conditions: []repository.FilterCondition{
+		{Field: "active", Operator: repository.FilterOperatorEq, Value: true},
+	}}
+	opts := &repository.ListOptions{
+		Filter:     filter,
+		Sorts:      []repository.Sort{{Field: "name", Direction: repository.SortAsc}},
+		Pagination: repository.Pagination{Limit: 2},
+	}
+
+	page, err := pager.ListPage(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("ListPage() error = %v", err)
+	}
+	if len(page.Items) != 2 || !page.HasMore || page.NextCursor == "" || page.Total != 3 {
+		t.Fatalf("ListPage() = %d items, HasMore %v, cursor %q, total %d; want 2, true, non-empty, 3",
+			len(page.Items), page.HasMore, page.NextCursor, page.Total)
+	}
+	first := backend.Statements()[0]
+	wantFirst := "SELECT id, name, deleted_at FROM users WHERE active = $1 ORDER BY name ASC, id ASC LIMIT $2 OFFSET $3"
+	if first.Query != wantFirst || !reflect.DeepEqual(first.Args, []any{true, int64(3), int64(0)}) {
+		t.Errorf("ListPage() first query = %q %v, want %q [true 3 0]", first.Query, first.Args, wantFirst)
+	}
+
+	opts.Pagination.Cursor = page.NextCursor
+	opts.SkipCount = true
+	if _, err := pager.ListPage(context.Background(), opts); err != nil {
+		t.Fatalf("ListPage() with cursor error = %v", err)
+	}
+	next := backend.Last()
+	wantNext := "SELECT id, name, deleted_at FROM users WHERE active = $1 AND (name > $2 OR (name = $3 AND id > $4)) " +
+		"ORDER BY name ASC, id ASC LIMIT $5 OFFSET $6"
+	wantArgs := []any{true, "bob", "bob", int64(2), int64(3), int64(0)}
+	if next.Query != wantNext || !reflect.DeepEqual(next.Args, wantArgs) {
+		t.Errorf("ListPage() cursor query = %q %v, want %q %v", next.Query, next.Args, wantNext, wantArgs)
+	}
+}
+
+func TestSQLRepository_ListPageOffsetFallback(t *testing.T) {
+	backend, repo := newTestRepo(t)
+	backend.queryFn = func(string) ([]string, [][]driver.Value) {
+		return []string{"id", "name"}, [][]driver.Value{{int64(1), "ann"}}
+	}
+	opts := &repository.ListOptions{Pagination: repository.Pagination{Limit: 5, Offset: 10}, SkipCount: true}
+
+	page, err := repo.(repository.PagedRepository[testUser]).ListPage(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("ListPage() error = %v", err)
+	}
+	if page.HasMore || page.NextCursor != "" || page.Offset != 10 || page.Limit != 5 {
+		t.Errorf("ListPage() = %+v, want no more rows, no cursor, offset 10, limit 5", page)
+	}
+	if got, want := backend.Last().Query, "SELECT id, name, deleted_at FROM users LIMIT $1 OFFSET $2"; got != want {
+		t.Errorf("ListPage() query = %q, want %q", got, want)
+	}
+}
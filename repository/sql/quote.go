@@ -0,0 +1,97 @@
+package sql
+
+import (
+	"regexp"
+	"strings"
+)
+
+// IdentifierQuoter is an optional Dialect extension that quotes identifiers, e.g. "order" or `order`.
+// It is used only for dialects wrapped with QuoteIdentifiers (see WithQuoteIdentifiers).
+type IdentifierQuoter interface {
+	QuoteIdentifier(name string) string
+}
+
+// quotableIdentifier is the allow-list for quoted identifiers: plain names, optionally qualified (table.column).
+var quotableIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_$]*(\.[A-Za-z_][A-Za-z0-9_$]*)*$`)
+
+// QuoteIdentifiers returns d wrapped so that the query builders quote column names with d's IdentifierQuoter
+// (double quotes when d does not implement it). Names outside the allow-list (letters, digits, _ and $,
+// optionally dot-qualified) are never quoted: filter and sort fields with such names are skipped, other
+// names are used unquoted. Optional interfaces of d (ReturningDialect, ILikeDialect, TimestampDialect,
+// WindowCountDialect) keep working.
+func QuoteIdentifiers(d Dialect) Dialect {
+	if d == nil {
+		d = DefaultDialect
+	}
+	if q, ok := d.(quotingDialect); ok {
+		return q
+	}
+	return quotingDialect{Dialect: d}
+}
+
+// quotingDialect marks a dialect whose identifiers must be quoted.
+type quotingDialect struct {
+	Dialect
+}
+
+// SupportsReturning implements ReturningDialect by delegating to the wrapped dialect.
+func (q quotingDialect) SupportsReturning() bool {
+	rd, ok := q.Dialect.(ReturningDialect)
+	return ok && rd.SupportsReturning()
+}
+
+// ILikeClause implements ILikeDialect by delegating to the wrapped dialect.
+func (q quotingDialect) ILikeClause(column, placeholder string) string {
+	return iLikeCondition(q.Dialect, column, placeholder)
+}
+
+// CurrentTimestamp implements TimestampDialect by delegating to the wrapped dialect.
+func (q quotingDialect) CurrentTimestamp() string {
+	return currentTimestamp(q.Dialect)
+}
+
+// SupportsWindowCount implements WindowCountDialect by delegating to the wrapped dialect.
+func (q quotingDialect) SupportsWindowCount() bool {
+	return supportsWindowCount(q.Dialect)
+}
+
+// quote quotes each dot-separated part of name.
+func (q quotingDialect) quote(name string) string {
+	parts := strings.Split(name, ".")
+	for i, p := range parts {
+		if quoter, ok := q.Dialect.(IdentifierQuoter); ok {
+			parts[i] = quoter.QuoteIdentifier(p)
+		} else {
+			parts[i] = `"` + p + `"`
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+// unwrapDialect returns the dialect wrapped by QuoteIdentifiers, or d itself.
+func unwrapDialect(d Dialect) Dialect {
+	if q, ok := d.(quotingDialect); ok {
+		return q.Dialect
+	}
+	return d
+}
+
+// columnIdent returns name as it must appear in SQL for dialect d: quoted when d quotes identifiers.
+// ok is false when quoting is on and name is outside the allow-list.
+func columnIdent(d Dialect, name string) (ident string, ok bool) {
+	q, quoting := d.(quotingDialect)
+	if !quoting {
+		return name, true
+	}
+	if !quotableIdentifier.MatchString(name) {
+		return name, false
+	}
+	return q.quote(name), true
+}
+
+// trustedIdent is columnIdent for names from struct tags and repository options, which are used unquoted
+// when outside the allow-list.
+func trustedIdent(d Dialect, name string) string {
+	ident, _ := columnIdent(d, name)
+	return ident
+}
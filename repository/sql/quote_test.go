@@ -0,0 +1,125 @@
+package sql
+
+import (
+	"context"
+	"database/sql/driver"
+	"reflect"
+	"testing"
+
+	"github.com/biairmal/go-sdk/repository"
+)
+
+type reservedEntity struct {
+	ID    int64  `db:"id"`
+	Order int    `db:"order"`
+	User  string `db:"user"`
+}
+
+func TestColumnIdent(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect Dialect
+		column  string
+		want    string
+		wantOK  bool
+	}{
+		{name: "not quoting", dialect: Postgres{}, column: "order", want: "order", wantOK: true},
+		{name: "postgres", dialect: QuoteIdentifiers(Postgres{}), column: "order", want: `"order"`, wantOK: true},
+		{name: "mysql", dialect: QuoteIdentifiers(MySQL{}), column: "order", want: "`order`", wantOK: true},
+		{name: "oracle qualified", dialect: QuoteIdentifiers(Oracle{}), column: "t.user", want: `"t"."user"`, wantOK: true},
+		{name: "sqlite", dialect: QuoteIdentifiers(SQLite{}), column: "user", want: `"user"`, wantOK: true},
+		{name: "rejects spaces", dialect: QuoteIdentifiers(Postgres{}), column: "a b", want: "a b", wantOK: false},
+		{name: "rejects quotes", dialect: QuoteIdentifiers(MySQL{}), column: "a`b", want: "a`b", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := columnIdent(tt.dialect, tt.column)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("columnIdent() = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestQuoteIdentifiers_KeepsDialectExtensions(t *testing.T) {
+	pg := QuoteIdentifiers(Postgres{})
+	if rd, ok := pg.(ReturningDialect); !ok || !rd.SupportsReturning() {
+		t.Error("QuoteIdentifiers(Postgres{}) lost ReturningDialect support")
+	}
+	if rd, ok := QuoteIdentifiers(MySQL{}).(ReturningDialect); ok && rd.SupportsReturning() {
+		t.Error("QuoteIdentifiers(MySQL{}) reports RETURNING support")
+	}
+	got, _ := BuildWhereClause(pg, repository.Filter{Conditions: []repository.FilterCondition{
+		{Field: "user", Operator: repository.FilterOperatorILike, Value: "a%"},
+	}})
+	if want := `WHERE "user" ILIKE $1`; got != want {
+		t.Errorf("BuildWhereClause() = %q, want %q", got, want)
+	}
+	if QuoteIdentifiers(pg) != pg {
+		t.Error("QuoteIdentifiers() wrapped an already quoting dialect")
+	}
+}
+
+func TestSQLRepository_QuoteIdentifiers(t *testing.T) {
+	tests := []struct {
+		name       string
+		dialect    Dialect
+		wantSelect string
+		wantUpdate string
+	}{
+		{
+			name:       "postgres",
+			dialect:    Postgres{},
+			wantSelect: `SELECT "id", "order", "user" FROM items WHERE "user" = $1 AND "order" > $2 ORDER BY "order" DESC`,
+			wantUpdate: `UPDATE items SET "order" = $1, "user" = $2 WHERE "id" = $3`,
+		},
+		{
+			name:       "mysql",
+			dialect:    MySQL{},
+			wantSelect: "SELECT `id`, `order`, `user` FROM items WHERE `user` = ? AND `order` > ? ORDER BY `order` DESC",
+			wantUpdate: "UPDATE items SET `order` = ?, `user` = ? WHERE `id` = ?",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend, db := newFakeRepoDB(t)
+			repo := NewSQLRepository[reservedEntity, int64](nil, db, "items",
+				WithDialect[reservedEntity, int64](tt.dialect),
+				WithQuoteIdentifiers[reservedEntity, int64](true),
+			)
+			backend.queryFn = func(string) ([]string, [][]driver.Value) {
+				return []string{"id", "order", "user"}, nil
+			}
+			opts := &repository.ListOptions{
+				Filter: repository.Filter{Conditions: []repository.FilterCondition{
+					{Field: "user", Operator: repository.FilterOperatorEq, Value: "ann"},
+					{Field: "order", Operator: repository.FilterOperatorGt, Value: 1},
+					{Field: "user = 1 OR 1", Operator: repository.FilterOperatorEq, Value: 1},
+				}},
+				Sorts:      []repository.Sort{{Field: "order", Direction: repository.SortDesc}},
+				Pagination: repository.Pagination{Limit: 5},
+				SkipCount:  true,
+			}
+			if _, _, err := repo.List(context.Background(), opts); err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			got := backend.Last()
+			wantQuery := tt.wantSelect + " " + tt.dialect.PaginationClause(3, 4)
+			if got.Query != wantQuery {
+				t.Errorf("List() query = %q, want %q", got.Query, wantQuery)
+			}
+			if want := []any{"ann", int64(1), int64(5), int64(0)}; !reflect.DeepEqual(got.Args, want) {
+				t.Errorf("List() args = %v, want %v", got.Args, want)
+			}
+
+			if err := repo.Update(context.Background(), 1, &reservedEntity{Order: 2, User: "bob"}); err != nil {
+				t.Fatalf("Update() error = %v", err)
+			}
+			if got := backend.Last().Query; got != tt.wantUpdate {
+				t.Errorf("Update() query = %q, want %q", got, tt.wantUpdate)
+			}
+		})
+	}
+}
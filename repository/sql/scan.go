@@ -6,19 +6,30 @@ import (
 	"strings"
 	"sync"
 	"time"
-
-	"github.com/google/uuid"
 )
 
-var columnMappingCache sync.Map // map[reflect.Type]map[string]int (column name lower -> field index)
-
-var uuidType = reflect.TypeOf(uuid.UUID{})
+var columnMappingCache sync.Map // map[reflect.Type]map[string]orderedColumn (column name lower -> column)
 
 // ScanRow maps one row from rows into *T using struct tag `db:"column_name"`.
 // Fields without `db` or with `db:"-"` are skipped. Column names are matched case-insensitively.
-// Supports common types, uuid.UUID and *uuid.UUID (scanned via string then parsed), and *time.Time.
+// Field types are scanned as follows:
+//   - types implementing sql.Scanner via their pointer (sql.NullString and the other
+//     sql.Null* types, uuid.UUID, custom types) scan the column themselves, NULL included
+//   - pointer fields (*string, *time.Time, *uuid.UUID, ...) are set to nil for NULL and
+//     otherwise allocated and scanned into
+//   - other types (string, int64, time.Time, ...) use database/sql's conversions, so
+//     NULL into them is an error: use a pointer or sql.Null* field for nullable columns
+//
+// Fields tagged `db:"name,json"` are scanned from []byte/string and decoded with json.Unmarshal.
+// rows is a *sql.Rows, a *sqlkit.Rows or any other Rows.
 // Caller must advance rows (e.g. rows.Next()) before calling ScanRow.
-func ScanRow[T any](rows *sql.Rows) (*T, error) {
+func ScanRow[T any](rows Rows) (*T, error) {
+	return scanRow[T](rows, nil)
+}
+
+// scanRow implements ScanRow. When total is non-nil, the windowTotalColumn column
+// (see WithWindowCount) is scanned into it instead of being matched to a field.
+func scanRow[T any](rows Rows, total *int64) (*T, error) {
 	var zero T
 	typ := reflect.TypeOf(&zero).Elem()
 	if typ.Kind() != reflect.Struct {
@@ -31,90 +42,69 @@ func ScanRow[T any](rows *sql.Rows) (*T, error) {
 	mapping := getColumnMapping(typ)
 	ptr := reflect.New(typ)
 	dest := make([]any, len(columns))
-	uuidScans := make([]*string, len(columns))
+	jsonScans := make([][]byte, len(columns))
+	jsonTargets := make([]reflect.Value, len(columns))
 	for i, col := range columns {
-		idx, ok := mapping[strings.ToLower(col)]
+		if total != nil && col == windowTotalColumn {
+			dest[i] = total
+			continue
+		}
+		c, ok := mapping[strings.ToLower(col)]
 		if !ok {
 			var dummy any
 			dest[i] = &dummy
 			continue
 		}
-		field := ptr.Elem().Field(idx)
+		field := settableFieldByIndex(ptr.Elem(), c.Index)
 		if !field.CanSet() {
 			var dummy any
 			dest[i] = &dummy
 			continue
 		}
-		ft := field.Type()
-		if ft == uuidType {
-			dest[i] = &uuidScans[i]
+		if c.JSON {
+			dest[i] = &jsonScans[i]
+			jsonTargets[i] = field
 			continue
 		}
-		if ft.Kind() == reflect.Ptr && ft.Elem() == uuidType {
-			dest[i] = &uuidScans[i]
-			continue
-		}
-		dest[i] = field.Addr().Interface()
+		dest[i] = scanTarget(field)
 	}
 	if err := rows.Scan(dest...); err != nil {
 		return nil, err
 	}
-	for i, col := range columns {
-		idx, ok := mapping[strings.ToLower(col)]
-		if !ok {
-			continue
-		}
-		field := ptr.Elem().Field(idx)
-		ft := field.Type()
-		if ft == uuidType {
-			if uuidScans[i] != nil && *uuidScans[i] != "" {
-				if u, err := uuid.Parse(*uuidScans[i]); err == nil {
-					field.Set(reflect.ValueOf(u))
-				}
-			}
-			continue
-		}
-		if ft.Kind() == reflect.Ptr && ft.Elem() == uuidType {
-			if uuidScans[i] != nil && *uuidScans[i] != "" {
-				if u, err := uuid.Parse(*uuidScans[i]); err == nil {
-					field.Set(reflect.ValueOf(&u))
-				}
-			}
-		}
+	if err := setJSONFields(columns, jsonScans, jsonTargets); err != nil {
+		return nil, err
 	}
 	return ptr.Interface().(*T), nil
 }
 
+// scanTarget returns the rows.Scan destination for a settable struct field.
+func scanTarget(field reflect.Value) any {
+	addr := field.Addr().Interface()
+	if scanner, ok := addr.(sql.Scanner); ok {
+		return scanner
+	}
+	// database/sql handles **T itself: nil for NULL, otherwise a new T scanned
+	// through T's Scanner when it has one.
+	return addr
+}
+
 // ReflectScan returns a function that maps rows to *T using struct tag `db:"column_name"`.
 // Deprecated: use ScanRow[T] directly for new code.
 func ReflectScan[T any]() func(*sql.Rows) (*T, error) {
-	return ScanRow[T]
+	return func(rows *sql.Rows) (*T, error) { return ScanRow[T](rows) }
 }
 
-// getColumnMapping returns column name (lower) -> struct field index for typ.
-func getColumnMapping(typ reflect.Type) map[string]int {
+// getColumnMapping returns column name (lower) -> column for typ, including the
+// columns of embedded structs (see getOrderedColumns).
+func getColumnMapping(typ reflect.Type) map[string]orderedColumn {
 	key := typ
 	if v, ok := columnMappingCache.Load(key); ok {
-		return v.(map[string]int)
+		return v.(map[string]orderedColumn)
 	}
-	m := make(map[string]int)
-	for i := 0; i < typ.NumField(); i++ {
-		f := typ.Field(i)
-		if f.PkgPath != "" {
-			continue
-		}
-		tag := f.Tag.Get("db")
-		if tag == "" || tag == "-" {
-			continue
-		}
-		name := strings.TrimSpace(tag)
-		if idx := strings.Index(name, ","); idx >= 0 {
-			name = strings.TrimSpace(name[:idx])
-		}
-		if name == "" {
-			continue
-		}
-		m[strings.ToLower(name)] = i
+	cols := getOrderedColumns(typ)
+	m := make(map[string]orderedColumn, len(cols))
+	for _, c := range cols {
+		m[strings.ToLower(c.Name)] = c
 	}
 	columnMappingCache.Store(key, m)
 	return m
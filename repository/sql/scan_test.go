@@ -0,0 +1,140 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// csvTags is a custom sql.Scanner decoding a comma-separated column.
+type csvTags []string
+
+func (t *csvTags) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*t = nil
+	case string:
+		*t = strings.Split(v, ",")
+	case []byte:
+		*t = strings.Split(string(v), ",")
+	default:
+		return errors.New("csvTags: unsupported type")
+	}
+	return nil
+}
+
+type scanEntity struct {
+	ID        uuid.UUID      `db:"id"`
+	Name      string         `db:"name"`
+	Nickname  *string        `db:"nickname"`
+	Bio       sql.NullString `db:"bio"`
+	Age       sql.NullInt64  `db:"age"`
+	ParentID  *uuid.UUID     `db:"parent_id"`
+	DeletedAt *time.Time     `db:"deleted_at"`
+	Tags      csvTags        `db:"tags"`
+	Labels    *csvTags       `db:"labels"`
+}
+
+// scanOne runs a query on a fake backend answering with columns and a single row,
+// and scans that row with ScanRow.
+func scanOne[T any](t *testing.T, columns []string, row []driver.Value) (*T, error) {
+	t.Helper()
+	backend, db := newFakeRepoDB(t)
+	backend.queryFn = func(string) ([]string, [][]driver.Value) {
+		return columns, [][]driver.Value{row}
+	}
+	rows, err := db.Leader().QueryContext(context.Background(), "SELECT")
+	if err != nil {
+		t.Fatalf("QueryContext() error = %v", err)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		t.Fatalf("Next() = false, err = %v", rows.Err())
+	}
+	return ScanRow[T](rows)
+}
+
+func TestScanRow_NullableAndScanner(t *testing.T) {
+	columns := []string{"id", "name", "nickname", "bio", "age", "parent_id", "deleted_at", "tags", "labels"}
+	id, parent := uuid.New(), uuid.New()
+	deleted := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("null columns", func(t *testing.T) {
+		row := []driver.Value{id.String(), "ada", nil, nil, nil, nil, nil, nil, nil}
+		got, err := scanOne[scanEntity](t, columns, row)
+		if err != nil {
+			t.Fatalf("ScanRow() error = %v", err)
+		}
+		if got.ID != id || got.Name != "ada" {
+			t.Errorf("ScanRow() ID, Name = %v, %q, want %v, %q", got.ID, got.Name, id, "ada")
+		}
+		if got.Nickname != nil || got.ParentID != nil || got.DeletedAt != nil || got.Labels != nil {
+			t.Errorf("ScanRow() pointer fields = %v, %v, %v, %v, want all nil",
+				got.Nickname, got.ParentID, got.DeletedAt, got.Labels)
+		}
+		if got.Bio.Valid || got.Age.Valid {
+			t.Errorf("ScanRow() Bio, Age = %+v, %+v, want invalid", got.Bio, got.Age)
+		}
+		if got.Tags != nil {
+			t.Errorf("ScanRow() Tags = %v, want nil", got.Tags)
+		}
+	})
+
+	t.Run("values", func(t *testing.T) {
+		row := []driver.Value{
+			[]byte(id.String()), "ada", "countess", "math", int64(36),
+			parent.String(), deleted, "a,b", []byte("x,y,z"),
+		}
+		got, err := scanOne[scanEntity](t, columns, row)
+		if err != nil {
+			t.Fatalf("ScanRow() error = %v", err)
+		}
+		if got.ID != id {
+			t.Errorf("ScanRow() ID = %v, want %v", got.ID, id)
+		}
+		if got.Nickname == nil || *got.Nickname != "countess" {
+			t.Errorf("ScanRow() Nickname = %v, want countess", got.Nickname)
+		}
+		if got.Bio != (sql.NullString{String: "math", Valid: true}) {
+			t.Errorf("ScanRow() Bio = %+v, want math", got.Bio)
+		}
+		if got.Age != (sql.NullInt64{Int64: 36, Valid: true}) {
+			t.Errorf("ScanRow() Age = %+v, want 36", got.Age)
+		}
+		if got.ParentID == nil || *got.ParentID != parent {
+			t.Errorf("ScanRow() ParentID = %v, want %v", got.ParentID, parent)
+		}
+		if got.DeletedAt == nil || !got.DeletedAt.Equal(deleted) {
+			t.Errorf("ScanRow() DeletedAt = %v, want %v", got.DeletedAt, deleted)
+		}
+		if strings.Join(got.Tags, "|") != "a|b" {
+			t.Errorf("ScanRow() Tags = %v, want [a b]", got.Tags)
+		}
+		if got.Labels == nil || strings.Join(*got.Labels, "|") != "x|y|z" {
+			t.Errorf("ScanRow() Labels = %v, want [x y z]", got.Labels)
+		}
+	})
+}
+
+func TestScanRow_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		row  []driver.Value
+	}{
+		{name: "null into non-pointer", row: []driver.Value{uuid.New().String(), nil}},
+		{name: "invalid uuid", row: []driver.Value{"not-a-uuid", "ada"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := scanOne[scanEntity](t, []string{"id", "name"}, tt.row); err == nil {
+				t.Error("ScanRow() error = nil, want error")
+			}
+		})
+	}
+}
@@ -2,9 +2,11 @@ package sql
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/biairmal/go-sdk/logger"
 	"github.com/biairmal/go-sdk/repository"
@@ -21,10 +23,18 @@ type SQLRepository[TEntity any, TID comparable] struct {
 	dialect       Dialect
 	selectColumns []string
 	entityType    reflect.Type
+	softDelete    string   // Soft-delete column; empty means hard deletes
+	compositeID   []string // Key columns set by WithCompositeID; empty means the single ID column
+	createdAt     string   // Column stamped by inserts (WithTimestamps); empty means none
+	updatedAt     string   // Column stamped by inserts and updates (WithTimestamps); empty means none
+	windowCount   bool     // List reads the total from COUNT(*) OVER() (WithWindowCount)
+
+	quoteIdentifiers bool
 }
 
 // NewSQLRepository creates a new SQL repository.
-// Logger may be nil (no query logging). Opts are optional (e.g. WithDialect, WithSelectColumns, WithIDColumn).
+// Logger may be nil (no query logging).
+// Opts are optional (e.g. WithDialect, WithSelectColumns, WithIDColumn, WithSoftDelete, WithTimestamps).
 func NewSQLRepository[TEntity any, TID comparable](
 	log logger.Logger,
 	db *sqlkit.DB,
@@ -48,6 +58,17 @@ func NewSQLRepository[TEntity any, TID comparable](
 	return repo
 }
 
+// WithTx returns a copy of the repository bound to tx, implementing
+// repository.TransactionalRepository. Every read and write of the copy runs on tx,
+// whatever the context carries, so the repository can join a transaction begun
+// elsewhere (e.g. by sqlc or hand-written code holding a *sql.Tx). The caller
+// commits or rolls back tx; the original repository is unaffected.
+func (r *SQLRepository[TEntity, TID]) WithTx(tx *sql.Tx) repository.Repository[TEntity, TID] {
+	bound := *r
+	bound.BaseRepository = r.BaseRepository.WithTx(tx)
+	return &bound
+}
+
 // WithDialect sets the SQL dialect (Postgres, MySQL, Oracle) for placeholders and pagination.
 func WithDialect[TEntity any, TID comparable](d Dialect) SQLRepositoryOption[TEntity, TID] {
 	return func(r *SQLRepository[TEntity, TID]) {
@@ -57,13 +78,22 @@ func WithDialect[TEntity any, TID comparable](d Dialect) SQLRepositoryOption[TEn
 	}
 }
 
-// WithSelectColumns sets columns to SELECT for read operations (List, GetByID).
+// WithSelectColumns sets columns to SELECT for read operations (GetByID, List, ListPage),
+// overriding the default of the entity's db-tagged columns.
 func WithSelectColumns[TEntity any, TID comparable](columns []string) SQLRepositoryOption[TEntity, TID] {
 	return func(r *SQLRepository[TEntity, TID]) {
 		r.selectColumns = columns
 	}
 }
 
+// WithQuoteIdentifiers quotes column names in generated SQL ("order" on Postgres/SQLite/Oracle, `order` on MySQL)
+// so reserved words can be used as columns. See QuoteIdentifiers for the allow-list applied to names.
+func WithQuoteIdentifiers[TEntity any, TID comparable](enabled bool) SQLRepositoryOption[TEntity, TID] {
+	return func(r *SQLRepository[TEntity, TID]) {
+		r.quoteIdentifiers = enabled
+	}
+}
+
 // WithIDColumn sets the ID column name (default "id").
 func WithIDColumn[TEntity any, TID comparable](column string) SQLRepositoryOption[TEntity, TID] {
 	return func(r *SQLRepository[TEntity, TID]) {
@@ -71,6 +101,75 @@ func WithIDColumn[TEntity any, TID comparable](column string) SQLRepositoryOptio
 	}
 }
 
+// WithCompositeID declares a composite primary key made of columns (in WHERE order).
+// Use GetByKey, UpdateByKey and DeleteByKey to address rows by the full key; Update excludes every key
+// column from its SET clause. The single-column methods (GetByID, Update, Delete, Exists) keep matching on
+// the ID column alone.
+func WithCompositeID[TEntity any, TID comparable](columns ...string) SQLRepositoryOption[TEntity, TID] {
+	return func(r *SQLRepository[TEntity, TID]) {
+		r.compositeID = nil
+		for _, col := range columns {
+			if col = SanitizeColumnName(col); col != "" {
+				r.compositeID = append(r.compositeID, col)
+			}
+		}
+	}
+}
+
+// WithSoftDelete enables soft deletes using the given timestamp column (e.g. "deleted_at").
+// Delete sets the column to the current UTC time instead of removing the row, and read operations
+// (GetByID, List, Count, Exists) skip rows where the column is not NULL. Use repository.WithIncludeDeleted
+// or ListOptions.IncludeDeleted to read soft-deleted rows.
+func WithSoftDelete[TEntity any, TID comparable](column string) SQLRepositoryOption[TEntity, TID] {
+	return func(r *SQLRepository[TEntity, TID]) {
+		r.softDelete = SanitizeColumnName(column)
+	}
+}
+
+// WithTimestamps maintains audit timestamp columns in SQL, using the dialect's current timestamp
+// (CURRENT_TIMESTAMP unless the dialect implements TimestampDialect): Create and CreateMany set createdCol and
+// updatedCol, and Update, UpdateByKey and UpdatePartial set updatedCol. createdCol is never updated. The
+// entity's own fields for these columns are ignored on write and are not refreshed afterwards; read the entity
+// back to see the stored values. The columns need not be mapped on the entity. An empty name disables that column.
+func WithTimestamps[TEntity any, TID comparable](createdCol, updatedCol string) SQLRepositoryOption[TEntity, TID] {
+	return func(r *SQLRepository[TEntity, TID]) {
+		r.createdAt = SanitizeColumnName(createdCol)
+		r.updatedAt = SanitizeColumnName(updatedCol)
+	}
+}
+
+// WithWindowCount makes List read the total from a COUNT(*) OVER() column added to the list query instead
+// of running a separate count query, saving a round-trip per page. It applies only to dialects implementing
+// WindowCountDialect (Postgres); others keep the count query. The database still counts every matching row,
+// so on large result sets the list query itself gets slower (the whole filtered set is scanned before LIMIT
+// applies, which rules out early termination). When a page past the end comes back empty the total cannot be
+// read from it, and List falls back to the count query. ListPage and Count are unaffected.
+func WithWindowCount[TEntity any, TID comparable]() SQLRepositoryOption[TEntity, TID] {
+	return func(r *SQLRepository[TEntity, TID]) {
+		r.windowCount = true
+	}
+}
+
+// insertStamps returns the columns inserts set to the current timestamp.
+func (r *SQLRepository[TEntity, TID]) insertStamps() []string {
+	var cols []string
+	if r.createdAt != "" {
+		cols = append(cols, r.createdAt)
+	}
+	if r.updatedAt != "" && !strings.EqualFold(r.updatedAt, r.createdAt) {
+		cols = append(cols, r.updatedAt)
+	}
+	return cols
+}
+
+// updateStamps returns the columns updates set to the current timestamp.
+func (r *SQLRepository[TEntity, TID]) updateStamps() []string {
+	if r.updatedAt == "" {
+		return nil
+	}
+	return []string{r.updatedAt}
+}
+
 func (r *SQLRepository[TEntity, TID]) logQuery(ctx context.Context, query string, args []any) {
 	if r.log == nil {
 		return
@@ -87,9 +186,59 @@ func (r *SQLRepository[TEntity, TID]) getDialect() Dialect {
 	if d == nil {
 		d = DefaultDialect
 	}
+	if r.quoteIdentifiers {
+		return QuoteIdentifiers(d)
+	}
 	return d
 }
 
+// selectClause returns the column list for SELECT statements: the WithSelectColumns override if set,
+// otherwise the entity's db-tagged columns in field order ("*" only if the entity maps no columns).
+func (r *SQLRepository[TEntity, TID]) selectClause() string {
+	d := r.getDialect()
+	names := make([]string, 0, len(r.selectColumns))
+	for _, col := range r.selectColumns {
+		names = append(names, trustedIdent(d, col))
+	}
+	if len(names) == 0 {
+		for _, c := range getOrderedColumns(r.entityType) {
+			names = append(names, trustedIdent(d, c.Name))
+		}
+	}
+	if len(names) == 0 {
+		return "*"
+	}
+	return strings.Join(names, ", ")
+}
+
+// excludeDeleted reports whether read operations must skip soft-deleted rows.
+func (r *SQLRepository[TEntity, TID]) excludeDeleted(ctx context.Context, includeDeleted bool) bool {
+	return r.softDelete != "" && !includeDeleted && !repository.IncludeDeleted(ctx)
+}
+
+// notDeletedFilter returns filter with a "<softDelete column> IS NULL" condition appended.
+// The caller's Conditions slice is not modified.
+func (r *SQLRepository[TEntity, TID]) notDeletedFilter(filter repository.Filter) repository.Filter {
+	conditions := make([]repository.FilterCondition, 0, len(filter.Conditions)+1)
+	conditions = append(conditions, filter.Conditions...)
+	conditions = append(conditions, repository.FilterCondition{
+		Field:    r.softDelete,
+		Operator: repository.FilterOperatorIsNull,
+	})
+	filter.Conditions = conditions
+	return filter
+}
+
+// byIDCondition returns the WHERE condition matching one row by ID, skipping soft-deleted rows when needed.
+func (r *SQLRepository[TEntity, TID]) byIDCondition(ctx context.Context) string {
+	d := r.getDialect()
+	cond := trustedIdent(d, r.IDColumn()) + " = " + d.Placeholder(1)
+	if r.excludeDeleted(ctx, false) {
+		cond += " AND " + trustedIdent(d, r.softDelete) + " IS NULL"
+	}
+	return cond
+}
+
 // Create inserts a new entity using reflection (db tags).
 // If the entity's ID is zero/nil, the ID column is omitted from INSERT so the DB can set it via DEFAULT;
 // the generated ID is then written back to the entity (int64 via LastInsertId, UUID/string via RETURNING).
@@ -99,8 +248,9 @@ func (r *SQLRepository[TEntity, TID]) Create(ctx context.Context, entity *TEntit
 	d := r.getDialect()
 	idColumn := r.IDColumn()
 	excludeID := IsEntityIDZero(entity, idColumn)
-	query := BuildInsertQuery(r.TableName(), idColumn, d, r.entityType, excludeID)
-	args := ExtractInsertValues(entity, idColumn, excludeID)
+	stamped := r.insertStamps()
+	query := buildInsertQuery(r.TableName(), idColumn, d, r.entityType, excludeID, stamped, 1)
+	args := insertValues(entity, idColumn, excludeID, stamped)
 	r.logQuery(ctx, query, args)
 
 	if excludeID && IsEntityIDFieldInt64(entity, idColumn) {
@@ -114,7 +264,7 @@ func (r *SQLRepository[TEntity, TID]) Create(ctx context.Context, entity *TEntit
 		return nil
 	}
 	if excludeID {
-		queryReturning := query + " RETURNING " + idColumn
+		queryReturning := query + " RETURNING " + trustedIdent(d, idColumn)
 		r.logQuery(ctx, queryReturning, args)
 		row := conn.QueryRowContext(ctx, queryReturning, args...)
 		if err := ScanReturnedIDAndSetEntity(entity, idColumn, row); err != nil {
@@ -128,14 +278,30 @@ func (r *SQLRepository[TEntity, TID]) Create(ctx context.Context, entity *TEntit
 
 // GetByID retrieves an entity by its ID.
 func (r *SQLRepository[TEntity, TID]) GetByID(ctx context.Context, id TID) (*TEntity, error) {
-	conn := r.GetReadConnection(ctx)
-	sel := "*"
-	if len(r.selectColumns) > 0 {
-		sel = strings.Join(r.selectColumns, ", ")
-	}
-	d := r.getDialect()
-	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s = %s", sel, r.TableName(), r.IDColumn(), d.Placeholder(1))
-	args := []any{id}
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s", r.selectClause(), r.TableName(), r.byIDCondition(ctx))
+	return r.queryOne(ctx, r.GetReadConnection(ctx), query, []any{id})
+}
+
+// GetByIDForUpdate retrieves an entity by its ID and locks the row until the transaction ends
+// (SELECT ... FOR UPDATE), for read-modify-write within sqlkit.WithTransaction.
+// Returns repository.ErrTransactionRequired when ctx carries no transaction, since the lock would be
+// released immediately. Dialects without row locks (SQLite) run a plain SELECT in the transaction.
+func (r *SQLRepository[TEntity, TID]) GetByIDForUpdate(ctx context.Context, id TID) (*TEntity, error) {
+	tx, ok := r.Tx(ctx)
+	if !ok {
+		return nil, repository.ErrTransactionRequired
+	}
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s", r.selectClause(), r.TableName(), r.byIDCondition(ctx))
+	if lock := r.getDialect().ForUpdateClause(); lock != "" {
+		query += " " + lock
+	}
+	return r.queryOne(ctx, txConnection{tx: tx}, query, []any{id})
+}
+
+// queryOne runs a SELECT on conn and scans the first row, returning repository.ErrNotFound if there is none.
+func (r *SQLRepository[TEntity, TID]) queryOne(
+	ctx context.Context, conn ReadConnection, query string, args []any,
+) (*TEntity, error) {
 	r.logQuery(ctx, query, args)
 	rows, err := conn.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -153,35 +319,56 @@ func (r *SQLRepository[TEntity, TID]) GetByID(ctx context.Context, id TID) (*TEn
 }
 
 // Update updates an existing entity using reflection (db tags).
+// The ID column, any WithCompositeID columns and the WithTimestamps created column are excluded from the
+// SET clause; the WithTimestamps updated column is set to the current timestamp.
 func (r *SQLRepository[TEntity, TID]) Update(ctx context.Context, id TID, entity *TEntity) error {
-	conn := r.GetConnection(ctx)
 	d := r.getDialect()
-	query := BuildUpdateQuery(r.TableName(), r.IDColumn(), d, r.entityType)
+	excluded, stamped := r.immutableColumns(), r.updateStamps()
+	query := buildUpdateQuery(r.TableName(), d, r.entityType, excluded, stamped, []string{r.IDColumn()})
 	if query == "" {
 		return fmt.Errorf("repository: no fields to update")
 	}
-	args := ExtractUpdateValues(entity, any(id), r.IDColumn())
-	r.logQuery(ctx, query, args)
-	result, err := conn.ExecContext(ctx, query, args...)
-	if err != nil {
-		return ConvertSQLError(err)
-	}
-	affected, err := result.RowsAffected()
-	if err != nil {
-		return err
-	}
-	if affected == 0 {
-		return repository.ErrNotFound
+	args := append(updateValues(entity, append(excluded, stamped...)), any(id))
+	return r.execAffectingOne(ctx, query, args)
+}
+
+// UpdatePartial updates only the non-zero fields of entity (PATCH semantics), leaving other columns unchanged.
+// Zero values (0, "", false, nil, uuid.Nil, zero time) cannot be written this way; use Update for that.
+// Returns an error wrapping repository.ErrInvalidEntity when entity has no non-zero fields.
+func (r *SQLRepository[TEntity, TID]) UpdatePartial(ctx context.Context, id TID, entity *TEntity) error {
+	excluded := []string{r.IDColumn()}
+	if r.createdAt != "" {
+		excluded = append(excluded, r.createdAt)
+	}
+	query, args := buildPartialUpdateQuery(
+		r.TableName(), r.IDColumn(), r.getDialect(), entity, any(id), excluded, r.updateStamps())
+	if query == "" {
+		return fmt.Errorf("%w: no non-zero fields to update", repository.ErrInvalidEntity)
 	}
-	return nil
+	return r.execAffectingOne(ctx, query, args)
 }
 
 // Delete removes an entity by its ID.
+// With WithSoftDelete, the soft-delete column is set to the current UTC time instead;
+// deleting an already soft-deleted row returns repository.ErrNotFound.
 func (r *SQLRepository[TEntity, TID]) Delete(ctx context.Context, id TID) error {
-	conn := r.GetConnection(ctx)
 	d := r.getDialect()
-	query := fmt.Sprintf("DELETE FROM %s WHERE %s = %s", r.TableName(), r.IDColumn(), d.Placeholder(1))
+	idColumn := trustedIdent(d, r.IDColumn())
+	query := fmt.Sprintf("DELETE FROM %s WHERE %s = %s", r.TableName(), idColumn, d.Placeholder(1))
 	args := []any{id}
+	if r.softDelete != "" {
+		softDelete := trustedIdent(d, r.softDelete)
+		query = fmt.Sprintf("UPDATE %s SET %s = %s WHERE %s = %s AND %s IS NULL",
+			r.TableName(), softDelete, d.Placeholder(1), idColumn, d.Placeholder(2), softDelete)
+		args = []any{time.Now().UTC(), id}
+	}
+	return r.execAffectingOne(ctx, query, args)
+}
+
+// execAffectingOne runs a write statement on the write connection and
+// returns repository.ErrNotFound when it affects no rows.
+func (r *SQLRepository[TEntity, TID]) execAffectingOne(ctx context.Context, query string, args []any) error {
+	conn := r.GetConnection(ctx)
 	r.logQuery(ctx, query, args)
 	result, err := conn.ExecContext(ctx, query, args...)
 	if err != nil {
@@ -198,38 +385,135 @@ func (r *SQLRepository[TEntity, TID]) Delete(ctx context.Context, id TID) error
 }
 
 // List retrieves entities with filtering and pagination and returns total count.
+// The count query is skipped (total 0) when opts.SkipCount is set. A nil opts lists the first page unfiltered.
+// With WithWindowCount the total is read from the list query where the dialect supports it.
 func (r *SQLRepository[TEntity, TID]) List(ctx context.Context, opts *repository.ListOptions) ([]*TEntity, int64, error) {
+	if opts == nil {
+		opts = &repository.ListOptions{}
+	}
+	if r.excludeDeleted(ctx, opts.IncludeDeleted) {
+		scoped := *opts
+		scoped.Filter = r.notDeletedFilter(opts.Filter)
+		opts = &scoped
+	}
+	var total int64 = 0
+	var windowTotal *int64
+	if !opts.SkipCount && r.windowCount && supportsWindowCount(r.getDialect()) {
+		windowTotal = &total
+	}
+	query, args := r.buildListQuery(opts, windowTotal != nil)
+	entities, err := r.queryEntitiesWithTotal(ctx, query, args, windowTotal)
+	if err != nil {
+		return nil, 0, err
+	}
+	// An empty page past the end carries no total; count separately.
+	pastEnd := len(entities) == 0 && normalizePagination(opts.Pagination).Offset > 0
+	if !opts.SkipCount && (windowTotal == nil || pastEnd) {
+		total, err = r.count(ctx, opts.Filter)
+		if err != nil {
+			return nil, 0, ConvertSQLError(err)
+		}
+	}
+	return entities, total, nil
+}
+
+// ListPage retrieves one page of entities and the total count (unless opts.SkipCount is set).
+// When opts.Sorts is set, the page is ordered by those columns plus the ID column as a tie-breaker and
+// NextCursor points after the last row; passing it back as opts.Pagination.Cursor continues with
+// keyset pagination (WHERE (sort columns) > (cursor values)) instead of an offset.
+// Without sorts, ListPage uses offset pagination and never returns a cursor.
+func (r *SQLRepository[TEntity, TID]) ListPage(
+	ctx context.Context, opts *repository.ListOptions,
+) (*repository.PagedResult[TEntity], error) {
+	if opts == nil {
+		opts = &repository.ListOptions{}
+	}
+	filter := opts.Filter
+	if r.excludeDeleted(ctx, opts.IncludeDeleted) {
+		filter = r.notDeletedFilter(filter)
+	}
+	pagination := normalizePagination(opts.Pagination)
+	sorts := keysetSorts(opts.Sorts, r.IDColumn())
+	var indexes [][]int
+	var cursorValues []any
+	if len(sorts) > 0 {
+		var err error
+		if indexes, err = sortFieldIndexes(r.entityType, sorts); err != nil {
+			return nil, err
+		}
+		if pagination.Cursor != "" {
+			if cursorValues, err = decodeCursor(pagination.Cursor, r.entityType, sorts, indexes); err != nil {
+				return nil, err
+			}
+			pagination.Offset = 0
+		}
+	}
+
+	query, args := r.buildPageQuery(filter, sorts, cursorValues, pagination)
+	entities, err := r.queryEntities(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+	result := &repository.PagedResult[TEntity]{Limit: pagination.Limit, Offset: pagination.Offset}
+	if len(entities) > pagination.Limit {
+		entities = entities[:pagination.Limit]
+		result.HasMore = true
+	}
+	result.Items = entities
+	if result.HasMore && len(sorts) > 0 {
+		last := reflect.ValueOf(entities[len(entities)-1]).Elem()
+		if result.NextCursor, err = encodeCursor(last, sorts, indexes); err != nil {
+			return nil, err
+		}
+	}
+	if !opts.SkipCount {
+		if result.Total, err = r.count(ctx, filter); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// queryEntities runs a SELECT on the read connection and scans every row into TEntity.
+func (r *SQLRepository[TEntity, TID]) queryEntities(ctx context.Context, query string, args []any) ([]*TEntity, error) {
+	return r.queryEntitiesWithTotal(ctx, query, args, nil)
+}
+
+// queryEntitiesWithTotal is queryEntities that also scans the windowTotalColumn into total when it is non-nil.
+func (r *SQLRepository[TEntity, TID]) queryEntitiesWithTotal(
+	ctx context.Context, query string, args []any, total *int64,
+) ([]*TEntity, error) {
 	conn := r.GetReadConnection(ctx)
-	query, args := r.buildListQuery(opts)
 	r.logQuery(ctx, query, args)
 	rows, err := conn.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, 0, ConvertSQLError(err)
+		return nil, ConvertSQLError(err)
 	}
 	defer rows.Close()
 	var entities []*TEntity
 	for rows.Next() {
-		entity, err := ScanRow[TEntity](rows)
+		entity, err := scanRow[TEntity](rows, total)
 		if err != nil {
-			return nil, 0, ConvertSQLError(err)
+			return nil, ConvertSQLError(err)
 		}
 		entities = append(entities, entity)
 	}
 	if err := rows.Err(); err != nil {
-		return nil, 0, ConvertSQLError(err)
-	}
-	var total int64 = 0
-	if !opts.SkipCount {
-		total, err = r.Count(ctx, opts.Filter)
-		if err != nil {
-			return nil, 0, ConvertSQLError(err)
-		}
+		return nil, ConvertSQLError(err)
 	}
-	return entities, total, nil
+	return entities, nil
 }
 
 // Count returns the total number of entities matching the filter.
 func (r *SQLRepository[TEntity, TID]) Count(ctx context.Context, filter repository.Filter) (int64, error) {
+	if r.excludeDeleted(ctx, false) {
+		filter = r.notDeletedFilter(filter)
+	}
+	return r.count(ctx, filter)
+}
+
+// count runs the count query for filter as given, without soft-delete scoping.
+func (r *SQLRepository[TEntity, TID]) count(ctx context.Context, filter repository.Filter) (int64, error) {
 	conn := r.GetReadConnection(ctx)
 	query, args := r.buildCountQuery(filter)
 	r.logQuery(ctx, query, args)
@@ -244,8 +528,7 @@ func (r *SQLRepository[TEntity, TID]) Count(ctx context.Context, filter reposito
 // Exists checks if an entity with given ID exists.
 func (r *SQLRepository[TEntity, TID]) Exists(ctx context.Context, id TID) (bool, error) {
 	conn := r.GetReadConnection(ctx)
-	d := r.getDialect()
-	query := fmt.Sprintf("SELECT EXISTS(SELECT 1 FROM %s WHERE %s = %s)", r.TableName(), r.IDColumn(), d.Placeholder(1))
+	query := fmt.Sprintf("SELECT EXISTS(SELECT 1 FROM %s WHERE %s)", r.TableName(), r.byIDCondition(ctx))
 	args := []any{id}
 	r.logQuery(ctx, query, args)
 	var exists bool
@@ -256,10 +539,16 @@ func (r *SQLRepository[TEntity, TID]) Exists(ctx context.Context, id TID) (bool,
 	return exists, nil
 }
 
-func (r *SQLRepository[TEntity, TID]) buildListQuery(opts *repository.ListOptions) (listQuery string, listArgs []any) {
-	sel := "*"
-	if len(r.selectColumns) > 0 {
-		sel = strings.Join(r.selectColumns, ", ")
+// windowTotalColumn is the alias of the COUNT(*) OVER() column added by WithWindowCount.
+const windowTotalColumn = "_total"
+
+// buildListQuery builds the List query; windowCount adds the COUNT(*) OVER() column.
+func (r *SQLRepository[TEntity, TID]) buildListQuery(
+	opts *repository.ListOptions, windowCount bool,
+) (listQuery string, listArgs []any) {
+	sel := r.selectClause()
+	if windowCount {
+		sel += ", COUNT(*) OVER() AS " + windowTotalColumn
 	}
 	query := fmt.Sprintf("SELECT %s FROM %s", sel, r.TableName())
 	var args []any
@@ -267,16 +556,16 @@ func (r *SQLRepository[TEntity, TID]) buildListQuery(opts *repository.ListOption
 	if opts == nil {
 		opts = &repository.ListOptions{}
 	}
-	whereClause, whereArgs := BuildWhereClause(d, opts.Filter)
+	whereClause, whereArgs, nextIdx := BuildWhereClauseFrom(d, opts.Filter, 1)
 	if whereClause != "" {
 		query += " " + whereClause
 		args = append(args, whereArgs...)
 	}
-	orderByClause := BuildOrderByClause(opts.Sorts)
+	orderByClause := BuildOrderByClauseFor(d, opts.Sorts)
 	if orderByClause != "" {
 		query += " " + orderByClause
 	}
-	paginationClause, paginationArgs := BuildPaginationClause(d, opts.Pagination)
+	paginationClause, paginationArgs := BuildPaginationClauseFrom(d, opts.Pagination, nextIdx)
 	if paginationClause != "" {
 		query += " " + paginationClause
 		args = append(args, paginationArgs...)
@@ -284,6 +573,34 @@ func (r *SQLRepository[TEntity, TID]) buildListQuery(opts *repository.ListOption
 	return query, args
 }
 
+// buildPageQuery builds the ListPage query. It fetches one row more than the limit to detect further pages,
+// and adds the keyset condition when cursor values are given.
+func (r *SQLRepository[TEntity, TID]) buildPageQuery(
+	filter repository.Filter, sorts []repository.Sort, cursorValues []any, pagination repository.Pagination,
+) (pageQuery string, pageArgs []any) {
+	d := r.getDialect()
+	query := fmt.Sprintf("SELECT %s FROM %s", r.selectClause(), r.TableName())
+	whereClause, args := BuildWhereClause(d, filter)
+	if cursorValues != nil {
+		cond, condArgs := keysetCondition(d, sorts, cursorValues, len(args)+1)
+		if whereClause == "" {
+			whereClause = "WHERE " + cond
+		} else {
+			whereClause += " AND " + cond
+		}
+		args = append(args, condArgs...)
+	}
+	if whereClause != "" {
+		query += " " + whereClause
+	}
+	if orderByClause := BuildOrderByClauseFor(d, sorts); orderByClause != "" {
+		query += " " + orderByClause
+	}
+	query += " " + d.PaginationClause(len(args)+1, len(args)+2)
+	args = append(args, pagination.Limit+1, pagination.Offset)
+	return query, args
+}
+
 func (r *SQLRepository[TEntity, TID]) buildCountQuery(filter repository.Filter) (countQuery string, countArgs []any) {
 	query := fmt.Sprintf("SELECT COUNT(*) FROM %s", r.TableName())
 	d := r.getDialect()
@@ -0,0 +1,650 @@
+package sql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/biairmal/go-sdk/repository"
+)
+
+type testUser struct {
+	ID        int64      `db:"id"`
+	Name      string     `db:"name"`
+	DeletedAt *time.Time `db:"deleted_at"`
+}
+
+func newTestRepo(
+	t *testing.T, opts ...SQLRepositoryOption[testUser, int64],
+) (*fakeBackend, repository.Repository[testUser, int64]) {
+	t.Helper()
+	backend, db := newFakeRepoDB(t)
+	return backend, NewSQLRepository[testUser, int64](nil, db, "users", opts...)
+}
+
+func TestSQLRepository_SoftDeleteDelete(t *testing.T) {
+	backend, repo := newTestRepo(t, WithSoftDelete[testUser, int64]("deleted_at"))
+
+	if err := repo.Delete(context.Background(), 7); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	got := backend.Last()
+	want := "UPDATE users SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL"
+	if got.Query != want {
+		t.Errorf("Delete() query = %q, want %q", got.Query, want)
+	}
+	if len(got.Args) != 2 {
+		t.Fatalf("Delete() args = %v, want [timestamp 7]", got.Args)
+	}
+	if _, ok := got.Args[0].(time.Time); !ok {
+		t.Errorf("Delete() first arg = %T, want time.Time", got.Args[0])
+	}
+	if got.Args[1] != int64(7) {
+		t.Errorf("Delete() id arg = %v, want 7", got.Args[1])
+	}
+
+	backend.rowsAffected = 0
+	if err := repo.Delete(context.Background(), 7); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("Delete() of deleted row error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestSQLRepository_HardDeleteWithoutOption(t *testing.T) {
+	backend, repo := newTestRepo(t)
+
+	if err := repo.Delete(context.Background(), 7); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if got, want := backend.Last().Query, "DELETE FROM users WHERE id = $1"; got != want {
+		t.Errorf("Delete() query = %q, want %q", got, want)
+	}
+}
+
+func TestSQLRepository_SoftDeleteReads(t *testing.T) {
+	filter := repository.Filter{Conditions: []repository.FilterCondition{
+		{Field: "name", Operator: repository.FilterOperatorEq, Value: "ann"},
+	}}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		call func(ctx context.Context, repo repository.Repository[testUser, int64]) error
+		want string
+	}{
+		{
+			name: "GetByID",
+			ctx:  context.Background(),
+			call: func(ctx context.Context, repo repository.Repository[testUser, int64]) error {
+				_, err := repo.GetByID(ctx, 1)
+				return err
+			},
+			want: "SELECT id, name, deleted_at FROM users WHERE id = $1 AND deleted_at IS NULL",
+		},
+		{
+			name: "Exists",
+			ctx:  context.Background(),
+			call: func(ctx context.Context, repo repository.Repository[testUser, int64]) error {
+				_, err := repo.Exists(ctx, 1)
+				return err
+			},
+			want: "SELECT EXISTS(SELECT 1 FROM users WHERE id = $1 AND deleted_at IS NULL)",
+		},
+		{
+			name: "Count",
+			ctx:  context.Background(),
+			call: func(ctx context.Context, repo repository.Repository[testUser, int64]) error {
+				_, err := repo.Count(ctx, filter)
+				return err
+			},
+			want: "SELECT COUNT(*) FROM users WHERE name = $1 AND deleted_at IS NULL",
+		},
+		{
+			name: "Count include deleted",
+			ctx:  repository.WithIncludeDeleted(context.Background()),
+			call: func(ctx context.Context, repo repository.Repository[testUser, int64]) error {
+				_, err := repo.Count(ctx, filter)
+				return err
+			},
+			want: "SELECT COUNT(*) FROM users WHERE name = $1",
+		},
+		{
+			name: "GetByID include deleted",
+			ctx:  repository.WithIncludeDeleted(context.Background()),
+			call: func(ctx context.Context, repo repository.Repository[testUser, int64]) error {
+				_, err := repo.GetByID(ctx, 1)
+				return err
+			},
+			want: "SELECT id, name, deleted_at FROM users WHERE id = $1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend, repo := newTestRepo(t, WithSoftDelete[testUser, int64]("deleted_at"))
+			backend.queryFn = func(query string) ([]string, [][]driver.Value) {
+				switch {
+				case strings.HasPrefix(query, "SELECT EXISTS"):
+					return []string{"exists"}, [][]driver.Value{{true}}
+				case strings.HasPrefix(query, "SELECT COUNT"):
+					return []string{"count"}, [][]driver.Value{{int64(1)}}
+				default:
+					return []string{"id", "name", "deleted_at"}, [][]driver.Value{{int64(1), "ann", nil}}
+				}
+			}
+			if err := tt.call(tt.ctx, repo); err != nil {
+				t.Fatalf("%s() error = %v", tt.name, err)
+			}
+			if got := backend.Last().Query; got != tt.want {
+				t.Errorf("%s() query = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSQLRepository_SoftDeleteList(t *testing.T) {
+	backend, repo := newTestRepo(t,
+		WithSoftDelete[testUser, int64]("deleted_at"),
+		WithDialect[testUser, int64](MySQL{}),
+	)
+	backend.queryFn = func(query string) ([]string, [][]driver.Value) {
+		if strings.HasPrefix(query, "SELECT COUNT") {
+			return []string{"count"}, [][]driver.Value{{int64(0)}}
+		}
+		return []string{"id"}, nil
+	}
+	filter := repository.Filter{Conditions: []repository.FilterCondition{
+		{Field: "name", Operator: repository.FilterOperatorEq, Value: "ann"},
+	}}
+
+	if _, _, err := repo.List(context.Background(), &repository.ListOptions{Filter: filter}); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	stmts := backend.Statements()
+	wantList := "SELECT id, name, deleted_at FROM users WHERE name = ? AND deleted_at IS NULL LIMIT ? OFFSET ?"
+	wantCount := "SELECT COUNT(*) FROM users WHERE name = ? AND deleted_at IS NULL"
+	if len(stmts) != 2 || stmts[0].Query != wantList || stmts[1].Query != wantCount {
+		t.Errorf("List() statements = %v, want [%q %q]", stmts, wantList, wantCount)
+	}
+	if len(filter.Conditions) != 1 {
+		t.Errorf("List() modified caller filter: %v", filter.Conditions)
+	}
+
+	opts := &repository.ListOptions{Filter: filter, IncludeDeleted: true, SkipCount: true}
+	if _, _, err := repo.List(context.Background(), opts); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	want := "SELECT id, name, deleted_at FROM users WHERE name = ? LIMIT ? OFFSET ?"
+	if got := backend.Last().Query; got != want {
+		t.Errorf("List() IncludeDeleted query = %q, want %q", got, want)
+	}
+}
+
+func TestSQLRepository_UpdatePartial(t *testing.T) {
+	backend, repo := newTestRepo(t)
+	patcher := repo.(repository.PartialUpdateRepository[testUser, int64])
+
+	if err := patcher.UpdatePartial(context.Background(), 3, &testUser{Name: "ann"}); err != nil {
+		t.Fatalf("UpdatePartial() error = %v", err)
+	}
+	if got, want := backend.Last().Query, "UPDATE users SET name = $1 WHERE id = $2"; got != want {
+		t.Errorf("UpdatePartial() query = %q, want %q", got, want)
+	}
+
+	if err := patcher.UpdatePartial(context.Background(), 3, &testUser{}); !errors.Is(err, repository.ErrInvalidEntity) {
+		t.Errorf("UpdatePartial() with zero entity error = %v, want ErrInvalidEntity", err)
+	}
+
+	backend.rowsAffected = 0
+	err := patcher.UpdatePartial(context.Background(), 3, &testUser{Name: "ann"})
+	if !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("UpdatePartial() of missing row error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestSQLRepository_SelectColumns(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []SQLRepositoryOption[testUser, int64]
+		want string
+	}{
+		{name: "mapped columns by default", want: "SELECT id, name, deleted_at FROM users WHERE id = $1"},
+		{
+			name: "explicit override",
+			opts: []SQLRepositoryOption[testUser, int64]{WithSelectColumns[testUser, int64]([]string{"id", "name"})},
+			want: "SELECT id, name FROM users WHERE id = $1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend, repo := newTestRepo(t, tt.opts...)
+			backend.queryFn = func(string) ([]string, [][]driver.Value) {
+				return []string{"id", "name"}, [][]driver.Value{{int64(1), "ann"}}
+			}
+			if _, err := repo.GetByID(context.Background(), 1); err != nil {
+				t.Fatalf("GetByID() error = %v", err)
+			}
+			if got := backend.Last().Query; got != tt.want {
+				t.Errorf("GetByID() query = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSQLRepository_GetByIDForUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect Dialect
+		want    string
+	}{
+		{name: "postgres", dialect: Postgres{}, want: "SELECT id, name, deleted_at FROM users WHERE id = $1 FOR UPDATE"},
+		{name: "mysql", dialect: MySQL{}, want: "SELECT id, name, deleted_at FROM users WHERE id = ? FOR UPDATE"},
+		{name: "sqlite no-op", dialect: SQLite{}, want: "SELECT id, name, deleted_at FROM users WHERE id = ?"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend, db := newFakeRepoDB(t)
+			repo := NewSQLRepository[testUser, int64](nil, db, "users", WithDialect[testUser, int64](tt.dialect))
+			locker := repo.(repository.LockingRepository[testUser, int64])
+			backend.queryFn = func(string) ([]string, [][]driver.Value) {
+				return []string{"id", "name"}, [][]driver.Value{{int64(1), "ann"}}
+			}
+
+			if _, err := locker.GetByIDForUpdate(context.Background(), 1); !errors.Is(err, repository.ErrTransactionRequired) {
+				t.Errorf("GetByIDForUpdate() outside transaction error = %v, want ErrTransactionRequired", err)
+			}
+			err := db.WithTransaction(context.Background(), func(ctx context.Context) error {
+				_, err := locker.GetByIDForUpdate(ctx, 1)
+				return err
+			})
+			if err != nil {
+				t.Fatalf("GetByIDForUpdate() error = %v", err)
+			}
+			stmts := backend.Statements()
+			if len(stmts) != 3 || stmts[1].Query != tt.want {
+				t.Errorf("GetByIDForUpdate() statements = %v, want BEGIN, %q, COMMIT", stmts, tt.want)
+			}
+		})
+	}
+}
+
+func TestSQLRepository_CreateConstraintErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{name: "postgres unique", err: &pgError{code: "23505"}, want: repository.ErrAlreadyExists},
+		{name: "mysql duplicate", err: &mysqlError{Number: 1062}, want: repository.ErrAlreadyExists},
+		{
+			name: "sqlite unique", err: errors.New("UNIQUE constraint failed: users.id"),
+			want: repository.ErrAlreadyExists,
+		},
+		{
+			name: "oracle unique", err: errors.New("ORA-00001: unique constraint (APP.USERS_PK) violated"),
+			want: repository.ErrAlreadyExists,
+		},
+		{name: "postgres not null", err: &pgError{code: "23502"}, want: repository.ErrInvalidEntity},
+		{name: "mysql check", err: &mysqlError{Number: 3819}, want: repository.ErrInvalidEntity},
+		{
+			name: "sqlite check", err: errors.New("CHECK constraint failed: name_not_empty"),
+			want: repository.ErrInvalidEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend, repo := newTestRepo(t)
+			backend.execErr = tt.err
+			ctx := context.Background()
+
+			if err := repo.Create(ctx, &testUser{ID: 1, Name: "ann"}); !errors.Is(err, tt.want) || !errors.Is(err, tt.err) {
+				t.Errorf("Create() error = %v, want %v wrapping the driver error", err, tt.want)
+			}
+			batch := repo.(repository.BatchRepository[testUser])
+			err := batch.CreateMany(ctx, []*testUser{{ID: 1, Name: "ann"}, {ID: 2, Name: "bob"}})
+			if !errors.Is(err, tt.want) || !errors.Is(err, tt.err) {
+				t.Errorf("CreateMany() error = %v, want %v wrapping the driver error", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSQLRepository_WithTx(t *testing.T) {
+	backend, db := newFakeRepoDB(t)
+	backend.queryFn = func(string) ([]string, [][]driver.Value) {
+		return []string{"id", "name"}, [][]driver.Value{{int64(1), "ann"}}
+	}
+	repo := NewSQLRepository[testUser, int64](nil, db, "users")
+	ctx := context.Background()
+	tx, err := db.Leader().BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatalf("BeginTx() error = %v", err)
+	}
+	txRepo := repo.(repository.TransactionalRepository[testUser, int64]).WithTx(tx)
+
+	if err := txRepo.Create(ctx, &testUser{ID: 1, Name: "ann"}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	batch := txRepo.(repository.BatchRepository[testUser])
+	if err := batch.CreateMany(ctx, []*testUser{{ID: 2, Name: "bob"}}); err != nil {
+		t.Fatalf("CreateMany() error = %v", err)
+	}
+	locker := txRepo.(repository.LockingRepository[testUser, int64])
+	if _, err := locker.GetByIDForUpdate(ctx, 1); err != nil {
+		t.Fatalf("GetByIDForUpdate() error = %v", err)
+	}
+	if _, err := repo.GetByID(ctx, 1); err != nil {
+		t.Fatalf("GetByID() on the unbound repository error = %v", err)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+
+	stmts := backend.Statements()
+	if len(stmts) != 6 || stmts[0].Query != "BEGIN" || stmts[5].Query != "ROLLBACK" {
+		t.Fatalf("statements = %v, want BEGIN, 3 statements in tx, 1 outside, ROLLBACK", stmts)
+	}
+	txConn := stmts[0].Conn
+	for _, st := range stmts[1:4] {
+		if st.Conn != txConn {
+			t.Errorf("%q ran on connection %d, want the transaction's %d", st.Query, st.Conn, txConn)
+		}
+	}
+	if stmts[4].Conn == txConn {
+		t.Errorf("unbound repository ran %q inside the transaction", stmts[4].Query)
+	}
+}
+
+func TestSQLRepository_ListPlaceholders(t *testing.T) {
+	filter := repository.Filter{Conditions: []repository.FilterCondition{
+		{Field: "name", Operator: repository.FilterOperatorEq, Value: "ann"},
+		{Field: "age", Operator: repository.FilterOperatorGt, Value: 18},
+	}}
+
+	tests := []struct {
+		name    string
+		dialect Dialect
+		want    string
+	}{
+		{
+			name: "postgres", dialect: Postgres{},
+			want: "SELECT id, name, deleted_at FROM users WHERE name = $1 AND age > $2 LIMIT $3 OFFSET $4",
+		},
+		{
+			name: "mysql", dialect: MySQL{},
+			want: "SELECT id, name, deleted_at FROM users WHERE name = ? AND age > ? LIMIT ? OFFSET ?",
+		},
+		{
+			name: "oracle", dialect: Oracle{},
+			want: "SELECT id, name, deleted_at FROM users WHERE name = :1 AND age > :2 OFFSET :4 ROWS FETCH NEXT :3 ROWS ONLY",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend, repo := newTestRepo(t, WithDialect[testUser, int64](tt.dialect))
+			opts := &repository.ListOptions{
+				Filter:     filter,
+				Pagination: repository.Pagination{Limit: 10, Offset: 20},
+				SkipCount:  true,
+			}
+			if _, _, err := repo.List(context.Background(), opts); err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			got := backend.Last()
+			if got.Query != tt.want {
+				t.Errorf("List() query = %q, want %q", got.Query, tt.want)
+			}
+			wantArgs := []any{"ann", int64(18), int64(10), int64(20)}
+			if !reflect.DeepEqual(got.Args, wantArgs) {
+				t.Errorf("List() args = %v, want %v", got.Args, wantArgs)
+			}
+		})
+	}
+}
+
+func TestSQLRepository_ListCount(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      *repository.ListOptions
+		wantTotal int64
+		wantStmts int
+	}{
+		{name: "nil options", opts: nil, wantTotal: 42, wantStmts: 2},
+		{name: "with count", opts: &repository.ListOptions{}, wantTotal: 42, wantStmts: 2},
+		{name: "skip count", opts: &repository.ListOptions{SkipCount: true}, wantTotal: 0, wantStmts: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend, repo := newTestRepo(t)
+			backend.queryFn = func(query string) ([]string, [][]driver.Value) {
+				if strings.HasPrefix(query, "SELECT COUNT") {
+					return []string{"count"}, [][]driver.Value{{int64(42)}}
+				}
+				return []string{"id", "name"}, [][]driver.Value{{int64(1), "ann"}}
+			}
+			items, total, err := repo.List(context.Background(), tt.opts)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			if len(items) != 1 || total != tt.wantTotal {
+				t.Errorf("List() = %d items, total %d, want 1, %d", len(items), total, tt.wantTotal)
+			}
+			if n := len(backend.Statements()); n != tt.wantStmts {
+				t.Errorf("List() ran %d statements, want %d", n, tt.wantStmts)
+			}
+		})
+	}
+}
+
+func TestSQLRepository_WithWindowCount(t *testing.T) {
+	const windowQuery = "SELECT id, name, deleted_at, COUNT(*) OVER() AS _total FROM users LIMIT $1 OFFSET $2"
+	tests := []struct {
+		name      string
+		dialect   Dialect
+		opts      *repository.ListOptions
+		rows      [][]driver.Value
+		wantTotal int64
+		wantStmts []string
+	}{
+		{
+			name: "postgres", dialect: Postgres{}, opts: &repository.ListOptions{},
+			rows: [][]driver.Value{{int64(1), "ann", int64(42)}, {int64(2), "bob", int64(42)}}, wantTotal: 42,
+			wantStmts: []string{windowQuery},
+		},
+		{
+			name: "postgres empty first page", dialect: Postgres{}, opts: &repository.ListOptions{},
+			wantTotal: 0, wantStmts: []string{windowQuery},
+		},
+		{
+			name: "postgres past the end", dialect: Postgres{},
+			opts:      &repository.ListOptions{Pagination: repository.Pagination{Limit: 20, Offset: 100}},
+			wantTotal: 7, wantStmts: []string{windowQuery, "SELECT COUNT(*) FROM users"},
+		},
+		{
+			name: "quoted postgres", dialect: QuoteIdentifiers(Postgres{}), opts: &repository.ListOptions{},
+			rows: [][]driver.Value{{int64(1), "ann", int64(3)}}, wantTotal: 3,
+			wantStmts: []string{
+				`SELECT "id", "name", "deleted_at", COUNT(*) OVER() AS _total FROM users LIMIT $1 OFFSET $2`,
+			},
+		},
+		{
+			name: "skip count", dialect: Postgres{}, opts: &repository.ListOptions{SkipCount: true},
+			rows: [][]driver.Value{{int64(1), "ann", nil}}, wantTotal: 0,
+			wantStmts: []string{"SELECT id, name, deleted_at FROM users LIMIT $1 OFFSET $2"},
+		},
+		{
+			name: "mysql falls back", dialect: MySQL{}, opts: &repository.ListOptions{},
+			rows: [][]driver.Value{{int64(1), "ann", nil}}, wantTotal: 7,
+			wantStmts: []string{"SELECT id, name, deleted_at FROM users LIMIT ? OFFSET ?", "SELECT COUNT(*) FROM users"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend, repo := newTestRepo(t,
+				WithDialect[testUser, int64](tt.dialect), WithWindowCount[testUser, int64]())
+			backend.queryFn = func(query string) ([]string, [][]driver.Value) {
+				if strings.HasPrefix(query, "SELECT COUNT") {
+					return []string{"count"}, [][]driver.Value{{int64(7)}}
+				}
+				if strings.Contains(query, "_total") {
+					return []string{"id", "name", "_total"}, tt.rows
+				}
+				return []string{"id", "name", "deleted_at"}, tt.rows
+			}
+			items, total, err := repo.List(context.Background(), tt.opts)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			if len(items) != len(tt.rows) || total != tt.wantTotal {
+				t.Errorf("List() = %d items, total %d, want %d, %d", len(items), total, len(tt.rows), tt.wantTotal)
+			}
+			var got []string
+			for _, st := range backend.Statements() {
+				got = append(got, st.Query)
+			}
+			if !reflect.DeepEqual(got, tt.wantStmts) {
+				t.Errorf("List() statements = %q, want %q", got, tt.wantStmts)
+			}
+		})
+	}
+}
+
+type embeddedUser struct {
+	*AuditBase
+	Name string `db:"name"`
+}
+
+func TestSQLRepository_EmbeddedBase(t *testing.T) {
+	backend, db := newFakeRepoDB(t)
+	repo := NewSQLRepository[embeddedUser, int64](nil, db, "users")
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	backend.queryFn = func(string) ([]string, [][]driver.Value) {
+		return []string{"id", "created_at", "name"}, [][]driver.Value{{int64(5), created, "ann"}}
+	}
+
+	got, err := repo.GetByID(context.Background(), 5)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if want := "SELECT id, created_at, name FROM users WHERE id = $1"; backend.Last().Query != want {
+		t.Errorf("GetByID() query = %q, want %q", backend.Last().Query, want)
+	}
+	if got.AuditBase == nil || got.ID != 5 || !got.CreatedAt.Equal(created) || got.Name != "ann" {
+		t.Errorf("GetByID() = %+v, want embedded ID 5 and CreatedAt %v", got, created)
+	}
+
+	// A nil embedded pointer reads as zero values instead of panicking.
+	if err := repo.Update(context.Background(), 5, &embeddedUser{Name: "bob"}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	last := backend.Last()
+	if want := "UPDATE users SET created_at = $1, name = $2 WHERE id = $3"; last.Query != want {
+		t.Errorf("Update() query = %q, want %q", last.Query, want)
+	}
+	if len(last.Args) != 3 || last.Args[1] != "bob" || last.Args[2] != int64(5) {
+		t.Errorf("Update() args = %v, want [zero time bob 5]", last.Args)
+	}
+}
+
+type stampedUser struct {
+	ID        int64     `db:"id"`
+	Name      string    `db:"name"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func TestSQLRepository_WithTimestamps(t *testing.T) {
+	backend, db := newFakeRepoDB(t)
+	repo := NewSQLRepository[stampedUser, int64](nil, db, "users",
+		WithTimestamps[stampedUser, int64]("created_at", "updated_at"))
+	ctx := context.Background()
+	stale := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	steps := []struct {
+		name     string
+		run      func() error
+		want     string
+		wantArgs []any
+	}{
+		{
+			name: "Create",
+			run:  func() error { return repo.Create(ctx, &stampedUser{ID: 1, Name: "ann", CreatedAt: stale}) },
+			want: "INSERT INTO users (id, name, created_at, updated_at) " +
+				"VALUES ($1, $2, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)",
+			wantArgs: []any{int64(1), "ann"},
+		},
+		{
+			name:     "Update",
+			run:      func() error { return repo.Update(ctx, 1, &stampedUser{Name: "bob", CreatedAt: stale}) },
+			want:     "UPDATE users SET name = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2",
+			wantArgs: []any{"bob", int64(1)},
+		},
+		{
+			name: "UpdatePartial",
+			run: func() error {
+				patcher := repo.(repository.PartialUpdateRepository[stampedUser, int64])
+				return patcher.UpdatePartial(ctx, 1, &stampedUser{Name: "cat", CreatedAt: stale, UpdatedAt: stale})
+			},
+			want:     "UPDATE users SET name = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2",
+			wantArgs: []any{"cat", int64(1)},
+		},
+		{
+			name: "CreateMany",
+			run: func() error {
+				batch := repo.(repository.BatchRepository[stampedUser])
+				return batch.CreateMany(ctx, []*stampedUser{{ID: 2, Name: "dan"}, {ID: 3, Name: "eve"}})
+			},
+			want: "INSERT INTO users (id, name, created_at, updated_at) VALUES " +
+				"($1, $2, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP), ($3, $4, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)",
+			wantArgs: []any{int64(2), "dan", int64(3), "eve"},
+		},
+	}
+	for _, step := range steps {
+		if err := step.run(); err != nil {
+			t.Fatalf("%s() error = %v", step.name, err)
+		}
+		got := backend.Last()
+		if step.name == "CreateMany" {
+			stmts := backend.Statements()
+			got = stmts[len(stmts)-2] // before COMMIT
+		}
+		if got.Query != step.want {
+			t.Errorf("%s() query = %q, want %q", step.name, got.Query, step.want)
+		}
+		if !reflect.DeepEqual(got.Args, step.wantArgs) {
+			t.Errorf("%s() args = %v, want %v", step.name, got.Args, step.wantArgs)
+		}
+	}
+}
+
+func TestSQLRepository_WithTimestampsUnmappedColumns(t *testing.T) {
+	backend, repo := newTestRepo(t,
+		WithTimestamps[testUser, int64]("created_at", "updated_at"),
+		WithDialect[testUser, int64](MySQL{}))
+	ctx := context.Background()
+
+	if err := repo.Create(ctx, &testUser{ID: 1, Name: "ann"}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	want := "INSERT INTO users (id, name, deleted_at, created_at, updated_at) " +
+		"VALUES (?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)"
+	if got := backend.Last().Query; got != want {
+		t.Errorf("Create() query = %q, want %q", got, want)
+	}
+
+	if err := repo.Update(ctx, 1, &testUser{Name: "bob"}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	want = "UPDATE users SET name = ?, deleted_at = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?"
+	if got := backend.Last().Query; got != want {
+		t.Errorf("Update() query = %q, want %q", got, want)
+	}
+}
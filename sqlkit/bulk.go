@@ -0,0 +1,167 @@
+package sqlkit
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// bulkInsertFunc inserts rows into table within tx and returns the number of rows inserted.
+type bulkInsertFunc func(ctx context.Context, tx *sql.Tx, table string, columns []string, rows [][]any) (int64, error)
+
+// BulkInsert inserts rows into table in one round trip per batch.
+// postgres (lib/pq): uses COPY FROM STDIN.
+// pgx, cloudsqlpostgres, mysql, sqlite3: uses multi-row INSERT ... VALUES (...), (...)
+// batches, split to stay under the driver's bind parameter limit.
+// Runs on the transaction in ctx if present; otherwise all batches run in a new
+// transaction on the leader, so the insert is all-or-nothing.
+// Each row must have exactly len(columns) values. Table and column names are quoted
+// (a dotted table name is treated as schema.table) and must not come from user input.
+// Returns the number of rows inserted, or ErrUnsupportedDriver for other drivers.
+func (db *DB) BulkInsert(ctx context.Context, table string, columns []string, rows [][]any) (int64, error) {
+	if table == "" || len(columns) == 0 {
+		return 0, fmt.Errorf("sqlkit: bulk insert requires a table and at least one column")
+	}
+	for i, row := range rows {
+		if len(row) != len(columns) {
+			return 0, fmt.Errorf("sqlkit: bulk insert row %d has %d values, want %d", i, len(row), len(columns))
+		}
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+
+	insert := db.bulkInserter()
+	if insert == nil {
+		return 0, fmt.Errorf("%w: bulk insert for %q", ErrUnsupportedDriver, db.driver)
+	}
+
+	if tx, ok := ExtractTx(ctx); ok {
+		return insert(ctx, tx, table, columns, rows)
+	}
+
+	var inserted int64
+	err := db.WithTransaction(ctx, func(txCtx context.Context) error {
+		tx, _ := ExtractTx(txCtx)
+		var err error
+		inserted, err = insert(txCtx, tx, table, columns, rows)
+		return err
+	})
+	return inserted, err
+}
+
+// bulkInserter returns the bulk insert strategy for the driver, or nil if unsupported.
+func (db *DB) bulkInserter() bulkInsertFunc {
+	switch db.driver {
+	case "postgres":
+		return copyIn
+	case "pgx", "cloudsqlpostgres":
+		return multiRowInserter(quoteDoubleQuoted, func(i int) string { return "$" + strconv.Itoa(i) }, 65535)
+	case "mysql":
+		return multiRowInserter(quoteBacktick, func(int) string { return "?" }, 65535)
+	case "sqlite3":
+		return multiRowInserter(quoteDoubleQuoted, func(int) string { return "?" }, 32766)
+	default:
+		return nil
+	}
+}
+
+// copyIn streams rows with lib/pq's COPY FROM STDIN protocol: the prepared COPY
+// statement is executed once per row, then once without arguments to flush.
+func copyIn(ctx context.Context, tx *sql.Tx, table string, columns []string, rows [][]any) (int64, error) {
+	query := fmt.Sprintf("COPY %s (%s) FROM STDIN", quoteDoubleQuoted(table), quoteColumns(quoteDoubleQuoted, columns))
+
+	stmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("sqlkit: failed to prepare copy: %w", err)
+	}
+	defer stmt.Close()
+
+	for i, row := range rows {
+		if _, err := stmt.ExecContext(ctx, row...); err != nil {
+			return 0, fmt.Errorf("sqlkit: copy row %d: %w", i, err)
+		}
+	}
+
+	result, err := stmt.ExecContext(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("sqlkit: failed to flush copy: %w", err)
+	}
+	if n, err := result.RowsAffected(); err == nil {
+		return n, nil
+	}
+	return int64(len(rows)), nil
+}
+
+// multiRowInserter returns a bulkInsertFunc that batches rows into multi-row INSERT
+// statements with at most maxParams bind parameters each.
+func multiRowInserter(quote func(string) string, placeholder func(int) string, maxParams int) bulkInsertFunc {
+	return func(ctx context.Context, tx *sql.Tx, table string, columns []string, rows [][]any) (int64, error) {
+		prefix := fmt.Sprintf("INSERT INTO %s (%s) VALUES ", quote(table), quoteColumns(quote, columns))
+		batchSize := max(maxParams/len(columns), 1)
+
+		var inserted int64
+		for start := 0; start < len(rows); start += batchSize {
+			batch := rows[start:min(start+batchSize, len(rows))]
+
+			var b strings.Builder
+			b.WriteString(prefix)
+			args := make([]any, 0, len(batch)*len(columns))
+			for i, row := range batch {
+				if i > 0 {
+					b.WriteString(", ")
+				}
+				b.WriteByte('(')
+				for j, v := range row {
+					if j > 0 {
+						b.WriteString(", ")
+					}
+					args = append(args, v)
+					b.WriteString(placeholder(len(args)))
+				}
+				b.WriteByte(')')
+			}
+
+			result, err := tx.ExecContext(ctx, b.String(), args...)
+			if err != nil {
+				return 0, fmt.Errorf("sqlkit: bulk insert rows %d-%d: %w", start, start+len(batch)-1, err)
+			}
+			if n, err := result.RowsAffected(); err == nil {
+				inserted += n
+			} else {
+				inserted += int64(len(batch))
+			}
+		}
+		return inserted, nil
+	}
+}
+
+// quoteColumns quotes and comma-joins column names.
+func quoteColumns(quote func(string) string, columns []string) string {
+	quoted := make([]string, len(columns))
+	for i, c := range columns {
+		quoted[i] = quote(c)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// quoteDoubleQuoted quotes a (possibly schema-qualified) identifier with double quotes.
+func quoteDoubleQuoted(ident string) string {
+	return quoteIdentifier(ident, `"`)
+}
+
+// quoteBacktick quotes a (possibly schema-qualified) identifier with backticks (MySQL).
+func quoteBacktick(ident string) string {
+	return quoteIdentifier(ident, "`")
+}
+
+// quoteIdentifier quotes each dot-separated part of ident with q, doubling embedded quotes.
+func quoteIdentifier(ident, q string) string {
+	parts := strings.Split(ident, ".")
+	for i, p := range parts {
+		parts[i] = q + strings.ReplaceAll(p, q, q+q) + q
+	}
+	return strings.Join(parts, ".")
+}
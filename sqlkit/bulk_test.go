@@ -0,0 +1,181 @@
+package sqlkit
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"slices"
+	"testing"
+)
+
+// argCounts records the number of bind arguments of each executed statement.
+// Every statement reports 2 rows affected, the size of the test data sets.
+func argCounts(backend *fakeBackend) *[]int {
+	counts := &[]int{}
+	backend.execFn = func(_ string, args []driver.NamedValue) (driver.Result, error) {
+		*counts = append(*counts, len(args))
+		return driver.RowsAffected(2), nil
+	}
+	return counts
+}
+
+func TestDB_BulkInsert(t *testing.T) {
+	columns := []string{"id", "name"}
+	rows := [][]any{{1, "a"}, {2, "b"}}
+
+	tests := []struct {
+		name      string
+		driver    string
+		want      []string
+		wantArgs  []int
+		wantCount int64
+	}{
+		{
+			name:   "postgres uses copy",
+			driver: "postgres",
+			want: []string{
+				"BEGIN",
+				`PREPARE COPY "users" ("id", "name") FROM STDIN`,
+				`COPY "users" ("id", "name") FROM STDIN`,
+				`COPY "users" ("id", "name") FROM STDIN`,
+				`COPY "users" ("id", "name") FROM STDIN`,
+				"COMMIT",
+			},
+			wantArgs:  []int{2, 2, 0},
+			wantCount: 2,
+		},
+		{
+			name:      "pgx uses multi-row insert",
+			driver:    "pgx",
+			want:      []string{"BEGIN", `INSERT INTO "users" ("id", "name") VALUES ($1, $2), ($3, $4)`, "COMMIT"},
+			wantArgs:  []int{4},
+			wantCount: 2,
+		},
+		{
+			name:      "mysql uses backticks",
+			driver:    "mysql",
+			want:      []string{"BEGIN", "INSERT INTO `users` (`id`, `name`) VALUES (?, ?), (?, ?)", "COMMIT"},
+			wantArgs:  []int{4},
+			wantCount: 2,
+		},
+		{
+			name:      "sqlite3",
+			driver:    "sqlite3",
+			want:      []string{"BEGIN", `INSERT INTO "users" ("id", "name") VALUES (?, ?), (?, ?)`, "COMMIT"},
+			wantArgs:  []int{4},
+			wantCount: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend, leader := newFakeDB(t)
+			counts := argCounts(backend)
+			db := newTestDB(Config{Leader: DBConfig{Driver: tt.driver}}, leader)
+
+			n, err := db.BulkInsert(context.Background(), "users", columns, rows)
+			if err != nil {
+				t.Fatalf("BulkInsert() error = %v", err)
+			}
+			if n != tt.wantCount {
+				t.Errorf("BulkInsert() = %d, want %d", n, tt.wantCount)
+			}
+			if got := backend.Queries(); !slices.Equal(got, tt.want) {
+				t.Errorf("statements = %q, want %q", got, tt.want)
+			}
+			if !slices.Equal(*counts, tt.wantArgs) {
+				t.Errorf("argument counts = %v, want %v", *counts, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestDB_BulkInsert_UsesContextTransaction(t *testing.T) {
+	backend, leader := newFakeDB(t)
+	db := newTestDB(Config{Leader: DBConfig{Driver: "mysql"}}, leader)
+
+	err := db.WithTransaction(context.Background(), func(ctx context.Context) error {
+		_, err := db.BulkInsert(ctx, "users", []string{"id"}, [][]any{{1}})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("BulkInsert() error = %v", err)
+	}
+
+	want := []string{"BEGIN", "INSERT INTO `users` (`id`) VALUES (?)", "COMMIT"}
+	if got := backend.Queries(); !slices.Equal(got, want) {
+		t.Errorf("statements = %q, want %q", got, want)
+	}
+}
+
+func TestDB_BulkInsert_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		driver  string
+		columns []string
+		rows    [][]any
+		wantErr error
+	}{
+		{name: "unsupported driver", driver: "sqlserver", columns: []string{"id"}, rows: [][]any{{1}},
+			wantErr: ErrUnsupportedDriver},
+		{name: "no columns", driver: "mysql", rows: [][]any{{1}}},
+		{name: "row length mismatch", driver: "mysql", columns: []string{"id", "name"}, rows: [][]any{{1}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend, leader := newFakeDB(t)
+			db := newTestDB(Config{Leader: DBConfig{Driver: tt.driver}}, leader)
+
+			_, err := db.BulkInsert(context.Background(), "users", tt.columns, tt.rows)
+			if err == nil {
+				t.Fatal("BulkInsert() error = nil, want error")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("BulkInsert() error = %v, want %v", err, tt.wantErr)
+			}
+			if got := backend.Queries(); len(got) != 0 {
+				t.Errorf("statements = %q, want none", got)
+			}
+		})
+	}
+}
+
+func TestMultiRowInserter_Batches(t *testing.T) {
+	backend, leader := newFakeDB(t)
+	counts := argCounts(backend)
+	db := newTestDB(Config{}, leader)
+
+	insert := multiRowInserter(quoteDoubleQuoted, func(int) string { return "?" }, 4)
+	err := db.WithTransaction(context.Background(), func(ctx context.Context) error {
+		tx, _ := ExtractTx(ctx)
+		_, err := insert(ctx, tx, "t", []string{"a", "b"}, [][]any{{1, 2}, {3, 4}, {5, 6}})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("insert() error = %v", err)
+	}
+
+	if want := []int{4, 2}; !slices.Equal(*counts, want) {
+		t.Errorf("argument counts = %v, want %v", *counts, want)
+	}
+}
+
+func TestQuoteIdentifier(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{in: "users", want: `"users"`},
+		{in: "public.users", want: `"public"."users"`},
+		{in: `we"ird`, want: `"we""ird"`},
+	}
+
+	for _, tt := range tests {
+		if got := quoteDoubleQuoted(tt.in); got != tt.want {
+			t.Errorf("quoteDoubleQuoted(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+	if got := quoteBacktick("app.users"); got != "`app`.`users`" {
+		t.Errorf("quoteBacktick(app.users) = %q, want %q", got, "`app`.`users`")
+	}
+}
@@ -2,7 +2,10 @@ package sqlkit
 
 import (
 	"fmt"
+	"maps"
 	"net/url"
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -12,6 +15,17 @@ type Config struct {
 	Followers []DBConfig   // Follower (read) database configurations (optional)
 	Pool      PoolConfig   // Connection pool settings
 	Health    HealthConfig // Health check settings
+	Retry     RetryConfig  // Backoff for connection and ExecWithRetry/QueryWithRetry retries
+	OnQuery   QueryHook    // Called after each statement run via LeaderConn/FollowerConn (optional)
+
+	// Named holds auxiliary connections by name, e.g. "analytics" for a reporting
+	// warehouse (optional). They are opened and health-checked like followers but
+	// only used through DB.Named and DB.NamedConn. Ignored by NewWithConns.
+	Named map[string]DBConfig
+
+	// DefaultQueryTimeout bounds each statement run through a Conn (LeaderConn,
+	// FollowerConn, ReaderConn, ExecWithRetry, QueryWithRetry). Zero means no timeout.
+	DefaultQueryTimeout time.Duration
 }
 
 // Validate validates the configuration.
@@ -22,44 +36,51 @@ func (c *Config) Validate() error {
 	if c.Leader.Driver == "" {
 		return fmt.Errorf("%w: leader driver is required", ErrInvalidConfig)
 	}
-	if c.Leader.Host == "" {
+	if c.Leader.Host == "" && c.Leader.Driver != "sqlite3" {
 		return fmt.Errorf("%w: leader host is required", ErrInvalidConfig)
 	}
 	if c.Leader.Database == "" {
 		return fmt.Errorf("%w: leader database is required", ErrInvalidConfig)
 	}
+	for name, named := range c.Named {
+		if err := validateNamedConnection(name, &named); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
 // DBConfig is the configuration for a single database connection.
 type DBConfig struct {
-	Driver         string        // Database driver: "postgres", "mysql", "sqlite3"
-	Host           string        // Database host
-	Port           int           // Database port
-	Database       string        // Database name
-	Username       string        // Database username
-	Password       string        // Database password
-	SSLMode        string        // SSL mode: "disable", "require", "verify-ca", "verify-full" (postgres)
-	ConnectTimeout time.Duration // Connection timeout (default: 5s)
-	MaxRetries     int           // Maximum connection retry attempts (default: 3)
+	Driver         string            // Database driver: "postgres", "pgx", "cloudsqlpostgres", "mysql", "sqlite3"
+	Host           string            // Database host
+	Port           int               // Database port
+	Database       string            // Database name (sqlite3: file path, or SQLiteMemory / SQLiteMemory+"name")
+	Username       string            // Database username
+	Password       string            // Database password
+	SSLMode        string            // SSL mode: "disable", "require", "verify-ca", "verify-full" (postgres)
+	SSLRootCert    string            // Path to the root CA certificate (postgres sslrootcert, optional)
+	SSLCert        string            // Path to the client certificate (postgres sslcert, optional)
+	SSLKey         string            // Path to the client private key (postgres sslkey, optional)
+	ConnectTimeout time.Duration     // Connection timeout (default: 5s)
+	MaxRetries     int               // Maximum connection retry attempts (default: 3)
+	Options        map[string]string // Extra driver parameters appended to the DSN (optional)
 }
 
 // DSN generates a database-specific connection string.
-// Supports PostgreSQL and MySQL at minimum.
+// Supports PostgreSQL (postgres, pgx, cloudsqlpostgres), MySQL and SQLite3.
 // Handles URL encoding for special characters in password.
+// Options are appended in key order: as key=value pairs for postgres, as query
+// parameters for mysql and sqlite3, and as key=value; pairs for other drivers.
+// For sqlite3, a Database of SQLiteMemory or SQLiteMemory+"name" selects a shared
+// in-memory database (see SQLiteMemory).
 func (c *DBConfig) DSN() string {
 	// URL encode password to handle special characters
 	encodedPassword := url.QueryEscape(c.Password)
 
 	switch c.Driver {
-	case "postgres":
-		timeoutSeconds := int(c.ConnectTimeout.Seconds())
-		if timeoutSeconds == 0 {
-			timeoutSeconds = 5 // default
-		}
-		return fmt.Sprintf(
-			"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s connect_timeout=%d",
-			c.Host, c.Port, c.Username, encodedPassword, c.Database, c.SSLMode, timeoutSeconds)
+	case "postgres", "pgx", "cloudsqlpostgres":
+		return c.postgresDSN(encodedPassword)
 	case "mysql":
 		timeoutStr := c.ConnectTimeout.String()
 		if timeoutStr == "0s" {
@@ -67,24 +88,91 @@ func (c *DBConfig) DSN() string {
 		}
 		return fmt.Sprintf(
 			"%s:%s@tcp(%s:%d)/%s?parseTime=true&timeout=%s",
-			c.Username, encodedPassword, c.Host, c.Port, c.Database, timeoutStr)
+			c.Username, encodedPassword, c.Host, c.Port, c.Database, timeoutStr) + c.queryOptions()
 	case "sqlite3":
 		timeoutMs := int(c.ConnectTimeout.Milliseconds())
 		if timeoutMs == 0 {
 			timeoutMs = 5000 // default 5 seconds
 		}
-		return fmt.Sprintf(
-			"file:%s?mode=rwc&cache=shared&_busy_timeout=%d",
-			c.Database, timeoutMs)
+		return sqliteDSN(c.Database, timeoutMs) + c.queryOptions()
 	default:
 		timeoutSeconds := int(c.ConnectTimeout.Seconds())
 		if timeoutSeconds == 0 {
 			timeoutSeconds = 5 // default
 		}
-		return fmt.Sprintf(
+		dsn := fmt.Sprintf(
 			"driver=%s;host=%s;port=%d;database=%s;user id=%s;password=%s;connect timeout=%d",
 			c.Driver, c.Host, c.Port, c.Database, c.Username, encodedPassword, timeoutSeconds)
+		for _, k := range slices.Sorted(maps.Keys(c.Options)) {
+			dsn += ";" + k + "=" + c.Options[k]
+		}
+		return dsn
+	}
+}
+
+// SQLiteMemory is the sqlite3 Database value for an in-memory database.
+// Every connection opened in the process with SQLiteMemory shares one database
+// (file::memory:?cache=shared); SQLiteMemory+"name" (e.g. ":memory:orders")
+// shares a database per name (file:name?mode=memory&cache=shared), so tests can
+// use one each. Give followers the same Database as the leader to read what it
+// writes. The database is dropped when its last connection closes, so keep
+// Pool.MaxIdleConns at 1 or more and disable ConnMaxLifetime and ConnMaxIdleTime.
+const SQLiteMemory = ":memory:"
+
+// sqliteDSN builds a sqlite3 file URI for database.
+func sqliteDSN(database string, busyTimeoutMs int) string {
+	if name, ok := strings.CutPrefix(database, SQLiteMemory); ok {
+		if name == "" {
+			return fmt.Sprintf("file::memory:?cache=shared&_busy_timeout=%d", busyTimeoutMs)
+		}
+		return fmt.Sprintf("file:%s?mode=memory&cache=shared&_busy_timeout=%d", name, busyTimeoutMs)
 	}
+	return fmt.Sprintf("file:%s?mode=rwc&cache=shared&_busy_timeout=%d", database, busyTimeoutMs)
+}
+
+// postgresDSN builds a libpq key/value connection string.
+func (c *DBConfig) postgresDSN(encodedPassword string) string {
+	timeoutSeconds := int(c.ConnectTimeout.Seconds())
+	if timeoutSeconds == 0 {
+		timeoutSeconds = 5 // default
+	}
+	dsn := fmt.Sprintf(
+		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s connect_timeout=%d",
+		c.Host, c.Port, c.Username, encodedPassword, c.Database, c.SSLMode, timeoutSeconds)
+
+	tls := []struct{ key, value string }{
+		{"sslrootcert", c.SSLRootCert},
+		{"sslcert", c.SSLCert},
+		{"sslkey", c.SSLKey},
+	}
+	for _, p := range tls {
+		if p.value != "" {
+			dsn += " " + p.key + "=" + quotePostgresValue(p.value)
+		}
+	}
+	for _, k := range slices.Sorted(maps.Keys(c.Options)) {
+		dsn += " " + k + "=" + quotePostgresValue(c.Options[k])
+	}
+	return dsn
+}
+
+// queryOptions returns Options as URL query parameters prefixed with "&", or "" if empty.
+func (c *DBConfig) queryOptions() string {
+	var b strings.Builder
+	for _, k := range slices.Sorted(maps.Keys(c.Options)) {
+		b.WriteString("&" + url.QueryEscape(k) + "=" + url.QueryEscape(c.Options[k]))
+	}
+	return b.String()
+}
+
+// quotePostgresValue single-quotes a libpq value when it is empty or contains
+// spaces, quotes or backslashes.
+func quotePostgresValue(v string) string {
+	if v != "" && !strings.ContainsAny(v, ` '\`) {
+		return v
+	}
+	r := strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+	return "'" + r.Replace(v) + "'"
 }
 
 // PoolConfig is the connection pool configuration.
@@ -110,13 +198,60 @@ type HealthConfig struct {
 	Enabled       bool          // Enable health checks (default: true)
 	CheckInterval time.Duration // Health check interval (default: 30s)
 	Timeout       time.Duration // Health check timeout (default: 5s)
+
+	// UnhealthyThreshold is the number of consecutive failed checks before a healthy
+	// connection is marked unhealthy (default: 1).
+	UnhealthyThreshold int
+	// HealthyThreshold is the number of consecutive successful checks before an
+	// unhealthy connection is marked healthy again (default: 1).
+	HealthyThreshold int
+
+	// AllowLeaderFailover routes writes (Leader, LeaderConn, transactions) to the
+	// follower at index FailoverFollower once the leader has been unhealthy for
+	// FailoverAfter and that follower is healthy; writes return to the leader as soon
+	// as it recovers. sqlkit does not promote the database itself: the follower must
+	// already accept writes (e.g. promoted by the database's own failover tooling).
+	AllowLeaderFailover bool
+	FailoverFollower    int           // Index of the failover follower, as in GetHealth().Followers (default: 0)
+	FailoverAfter       time.Duration // How long the leader must be unhealthy before failing over (default: 0)
+
+	// OnHealthChange is called after a health check when a connection's Healthy flag
+	// changes (optional). target is "leader", "follower-N" or the name of a named
+	// connection. It runs on the health
+	// check goroutine without sqlkit locks held, so it may call GetHealth or Follower.
+	OnHealthChange func(target string, oldHealth, newHealth ConnectionHealth)
 }
 
 // DefaultHealthConfig returns a HealthConfig with default values.
 func DefaultHealthConfig() HealthConfig {
 	return HealthConfig{
-		Enabled:       true,
-		CheckInterval: 30 * time.Second,
-		Timeout:       5 * time.Second,
+		Enabled:            true,
+		CheckInterval:      30 * time.Second,
+		Timeout:            5 * time.Second,
+		UnhealthyThreshold: 1,
+		HealthyThreshold:   1,
+	}
+}
+
+// RetryConfig is the retry/backoff configuration.
+// The delay before retry n (0-based) is InitialDelay * Multiplier^n, capped at MaxDelay,
+// then randomized by ±Jitter (a fraction of the delay) to avoid synchronized retries.
+type RetryConfig struct {
+	MaxAttempts  int                  // Total attempts for ExecWithRetry/QueryWithRetry, including the first (default: 3)
+	InitialDelay time.Duration        // Delay before the first retry (default: 100ms)
+	MaxDelay     time.Duration        // Upper bound for a single delay (default: 2s)
+	Multiplier   float64              // Backoff growth factor (default: 2)
+	Jitter       float64              // Randomization fraction in [0, 1] (default: 0.2; negative disables)
+	IsTransient  func(err error) bool // Custom classifier (optional; default: IsTransientError for the driver)
+}
+
+// DefaultRetryConfig returns a RetryConfig with default values.
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxAttempts:  3,
+		InitialDelay: 100 * time.Millisecond,
+		MaxDelay:     2 * time.Second,
+		Multiplier:   2,
+		Jitter:       0.2,
 	}
 }
@@ -0,0 +1,111 @@
+package sqlkit
+
+import "testing"
+
+func TestDBConfig_DSN(t *testing.T) {
+	base := DBConfig{
+		Host:     "db.local",
+		Port:     5432,
+		Database: "app",
+		Username: "user",
+		Password: "p@ss",
+		SSLMode:  "verify-full",
+	}
+	withDriver := func(driver string, mutate func(*DBConfig)) DBConfig {
+		c := base
+		c.Driver = driver
+		if mutate != nil {
+			mutate(&c)
+		}
+		return c
+	}
+	pgBase := "host=db.local port=5432 user=user password=p%40ss dbname=app sslmode=verify-full connect_timeout=5"
+
+	tests := []struct {
+		name string
+		cfg  DBConfig
+		want string
+	}{
+		{name: "postgres", cfg: withDriver("postgres", nil), want: pgBase},
+		{name: "pgx uses postgres format", cfg: withDriver("pgx", nil), want: pgBase},
+		{name: "cloudsqlpostgres uses postgres format", cfg: withDriver("cloudsqlpostgres", nil), want: pgBase},
+		{
+			name: "postgres tls and options",
+			cfg: withDriver("postgres", func(c *DBConfig) {
+				c.SSLRootCert = "/certs/ca.pem"
+				c.SSLCert = "/certs/client.pem"
+				c.SSLKey = "/certs/client key.pem"
+				c.Options = map[string]string{"search_path": "app", "application_name": "api"}
+			}),
+			want: pgBase + " sslrootcert=/certs/ca.pem sslcert=/certs/client.pem sslkey='/certs/client key.pem'" +
+				" application_name=api search_path=app",
+		},
+		{
+			name: "mysql",
+			cfg:  withDriver("mysql", nil),
+			want: "user:p%40ss@tcp(db.local:5432)/app?parseTime=true&timeout=5s",
+		},
+		{
+			name: "mysql options",
+			cfg: withDriver("mysql", func(c *DBConfig) {
+				c.Options = map[string]string{"loc": "Asia/Jakarta", "charset": "utf8mb4"}
+			}),
+			want: "user:p%40ss@tcp(db.local:5432)/app?parseTime=true&timeout=5s&charset=utf8mb4&loc=Asia%2FJakarta",
+		},
+		{
+			name: "sqlite3 options",
+			cfg: withDriver("sqlite3", func(c *DBConfig) {
+				c.Options = map[string]string{"_foreign_keys": "on"}
+			}),
+			want: "file:app?mode=rwc&cache=shared&_busy_timeout=5000&_foreign_keys=on",
+		},
+		{
+			name: "sqlite3 shared memory",
+			cfg:  withDriver("sqlite3", func(c *DBConfig) { c.Database = SQLiteMemory }),
+			want: "file::memory:?cache=shared&_busy_timeout=5000",
+		},
+		{
+			name: "sqlite3 named memory",
+			cfg: withDriver("sqlite3", func(c *DBConfig) {
+				c.Database = SQLiteMemory + "orders"
+				c.Options = map[string]string{"_foreign_keys": "on"}
+			}),
+			want: "file:orders?mode=memory&cache=shared&_busy_timeout=5000&_foreign_keys=on",
+		},
+		{
+			name: "other driver options",
+			cfg: withDriver("sqlserver", func(c *DBConfig) {
+				c.Options = map[string]string{"encrypt": "true"}
+			}),
+			want: "driver=sqlserver;host=db.local;port=5432;database=app;user id=user;password=p%40ss;" +
+				"connect timeout=5;encrypt=true",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cfg.DSN(); got != tt.want {
+				t.Errorf("DSN() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuotePostgresValue(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "plain", want: "plain"},
+		{in: "", want: "''"},
+		{in: "with space", want: "'with space'"},
+		{in: `it's`, want: `'it\'s'`},
+		{in: `back\slash`, want: `'back\\slash'`},
+	}
+
+	for _, tt := range tests {
+		if got := quotePostgresValue(tt.in); got != tt.want {
+			t.Errorf("quotePostgresValue(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
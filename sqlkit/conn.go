@@ -0,0 +1,200 @@
+package sqlkit
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// TargetLeader identifies the leader connection in QueryEvent.Target and health callbacks.
+const TargetLeader = "leader"
+
+// followerTarget returns the name identifying the follower at index i ("follower-0", ...).
+func followerTarget(i int) string {
+	return fmt.Sprintf("follower-%d", i)
+}
+
+// QueryEvent describes a statement executed through a Conn.
+type QueryEvent struct {
+	Driver   string        // Database driver name, e.g. "postgres"
+	Target   string        // Connection that ran the statement: "leader", "follower-N" or a named connection
+	Query    string        // SQL statement
+	Duration time.Duration // Time until the driver returned (excludes iterating rows)
+	Err      error         // Error returned by the driver, if any
+}
+
+// QueryHook is called after every statement executed through a Conn.
+// Use it to record query metrics (duration histograms, error counters) or traces.
+// Hooks run synchronously on the calling goroutine and should be fast.
+type QueryHook func(ctx context.Context, event QueryEvent)
+
+// Conn wraps a leader or follower *sql.DB and invokes Config.OnQuery after each statement.
+// When Config.DefaultQueryTimeout (or WithQueryTimeout) sets a timeout, each statement
+// runs under a child context with that timeout, unless ctx already has an earlier deadline.
+// QueryContext and QueryRowContext return Rows and Row, which wrap their database/sql
+// counterparts so the statement context is released when the rows are closed or scanned.
+// Obtain one via DB.LeaderConn or DB.FollowerConn.
+type Conn struct {
+	db      *sql.DB
+	driver  string
+	target  string
+	hook    QueryHook
+	timeout time.Duration
+}
+
+// DB returns the underlying *sql.DB.
+func (c *Conn) DB() *sql.DB {
+	return c.db
+}
+
+// Target returns the name of the underlying connection: "leader", "follower-N" or a named connection.
+func (c *Conn) Target() string {
+	return c.target
+}
+
+// ExecContext executes a statement that returns no rows and reports it to the query hook.
+func (c *Conn) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	ctx, cancel := c.statementContext(ctx)
+	defer cancel()
+
+	start := time.Now()
+	result, err := c.db.ExecContext(ctx, query, args...)
+	c.report(ctx, query, start, err)
+	return result, err
+}
+
+// QueryContext executes a query that returns rows and reports it to the query hook.
+// The reported duration covers query execution only, not iterating the returned rows.
+// The statement timeout also bounds iterating the rows; it is released when the
+// returned Rows is closed, so always Close it.
+func (c *Conn) QueryContext(ctx context.Context, query string, args ...any) (*Rows, error) {
+	ctx, cancel := c.statementContext(ctx)
+
+	start := time.Now()
+	rows, err := c.db.QueryContext(ctx, query, args...)
+	c.report(ctx, query, start, err)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &Rows{Rows: rows, cancel: cancel}, nil
+}
+
+// QueryRowContext executes a query that returns at most one row and reports it to the
+// query hook. The reported error is the query's error (sql.ErrNoRows is not
+// reported, since it surfaces only on Scan).
+// The statement timeout also bounds Scan and is released once Scan returns.
+func (c *Conn) QueryRowContext(ctx context.Context, query string, args ...any) *Row {
+	rows, err := c.QueryContext(ctx, query, args...)
+	return &Row{rows: rows, err: err}
+}
+
+// Rows is the result of Conn.QueryContext: a *sql.Rows whose Close also releases
+// the statement timeout context.
+type Rows struct {
+	*sql.Rows
+	cancel context.CancelFunc
+}
+
+// Close closes the rows and releases the statement context.
+func (r *Rows) Close() error {
+	err := r.Rows.Close()
+	r.cancel()
+	return err
+}
+
+// Row is the result of Conn.QueryRowContext. Like *sql.Row, it defers the query
+// error to Scan and returns sql.ErrNoRows when the query selected no rows.
+type Row struct {
+	rows *Rows
+	err  error
+}
+
+// Scan copies the columns of the first row into dest, then closes the rows and
+// releases the statement context. See sql.Row.Scan.
+func (r *Row) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	defer r.rows.Close()
+	if !r.rows.Next() {
+		if err := r.rows.Err(); err != nil {
+			return err
+		}
+		return sql.ErrNoRows
+	}
+	if err := r.rows.Scan(dest...); err != nil {
+		return err
+	}
+	return r.rows.Close()
+}
+
+// Err returns the error of the query, if any, without scanning. See sql.Row.Err.
+func (r *Row) Err() error {
+	return r.err
+}
+
+// statementContext derives the context for one statement, applying the timeout from
+// WithQueryTimeout or Config.DefaultQueryTimeout. context.WithTimeout keeps an
+// earlier parent deadline.
+func (c *Conn) statementContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	timeout := c.timeout
+	if override, ok := ctx.Value(queryTimeoutKey{}).(time.Duration); ok {
+		timeout = override
+	}
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// report invokes the query hook, if any.
+func (c *Conn) report(ctx context.Context, query string, start time.Time, err error) {
+	if c.hook == nil {
+		return
+	}
+	c.hook(ctx, QueryEvent{
+		Driver:   c.driver,
+		Target:   c.target,
+		Query:    query,
+		Duration: time.Since(start),
+		Err:      err,
+	})
+}
+
+// LeaderConn returns the leader connection wrapped in a Conn so Config.OnQuery fires.
+// Thread-safe.
+func (db *DB) LeaderConn() *Conn {
+	conn, target := db.selectLeader()
+	return db.newConn(conn, target)
+}
+
+// FollowerConn returns a follower connection, selected like Follower, wrapped in a
+// Conn so Config.OnQuery fires. Falls back to the leader when no follower is healthy.
+// Thread-safe.
+func (db *DB) FollowerConn() *Conn {
+	conn, target := db.selectFollower()
+	return db.newConn(conn, target)
+}
+
+// newConn wraps conn in a Conn that reports to the configured query hook.
+func (db *DB) newConn(conn *sql.DB, target string) *Conn {
+	return &Conn{
+		db:      conn,
+		driver:  db.driver,
+		target:  target,
+		hook:    db.config.OnQuery,
+		timeout: db.config.DefaultQueryTimeout,
+	}
+}
+
+// queryTimeoutKey is an empty struct used as context key for the per-call statement timeout.
+type queryTimeoutKey struct{}
+
+// WithQueryTimeout returns a context whose statements run through a Conn use timeout d
+// instead of Config.DefaultQueryTimeout. d <= 0 disables the timeout for those calls.
+// An earlier deadline already on ctx still wins.
+func WithQueryTimeout(ctx context.Context, d time.Duration) context.Context {
+	return context.WithValue(ctx, queryTimeoutKey{}, d)
+}
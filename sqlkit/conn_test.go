@@ -0,0 +1,292 @@
+package sqlkit
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// hookRecorder collects QueryEvents passed to a QueryHook.
+type hookRecorder struct {
+	mu     sync.Mutex
+	events []QueryEvent
+}
+
+func (r *hookRecorder) hook(_ context.Context, event QueryEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *hookRecorder) Events() []QueryEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]QueryEvent(nil), r.events...)
+}
+
+func TestConn_QueryHook(t *testing.T) {
+	errExec := errors.New("exec failed")
+	backend, leader := newFakeDB(t)
+	backend.execFn = func(query string, _ []driver.NamedValue) (driver.Result, error) {
+		if query == "DELETE FROM broken" {
+			return nil, errExec
+		}
+		return driver.RowsAffected(1), nil
+	}
+
+	rec := &hookRecorder{}
+	db := newTestDB(Config{Leader: DBConfig{Driver: "postgres"}, OnQuery: rec.hook}, leader)
+	conn := db.LeaderConn()
+	ctx := context.Background()
+
+	if _, err := conn.ExecContext(ctx, "UPDATE users SET name = $1", "a"); err != nil {
+		t.Fatalf("ExecContext() error = %v", err)
+	}
+	if _, err := conn.ExecContext(ctx, "DELETE FROM broken"); !errors.Is(err, errExec) {
+		t.Fatalf("ExecContext() error = %v, want %v", err, errExec)
+	}
+	rows, err := conn.QueryContext(ctx, "SELECT 1")
+	if err != nil {
+		t.Fatalf("QueryContext() error = %v", err)
+	}
+	_ = rows.Close()
+	_ = conn.QueryRowContext(ctx, "SELECT 2")
+
+	events := rec.Events()
+	wantQueries := []string{"UPDATE users SET name = $1", "DELETE FROM broken", "SELECT 1", "SELECT 2"}
+	if len(events) != len(wantQueries) {
+		t.Fatalf("hook called %d times, want %d", len(events), len(wantQueries))
+	}
+	for i, want := range wantQueries {
+		ev := events[i]
+		if ev.Query != want {
+			t.Errorf("events[%d].Query = %q, want %q", i, ev.Query, want)
+		}
+		if ev.Driver != "postgres" {
+			t.Errorf("events[%d].Driver = %q, want %q", i, ev.Driver, "postgres")
+		}
+		if ev.Target != TargetLeader {
+			t.Errorf("events[%d].Target = %q, want %q", i, ev.Target, TargetLeader)
+		}
+		if ev.Duration < 0 {
+			t.Errorf("events[%d].Duration = %v, want >= 0", i, ev.Duration)
+		}
+	}
+	if !errors.Is(events[1].Err, errExec) {
+		t.Errorf("events[1].Err = %v, want %v", events[1].Err, errExec)
+	}
+	if events[0].Err != nil {
+		t.Errorf("events[0].Err = %v, want nil", events[0].Err)
+	}
+}
+
+func TestConn_NoHook(t *testing.T) {
+	backend, leader := newFakeDB(t)
+	db := newTestDB(Config{Leader: DBConfig{Driver: "postgres"}}, leader)
+
+	if _, err := db.LeaderConn().ExecContext(context.Background(), "SELECT 1"); err != nil {
+		t.Fatalf("ExecContext() error = %v", err)
+	}
+	if got := backend.Queries(); len(got) != 1 || got[0] != "SELECT 1" {
+		t.Errorf("Queries() = %v, want [SELECT 1]", got)
+	}
+}
+
+func TestDB_FollowerConn(t *testing.T) {
+	tests := []struct {
+		name       string
+		followers  int
+		healthy    bool
+		wantTarget string
+	}{
+		{name: "no followers falls back to leader", followers: 0, wantTarget: TargetLeader},
+		{name: "healthy follower", followers: 1, healthy: true, wantTarget: "follower-0"},
+		{name: "unhealthy follower falls back to leader", followers: 1, healthy: false, wantTarget: TargetLeader},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, leader := newFakeDB(t)
+			followerBackend, follower := newFakeDB(t)
+			rec := &hookRecorder{}
+			cfg := Config{Leader: DBConfig{Driver: "mysql"}, OnQuery: rec.hook}
+
+			db := newTestDB(cfg, leader)
+			if tt.followers > 0 {
+				db = newTestDB(cfg, leader, follower)
+				db.followerHealthMap[0] = ConnectionHealth{Healthy: tt.healthy}
+			}
+
+			conn := db.FollowerConn()
+			if conn.Target() != tt.wantTarget {
+				t.Errorf("FollowerConn().Target() = %q, want %q", conn.Target(), tt.wantTarget)
+			}
+			_ = conn.QueryRowContext(context.Background(), "SELECT 1")
+
+			events := rec.Events()
+			if len(events) != 1 || events[0].Target != tt.wantTarget || events[0].Driver != "mysql" {
+				t.Errorf("events = %+v, want one event with target %q", events, tt.wantTarget)
+			}
+			servedByFollower := len(followerBackend.Queries()) > 0
+			if servedByFollower != (tt.wantTarget != TargetLeader) {
+				t.Errorf("served by follower = %v, want %v", servedByFollower, tt.wantTarget != TargetLeader)
+			}
+		})
+	}
+}
+
+func TestConn_StatementTimeout(t *testing.T) {
+	tests := []struct {
+		name         string
+		defaultLimit time.Duration
+		ctx          func() (context.Context, context.CancelFunc)
+		wantDeadline bool
+		wantMax      time.Duration
+	}{
+		{
+			name:         "no timeout configured",
+			ctx:          func() (context.Context, context.CancelFunc) { return context.Background(), func() {} },
+			wantDeadline: false,
+		},
+		{
+			name:         "default timeout applied",
+			defaultLimit: time.Minute,
+			ctx:          func() (context.Context, context.CancelFunc) { return context.Background(), func() {} },
+			wantDeadline: true,
+			wantMax:      time.Minute,
+		},
+		{
+			name:         "earlier parent deadline wins",
+			defaultLimit: time.Hour,
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), time.Minute)
+			},
+			wantDeadline: true,
+			wantMax:      time.Minute,
+		},
+		{
+			name:         "per-call override",
+			defaultLimit: time.Hour,
+			ctx: func() (context.Context, context.CancelFunc) {
+				return WithQueryTimeout(context.Background(), time.Second), func() {}
+			},
+			wantDeadline: true,
+			wantMax:      time.Second,
+		},
+		{
+			name:         "per-call override disables timeout",
+			defaultLimit: time.Hour,
+			ctx: func() (context.Context, context.CancelFunc) {
+				return WithQueryTimeout(context.Background(), 0), func() {}
+			},
+			wantDeadline: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend, leader := newFakeDB(t)
+			var deadline time.Time
+			var hasDeadline bool
+			backend.ctxFn = func(ctx context.Context) error {
+				deadline, hasDeadline = ctx.Deadline()
+				return nil
+			}
+			db := newTestDB(Config{DefaultQueryTimeout: tt.defaultLimit}, leader)
+
+			ctx, cancel := tt.ctx()
+			defer cancel()
+			if _, err := db.LeaderConn().ExecContext(ctx, "UPDATE t SET x = 1"); err != nil {
+				t.Fatalf("ExecContext() error = %v", err)
+			}
+
+			if hasDeadline != tt.wantDeadline {
+				t.Fatalf("statement context has deadline = %v, want %v", hasDeadline, tt.wantDeadline)
+			}
+			if tt.wantDeadline && time.Until(deadline) > tt.wantMax {
+				t.Errorf("statement deadline in %v, want <= %v", time.Until(deadline), tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestConn_StatementTimeoutCancelsDriver(t *testing.T) {
+	backend, leader := newFakeDB(t)
+	backend.ctxFn = func(ctx context.Context) error {
+		<-ctx.Done() // simulate a long-running query
+		return ctx.Err()
+	}
+	rec := &hookRecorder{}
+	db := newTestDB(Config{DefaultQueryTimeout: 10 * time.Millisecond, OnQuery: rec.hook}, leader)
+
+	_, err := db.ReaderConn(context.Background()).QueryContext(context.Background(), "SELECT pg_sleep(60)")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("QueryContext() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if events := rec.Events(); len(events) != 1 || !errors.Is(events[0].Err, context.DeadlineExceeded) {
+		t.Errorf("events = %+v, want one event with a deadline error", events)
+	}
+}
+
+func TestConn_StatementContextReleased(t *testing.T) {
+	newConn := func(t *testing.T, values [][]driver.Value) (*Conn, *context.Context) {
+		t.Helper()
+		backend, leader := newFakeDB(t)
+		var stmtCtx context.Context
+		backend.ctxFn = func(ctx context.Context) error {
+			stmtCtx = ctx
+			return nil
+		}
+		backend.queryFn = func(string, []driver.NamedValue) (driver.Rows, error) {
+			return &fakeRows{columns: []string{"name"}, values: values}, nil
+		}
+		return newTestDB(Config{DefaultQueryTimeout: time.Hour}, leader).LeaderConn(), &stmtCtx
+	}
+	ctx := context.Background()
+
+	t.Run("rows closed", func(t *testing.T) {
+		conn, stmtCtx := newConn(t, [][]driver.Value{{"ann"}})
+		rows, err := conn.QueryContext(ctx, "SELECT name FROM users")
+		if err != nil {
+			t.Fatalf("QueryContext() error = %v", err)
+		}
+		if err := (*stmtCtx).Err(); err != nil {
+			t.Fatalf("statement context done before Close: %v", err)
+		}
+		if err := rows.Close(); err != nil {
+			t.Fatalf("Close() error = %v", err)
+		}
+		if err := (*stmtCtx).Err(); !errors.Is(err, context.Canceled) {
+			t.Errorf("statement context after Close: %v, want %v", err, context.Canceled)
+		}
+	})
+
+	t.Run("row scanned", func(t *testing.T) {
+		conn, stmtCtx := newConn(t, [][]driver.Value{{"ann"}})
+		var name string
+		if err := conn.QueryRowContext(ctx, "SELECT name FROM users LIMIT 1").Scan(&name); err != nil {
+			t.Fatalf("Scan() error = %v", err)
+		}
+		if name != "ann" {
+			t.Errorf("Scan() name = %q, want ann", name)
+		}
+		if err := (*stmtCtx).Err(); !errors.Is(err, context.Canceled) {
+			t.Errorf("statement context after Scan: %v, want %v", err, context.Canceled)
+		}
+	})
+
+	t.Run("no rows", func(t *testing.T) {
+		conn, stmtCtx := newConn(t, nil)
+		var name string
+		if err := conn.QueryRowContext(ctx, "SELECT name FROM users LIMIT 1").Scan(&name); !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("Scan() error = %v, want %v", err, sql.ErrNoRows)
+		}
+		if err := (*stmtCtx).Err(); !errors.Is(err, context.Canceled) {
+			t.Errorf("statement context after Scan: %v, want %v", err, context.Canceled)
+		}
+	})
+}
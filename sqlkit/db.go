@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -16,6 +17,7 @@ type DB struct {
 	followers []*sql.DB
 	config    Config
 	driver    string
+	named     map[string]namedPool
 
 	// Round-robin for follower selection
 	followerIdx int
@@ -25,10 +27,15 @@ type DB struct {
 	healthMu          sync.RWMutex
 	leaderHealth      ConnectionHealth
 	followerHealthMap map[int]ConnectionHealth
+	namedHealth       map[string]ConnectionHealth
+	failover          failoverState
 
 	// Lifecycle
-	ctx    context.Context
-	cancel context.CancelFunc
+	ctx       context.Context
+	cancel    context.CancelFunc
+	closeOnce sync.Once
+	closeErr  error
+	closed    atomic.Bool
 }
 
 // New creates and initializes a new DB instance.
@@ -48,30 +55,81 @@ func New(ctx context.Context, cfg *Config) (*DB, error) {
 	if cfg.Pool.MaxOpenConns == 0 {
 		cfg.Pool = DefaultPoolConfig()
 	}
-	if cfg.Health.CheckInterval == 0 {
-		cfg.Health = DefaultHealthConfig()
-	}
-
-	// Create context with cancellation for health checks
-	ctxWithCancel, cancel := context.WithCancel(ctx)
 
-	db := &DB{
-		config:            *cfg,
-		driver:            cfg.Leader.Driver,
-		followerHealthMap: make(map[int]ConnectionHealth),
-		leaderHealth:      ConnectionHealth{Healthy: false},
-		ctx:               ctxWithCancel,
-		cancel:            cancel,
-	}
+	db := newDB(ctx, cfg)
 
 	// Initialize leader connection (required)
 	if err := db.initLeader(); err != nil {
-		cancel()
+		db.cancel()
 		return nil, fmt.Errorf("sqlkit: failed to initialize leader: %w", err)
 	}
 
-	// Initialize follower connections (optional, non-blocking)
+	// Initialize follower and named connections (optional, non-blocking)
 	db.initFollowers()
+	db.initNamed()
+
+	// Start health check goroutine if enabled
+	if cfg.Health.Enabled {
+		go db.runHealthChecks()
+	}
+
+	return db, nil
+}
+
+// NewWithConns creates a DB around connections opened and registered by the caller
+// (custom driver registration, connectors, sqlmock in tests).
+// Skips sql.Open and DSN building; cfg.Leader.Driver should still name the driver
+// because dialect-specific features (BulkInsert, savepoints, error classification)
+// depend on it. cfg may be nil.
+// Validates that leader (and every follower) is non-nil.
+// Pings leader (error on failure) and followers (marked unhealthy on failure).
+// Applies cfg.Pool only if set (MaxOpenConns > 0); otherwise the pools keep the
+// caller's settings.
+// Starts health check goroutine if enabled.
+// The DB takes ownership: Close closes the provided connections.
+func NewWithConns(ctx context.Context, leader *sql.DB, followers []*sql.DB, cfg *Config) (*DB, error) {
+	if leader == nil {
+		return nil, fmt.Errorf("%w: leader connection is required", ErrInvalidConfig)
+	}
+	for i, follower := range followers {
+		if follower == nil {
+			return nil, fmt.Errorf("%w: follower connection %d is nil", ErrInvalidConfig, i)
+		}
+	}
+	if cfg == nil {
+		cfg = &Config{}
+	}
+
+	db := newDB(ctx, cfg)
+	db.leader = leader
+	db.followers = append([]*sql.DB(nil), followers...)
+
+	if cfg.Pool.MaxOpenConns > 0 {
+		db.configurePool(leader)
+		for _, follower := range db.followers {
+			db.configurePool(follower)
+		}
+	}
+
+	connectTimeout := cfg.Leader.ConnectTimeout
+	if connectTimeout == 0 {
+		connectTimeout = 5 * time.Second
+	}
+	pingCtx, cancel := context.WithTimeout(ctx, connectTimeout)
+	defer cancel()
+
+	if err := leader.PingContext(pingCtx); err != nil {
+		db.cancel()
+		return nil, fmt.Errorf("sqlkit: failed to ping leader: %w", err)
+	}
+
+	now := time.Now()
+	db.healthMu.Lock()
+	db.leaderHealth = ConnectionHealth{Healthy: true, LastCheck: now}
+	for i, follower := range db.followers {
+		db.followerHealthMap[i] = db.probe(pingCtx, follower, now)
+	}
+	db.healthMu.Unlock()
 
 	// Start health check goroutine if enabled
 	if cfg.Health.Enabled {
@@ -81,13 +139,56 @@ func New(ctx context.Context, cfg *Config) (*DB, error) {
 	return db, nil
 }
 
+// newDB applies health and retry defaults to cfg and returns a DB without connections.
+func newDB(ctx context.Context, cfg *Config) *DB {
+	if cfg.Health.CheckInterval == 0 {
+		// Keep thresholds and callbacks; only the timing fields have defaults
+		defaults := DefaultHealthConfig()
+		cfg.Health.Enabled = defaults.Enabled
+		cfg.Health.CheckInterval = defaults.CheckInterval
+		cfg.Health.Timeout = defaults.Timeout
+	}
+	// Each zero retry field takes its default; fields the caller set are kept
+	retryDefaults := DefaultRetryConfig()
+	if cfg.Retry.MaxAttempts == 0 {
+		cfg.Retry.MaxAttempts = retryDefaults.MaxAttempts
+	}
+	if cfg.Retry.InitialDelay == 0 {
+		cfg.Retry.InitialDelay = retryDefaults.InitialDelay
+	}
+	if cfg.Retry.MaxDelay == 0 {
+		cfg.Retry.MaxDelay = retryDefaults.MaxDelay
+	}
+	if cfg.Retry.Multiplier == 0 {
+		cfg.Retry.Multiplier = retryDefaults.Multiplier
+	}
+	if cfg.Retry.Jitter == 0 {
+		cfg.Retry.Jitter = retryDefaults.Jitter
+	}
+
+	// Create context with cancellation for health checks
+	ctxWithCancel, cancel := context.WithCancel(ctx)
+
+	return &DB{
+		config:            *cfg,
+		driver:            cfg.Leader.Driver,
+		followerHealthMap: make(map[int]ConnectionHealth),
+		namedHealth:       make(map[string]ConnectionHealth),
+		leaderHealth:      ConnectionHealth{Healthy: false},
+		ctx:               ctxWithCancel,
+		cancel:            cancel,
+	}
+}
+
 // Leader returns the leader (write) database connection.
 // Thread-safe.
 // Always returns non-nil if DB was successfully created.
+// While failed over (HealthConfig.AllowLeaderFailover), returns the promoted follower.
 // Use cases: Write operations (INSERT, UPDATE, DELETE), transactions that modify data,
 // operations requiring strong consistency.
 func (db *DB) Leader() *sql.DB {
-	return db.leader
+	conn, _ := db.selectLeader()
+	return conn
 }
 
 // Follower returns a follower (read) database connection using round-robin load balancing.
@@ -100,9 +201,16 @@ func (db *DB) Leader() *sql.DB {
 // Use cases: Read operations (SELECT), analytics queries, report generation,
 // any operation that can tolerate eventual consistency.
 func (db *DB) Follower() *sql.DB {
+	conn, _ := db.selectFollower()
+	return conn
+}
+
+// selectFollower implements Follower and also returns the selected connection's
+// target name ("follower-N", or "leader" on fallback).
+func (db *DB) selectFollower() (*sql.DB, string) {
 	// If no followers configured, return leader
 	if len(db.followers) == 0 {
-		return db.leader
+		return db.selectLeader()
 	}
 
 	db.followerMu.Lock()
@@ -123,12 +231,12 @@ func (db *DB) Follower() *sql.DB {
 		db.healthMu.RUnlock()
 
 		if healthy && db.followers[idx] != nil {
-			return db.followers[idx]
+			return db.followers[idx], followerTarget(idx)
 		}
 	}
 
 	// All followers unhealthy, fall back to leader
-	return db.leader
+	return db.selectLeader()
 }
 
 // Driver returns the database driver name.
@@ -137,13 +245,49 @@ func (db *DB) Driver() string {
 	return db.driver
 }
 
+// Stats returns connection pool statistics keyed by connection name:
+// "leader", "follower-N" (N is the follower's index, as in GetHealth) and the
+// names of connected named connections.
+// Use case: Export open/idle/in-use counts and wait times to metrics.
+// Thread-safe.
+func (db *DB) Stats() map[string]sql.DBStats {
+	stats := make(map[string]sql.DBStats, len(db.followers)+len(db.named)+1)
+	if db.leader != nil {
+		stats[TargetLeader] = db.leader.Stats()
+	}
+	for i, follower := range db.followers {
+		if follower != nil {
+			stats[followerTarget(i)] = follower.Stats()
+		}
+	}
+	for name, pool := range db.named {
+		if pool.conn != nil {
+			stats[name] = pool.conn.Stats()
+		}
+	}
+	return stats
+}
+
 // Close closes all database connections and stops health checks.
 // Cancels context (stops health checks).
 // Closes leader connection.
-// Closes all follower connections.
+// Closes all follower and named connections.
 // Collects and returns any errors.
+// Idempotent: the connections are closed once; later and concurrent calls wait
+// for the first one and return its result.
+// After Close, transactions, Ping and Named return ErrClosed; Leader and Follower
+// still return the closed pools, whose methods fail with "sql: database is closed".
 // Thread-safe.
 func (db *DB) Close() error {
+	db.closeOnce.Do(func() {
+		db.closed.Store(true)
+		db.closeErr = db.close()
+	})
+	return db.closeErr
+}
+
+// close implements Close.
+func (db *DB) close() error {
 	var errs []error
 
 	// Cancel context (stops health checks)
@@ -167,6 +311,15 @@ func (db *DB) Close() error {
 		}
 	}
 
+	// Close all named connections
+	for name, pool := range db.named {
+		if pool.conn != nil {
+			if err := pool.conn.Close(); err != nil {
+				errs = append(errs, fmt.Errorf("named connection %q close error: %w", name, err))
+			}
+		}
+	}
+
 	// Return combined error if any
 	if len(errs) > 0 {
 		return fmt.Errorf("sqlkit: errors during close: %v", errs)
@@ -232,14 +385,27 @@ func (db *DB) initFollowers() {
 	}
 }
 
+// configurePool applies the pool settings (defaults if unset) to conn.
+func (db *DB) configurePool(conn *sql.DB) {
+	pool := db.config.Pool
+	if pool.MaxOpenConns == 0 {
+		pool = DefaultPoolConfig()
+	}
+
+	conn.SetMaxOpenConns(pool.MaxOpenConns)
+	conn.SetMaxIdleConns(pool.MaxIdleConns)
+	conn.SetConnMaxLifetime(pool.ConnMaxLifetime)
+	conn.SetConnMaxIdleTime(pool.ConnMaxIdleTime)
+}
+
 // connect creates a database connection from config.
-// Calls sql.Open(cfg.Driver, cfg.DSN()).
+// Calls sql.Open(cfg.Driver, cfg.DSN()), using the leader's driver (for both) when cfg.Driver is empty.
 // Creates context with ConnectTimeout.
 // Pings database to verify connection.
 // Configures connection pool settings.
 // Returns connection or error.
 // Must validate connection before returning.
-// Should retry on transient errors (up to MaxRetries).
+// Should retry on transient errors (up to MaxRetries), sleeping per Config.Retry backoff.
 // Closes connection on validation failure.
 func (db *DB) connect(cfg *DBConfig) (*sql.DB, error) {
 	if cfg == nil {
@@ -254,16 +420,24 @@ func (db *DB) connect(cfg *DBConfig) (*sql.DB, error) {
 	if maxRetries == 0 {
 		maxRetries = 3
 	}
+	driverName := cfg.Driver
+	if driverName == "" {
+		// Build the DSN in the leader driver's format too.
+		withDriver := *cfg
+		withDriver.Driver = db.driver
+		cfg = &withDriver
+		driverName = db.driver
+	}
 
 	var conn *sql.DB
 	var err error
 
 	// Retry connection up to MaxRetries times
 	for attempt := 0; attempt < maxRetries; attempt++ {
-		conn, err = sql.Open(db.driver, cfg.DSN())
+		conn, err = sql.Open(driverName, cfg.DSN())
 		if err != nil {
 			if attempt < maxRetries-1 {
-				time.Sleep(time.Duration(attempt+1) * 100 * time.Millisecond) // Exponential backoff
+				time.Sleep(db.config.Retry.backoff(attempt))
 				continue
 			}
 			return nil, fmt.Errorf("sqlkit: failed to open connection after %d attempts: %w", maxRetries, err)
@@ -277,22 +451,14 @@ func (db *DB) connect(cfg *DBConfig) (*sql.DB, error) {
 		if err != nil {
 			conn.Close() // Close failed connection
 			if attempt < maxRetries-1 {
-				time.Sleep(time.Duration(attempt+1) * 100 * time.Millisecond)
+				time.Sleep(db.config.Retry.backoff(attempt))
 				continue
 			}
 			return nil, fmt.Errorf("sqlkit: failed to ping connection after %d attempts: %w", maxRetries, err)
 		}
 
 		// Connection successful, configure pool
-		pool := db.config.Pool
-		if pool.MaxOpenConns == 0 {
-			pool = DefaultPoolConfig()
-		}
-
-		conn.SetMaxOpenConns(pool.MaxOpenConns)
-		conn.SetMaxIdleConns(pool.MaxIdleConns)
-		conn.SetConnMaxLifetime(pool.ConnMaxLifetime)
-		conn.SetConnMaxIdleTime(pool.ConnMaxIdleTime)
+		db.configurePool(conn)
 
 		return conn, nil
 	}
@@ -0,0 +1,223 @@
+package sqlkit
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDB_Stats(t *testing.T) {
+	_, leader := newFakeDB(t)
+	_, follower0 := newFakeDB(t)
+	_, follower1 := newFakeDB(t)
+	db := newTestDB(Config{}, leader, follower0, follower1)
+
+	conn, err := follower1.Conn(context.Background())
+	if err != nil {
+		t.Fatalf("Conn() error = %v", err)
+	}
+	defer conn.Close()
+
+	stats := db.Stats()
+	if len(stats) != 3 {
+		t.Fatalf("len(Stats()) = %d, want 3", len(stats))
+	}
+	for _, name := range []string{"leader", "follower-0", "follower-1"} {
+		if _, ok := stats[name]; !ok {
+			t.Errorf("Stats() missing %q", name)
+		}
+	}
+	if got := stats["follower-1"].InUse; got != 1 {
+		t.Errorf("Stats()[follower-1].InUse = %d, want 1", got)
+	}
+	if got := stats["leader"].InUse; got != 0 {
+		t.Errorf("Stats()[leader].InUse = %d, want 0", got)
+	}
+}
+
+func TestNewDB_RetryDefaults(t *testing.T) {
+	defaults := DefaultRetryConfig()
+	tests := []struct {
+		name  string
+		retry RetryConfig
+		want  RetryConfig
+	}{
+		{"zero takes defaults", RetryConfig{}, defaults},
+		{
+			name:  "set fields are kept without MaxAttempts",
+			retry: RetryConfig{InitialDelay: time.Second, MaxDelay: 10 * time.Second, Jitter: 0.5},
+			want: RetryConfig{
+				MaxAttempts:  defaults.MaxAttempts,
+				InitialDelay: time.Second,
+				MaxDelay:     10 * time.Second,
+				Multiplier:   defaults.Multiplier,
+				Jitter:       0.5,
+			},
+		},
+		{
+			name:  "set fields are kept with MaxAttempts",
+			retry: RetryConfig{MaxAttempts: 5, Multiplier: 3, Jitter: -1},
+			want: RetryConfig{
+				MaxAttempts:  5,
+				InitialDelay: defaults.InitialDelay,
+				MaxDelay:     defaults.MaxDelay,
+				Multiplier:   3,
+				Jitter:       -1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newDB(context.Background(), &Config{Retry: tt.retry})
+			defer db.cancel()
+			if got := db.config.Retry; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newDB() Retry = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewWithConns(t *testing.T) {
+	_, leader := newFakeDB(t)
+	followerBackend, follower := newFakeDB(t)
+	followerBackend.pingErr = errors.New("replica down")
+
+	cfg := &Config{
+		Leader: DBConfig{Driver: "postgres"},
+		Pool:   PoolConfig{MaxOpenConns: 7, MaxIdleConns: 2},
+	}
+	db, err := NewWithConns(context.Background(), leader, []*sql.DB{follower}, cfg)
+	if err != nil {
+		t.Fatalf("NewWithConns() error = %v", err)
+	}
+	defer db.Close()
+
+	if db.Leader() != leader {
+		t.Error("Leader() did not return the provided connection")
+	}
+	if db.Driver() != "postgres" {
+		t.Errorf("Driver() = %q, want %q", db.Driver(), "postgres")
+	}
+	health := db.GetHealth()
+	if !health.Leader.Healthy {
+		t.Error("GetHealth().Leader.Healthy = false, want true")
+	}
+	if len(health.Followers) != 1 || health.Followers[0].Healthy {
+		t.Errorf("GetHealth().Followers = %+v, want one unhealthy follower", health.Followers)
+	}
+	if got := db.Stats()["leader"].MaxOpenConnections; got != 7 {
+		t.Errorf("leader MaxOpenConnections = %d, want 7", got)
+	}
+}
+
+func TestNewWithConns_KeepsCallerPool(t *testing.T) {
+	_, leader := newFakeDB(t)
+	leader.SetMaxOpenConns(3)
+
+	db, err := NewWithConns(context.Background(), leader, nil, nil)
+	if err != nil {
+		t.Fatalf("NewWithConns() error = %v", err)
+	}
+	defer db.Close()
+
+	if got := db.Stats()["leader"].MaxOpenConnections; got != 3 {
+		t.Errorf("leader MaxOpenConnections = %d, want 3", got)
+	}
+}
+
+func TestNewWithConns_Errors(t *testing.T) {
+	errPing := errors.New("connection refused")
+	failingBackend, failing := newFakeDB(t)
+	failingBackend.pingErr = errPing
+	_, ok := newFakeDB(t)
+
+	tests := []struct {
+		name      string
+		leader    *sql.DB
+		followers []*sql.DB
+		wantErr   error
+	}{
+		{name: "nil leader", leader: nil, wantErr: ErrInvalidConfig},
+		{name: "nil follower", leader: ok, followers: []*sql.DB{nil}, wantErr: ErrInvalidConfig},
+		{name: "leader ping fails", leader: failing, wantErr: errPing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, err := NewWithConns(context.Background(), tt.leader, tt.followers, nil)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewWithConns() error = %v, want %v", err, tt.wantErr)
+			}
+			if db != nil {
+				t.Error("NewWithConns() returned a DB on error")
+			}
+		})
+	}
+}
+
+func TestDB_CloseConcurrent(t *testing.T) {
+	_, leader := newFakeDB(t)
+	_, follower := newFakeDB(t)
+	db := newTestDB(Config{}, leader, follower)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 16)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = db.Close()
+		}()
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("Close() call %d = %v, want nil", i, err)
+		}
+	}
+	if err := db.Close(); err != nil {
+		t.Errorf("Close() after close = %v, want nil", err)
+	}
+
+	ctx := context.Background()
+	if err := db.WithTransaction(ctx, func(context.Context) error { return nil }); !errors.Is(err, ErrClosed) {
+		t.Errorf("WithTransaction() after close = %v, want %v", err, ErrClosed)
+	}
+	if err := db.WithReadOnlyTransaction(ctx, func(context.Context) error { return nil }); !errors.Is(err, ErrClosed) {
+		t.Errorf("WithReadOnlyTransaction() after close = %v, want %v", err, ErrClosed)
+	}
+	if err := db.Ping(ctx); !errors.Is(err, ErrClosed) {
+		t.Errorf("Ping() after close = %v, want %v", err, ErrClosed)
+	}
+	if _, err := db.Follower().ExecContext(ctx, "SELECT 1"); err == nil {
+		t.Error("Follower().ExecContext() after close = nil, want error")
+	}
+}
+
+func TestNew_sqliteMemorySharedWithFollowers(t *testing.T) {
+	ctx := context.Background()
+	db, err := New(ctx, &Config{
+		Leader:    DBConfig{Driver: "sqlite3", Database: SQLiteMemory + "shared_with_followers"},
+		Followers: []DBConfig{{Database: SQLiteMemory + "shared_with_followers"}},
+		Health:    HealthConfig{Enabled: false, CheckInterval: time.Hour},
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer db.Close()
+
+	if _, err := db.Leader().ExecContext(ctx, "CREATE TABLE users (id INTEGER PRIMARY KEY)"); err != nil {
+		t.Fatalf("CREATE TABLE error = %v", err)
+	}
+	var name string
+	if err := db.Follower().QueryRowContext(ctx, "SELECT name FROM sqlite_master").Scan(&name); err != nil {
+		t.Fatalf("follower query error = %v", err)
+	}
+	if name != "users" {
+		t.Errorf("follower sees table %q, want users", name)
+	}
+}
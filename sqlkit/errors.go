@@ -3,6 +3,7 @@ package sqlkit
 import (
 	"database/sql"
 	"errors"
+	"strings"
 )
 
 var (
@@ -20,6 +21,18 @@ var (
 
 	// ErrTransactionFailed indicates a transaction failed.
 	ErrTransactionFailed = errors.New("sqlkit: transaction failed")
+
+	// ErrUnsupportedDriver indicates the operation is not implemented for the configured driver.
+	ErrUnsupportedDriver = errors.New("sqlkit: unsupported driver")
+
+	// ErrNestedTransaction indicates a transaction was started while one is already in the context.
+	ErrNestedTransaction = errors.New("sqlkit: nested transaction detected")
+
+	// ErrClosed indicates the DB was closed with Close.
+	ErrClosed = errors.New("sqlkit: database closed")
+
+	// ErrUnknownConnection indicates a named connection that is not in Config.Named.
+	ErrUnknownConnection = errors.New("sqlkit: unknown named connection")
 )
 
 // IsNoRows checks if error is sql.ErrNoRows.
@@ -27,3 +40,153 @@ var (
 func IsNoRows(err error) bool {
 	return errors.Is(err, sql.ErrNoRows)
 }
+
+// ErrorClass is a driver-independent category of database error.
+type ErrorClass int
+
+const (
+	// ClassUnknown is any error not recognized by ClassifyError.
+	ClassUnknown ErrorClass = iota
+	// ClassNoRows is sql.ErrNoRows.
+	ClassNoRows
+	// ClassUniqueViolation is a unique or primary key constraint violation.
+	ClassUniqueViolation
+	// ClassForeignKeyViolation is a foreign key constraint violation.
+	ClassForeignKeyViolation
+	// ClassNotNullViolation is a NOT NULL constraint violation.
+	ClassNotNullViolation
+	// ClassDeadlock is a deadlock detected by the database.
+	ClassDeadlock
+	// ClassCheckViolation is a CHECK constraint violation.
+	ClassCheckViolation
+	// ClassSerializationFailure is a transaction that could not be serialized with
+	// concurrent ones (SERIALIZABLE or REPEATABLE READ isolation) and may be retried.
+	ClassSerializationFailure
+)
+
+// String returns the class name.
+func (c ErrorClass) String() string {
+	switch c {
+	case ClassNoRows:
+		return "no_rows"
+	case ClassUniqueViolation:
+		return "unique_violation"
+	case ClassForeignKeyViolation:
+		return "foreign_key_violation"
+	case ClassNotNullViolation:
+		return "not_null_violation"
+	case ClassDeadlock:
+		return "deadlock"
+	case ClassCheckViolation:
+		return "check_violation"
+	case ClassSerializationFailure:
+		return "serialization_failure"
+	default:
+		return "unknown"
+	}
+}
+
+// Per-driver error codes and messages recognized by ClassifyError.
+var (
+	postgresErrorClasses = map[string]ErrorClass{
+		"23505": ClassUniqueViolation,
+		"23503": ClassForeignKeyViolation,
+		"23502": ClassNotNullViolation,
+		"23514": ClassCheckViolation,
+		"40001": ClassSerializationFailure,
+		"40P01": ClassDeadlock,
+	}
+	mysqlErrorClasses = map[int64]ErrorClass{
+		1062: ClassUniqueViolation,     // ER_DUP_ENTRY
+		1216: ClassForeignKeyViolation, // ER_NO_REFERENCED_ROW
+		1217: ClassForeignKeyViolation, // ER_ROW_IS_REFERENCED
+		1451: ClassForeignKeyViolation, // ER_ROW_IS_REFERENCED_2
+		1452: ClassForeignKeyViolation, // ER_NO_REFERENCED_ROW_2
+		1048: ClassNotNullViolation,    // ER_BAD_NULL_ERROR
+		1213: ClassDeadlock,            // ER_LOCK_DEADLOCK
+		3819: ClassCheckViolation,      // ER_CHECK_CONSTRAINT_VIOLATED
+	}
+	sqliteErrorClasses = map[string]ErrorClass{
+		"UNIQUE constraint failed":      ClassUniqueViolation,
+		"FOREIGN KEY constraint failed": ClassForeignKeyViolation,
+		"NOT NULL constraint failed":    ClassNotNullViolation,
+		"CHECK constraint failed":       ClassCheckViolation,
+	}
+	oracleErrorClasses = map[string]ErrorClass{
+		"ORA-00001": ClassUniqueViolation,
+		"ORA-02291": ClassForeignKeyViolation,
+		"ORA-02292": ClassForeignKeyViolation,
+		"ORA-01400": ClassNotNullViolation,
+		"ORA-00060": ClassDeadlock,
+		"ORA-02290": ClassCheckViolation,
+		"ORA-08177": ClassSerializationFailure,
+	}
+)
+
+// ClassifyError maps a driver error to an ErrorClass.
+// Errors are recognized without importing the drivers:
+//   - postgres/pgx/cloudsqlpostgres: SQLSTATE 23505, 23503, 23502, 40P01, 23514, 40001 via SQLState() string
+//   - mysql: error numbers 1062, 1216/1217/1451/1452, 1048, 1213, 3819 via the Number field
+//   - sqlite3: "UNIQUE/FOREIGN KEY/NOT NULL/CHECK constraint failed" messages
+//   - oracle/godror/oci8: ORA-00001, ORA-02291/02292, ORA-01400, ORA-00060, ORA-02290, ORA-08177 messages
+//
+// An empty or unknown driver tries every format. sql.ErrNoRows is ClassNoRows for any driver.
+func ClassifyError(driverName string, err error) ErrorClass {
+	if err == nil {
+		return ClassUnknown
+	}
+	if IsNoRows(err) {
+		return ClassNoRows
+	}
+
+	switch driverName {
+	case "postgres", "pgx", "cloudsqlpostgres":
+		return classifySQLState(err)
+	case "mysql":
+		return classifyMySQL(err)
+	case "sqlite3":
+		return classifyMessage(err, sqliteErrorClasses)
+	case "oracle", "godror", "oci8":
+		return classifyMessage(err, oracleErrorClasses)
+	}
+
+	for _, classify := range []func(error) ErrorClass{
+		classifySQLState,
+		classifyMySQL,
+		func(err error) ErrorClass { return classifyMessage(err, sqliteErrorClasses) },
+		func(err error) ErrorClass { return classifyMessage(err, oracleErrorClasses) },
+	} {
+		if class := classify(err); class != ClassUnknown {
+			return class
+		}
+	}
+	return ClassUnknown
+}
+
+// classifySQLState classifies errors exposing a PostgreSQL SQLSTATE.
+func classifySQLState(err error) ErrorClass {
+	var stateErr interface{ SQLState() string }
+	if errors.As(err, &stateErr) {
+		return postgresErrorClasses[stateErr.SQLState()]
+	}
+	return ClassUnknown
+}
+
+// classifyMySQL classifies errors carrying a MySQL error Number.
+func classifyMySQL(err error) ErrorClass {
+	if n, ok := errorCodeField(err, "Number"); ok {
+		return mysqlErrorClasses[n]
+	}
+	return ClassUnknown
+}
+
+// classifyMessage classifies err by the first known marker contained in its message.
+func classifyMessage(err error, classes map[string]ErrorClass) ErrorClass {
+	msg := err.Error()
+	for marker, class := range classes {
+		if strings.Contains(msg, marker) {
+			return class
+		}
+	}
+	return ClassUnknown
+}
@@ -0,0 +1,76 @@
+package sqlkit
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestClassifyError(t *testing.T) {
+	sqliteUnique := errors.New("UNIQUE constraint failed: users.email")
+
+	tests := []struct {
+		name   string
+		driver string
+		err    error
+		want   ErrorClass
+	}{
+		{name: "nil", driver: "postgres", err: nil, want: ClassUnknown},
+		{name: "no rows", driver: "mysql", err: fmt.Errorf("get: %w", sql.ErrNoRows), want: ClassNoRows},
+		{name: "plain error", driver: "postgres", err: errors.New("boom"), want: ClassUnknown},
+
+		{name: "postgres unique", driver: "postgres", err: &fakePgError{code: "23505"}, want: ClassUniqueViolation},
+		{name: "pgx foreign key", driver: "pgx", err: &fakePgError{code: "23503"}, want: ClassForeignKeyViolation},
+		{name: "postgres not null", driver: "postgres", err: &fakePgError{code: "23502"}, want: ClassNotNullViolation},
+		{name: "postgres deadlock", driver: "postgres", err: &fakePgError{code: "40P01"}, want: ClassDeadlock},
+		{name: "postgres check", driver: "postgres", err: &fakePgError{code: "23514"}, want: ClassCheckViolation},
+		{
+			name: "postgres serialization", driver: "pgx",
+			err: &fakePgError{code: "40001"}, want: ClassSerializationFailure,
+		},
+		{name: "postgres other", driver: "postgres", err: &fakePgError{code: "42P01"}, want: ClassUnknown},
+
+		{name: "mysql duplicate", driver: "mysql", err: &fakeMySQLError{Number: 1062}, want: ClassUniqueViolation},
+		{name: "mysql foreign key", driver: "mysql", err: &fakeMySQLError{Number: 1452}, want: ClassForeignKeyViolation},
+		{name: "mysql not null", driver: "mysql", err: &fakeMySQLError{Number: 1048}, want: ClassNotNullViolation},
+		{name: "mysql deadlock", driver: "mysql", err: &fakeMySQLError{Number: 1213}, want: ClassDeadlock},
+		{name: "mysql check", driver: "mysql", err: &fakeMySQLError{Number: 3819}, want: ClassCheckViolation},
+
+		{name: "sqlite unique", driver: "sqlite3", err: sqliteUnique, want: ClassUniqueViolation},
+		{
+			name: "sqlite foreign key", driver: "sqlite3",
+			err: errors.New("FOREIGN KEY constraint failed"), want: ClassForeignKeyViolation,
+		},
+		{
+			name: "sqlite not null", driver: "sqlite3",
+			err: errors.New("NOT NULL constraint failed: users.name"), want: ClassNotNullViolation,
+		},
+		{
+			name: "sqlite check", driver: "sqlite3",
+			err: errors.New("CHECK constraint failed: age_positive"), want: ClassCheckViolation,
+		},
+
+		{
+			name: "oracle unique", driver: "godror",
+			err: errors.New("ORA-00001: unique constraint (APP.USERS_PK) violated"), want: ClassUniqueViolation,
+		},
+		{
+			name: "oracle check", driver: "oracle",
+			err: errors.New("ORA-02290: check constraint (APP.AGE_CK) violated"), want: ClassCheckViolation,
+		},
+
+		{name: "auto-detect postgres", driver: "", err: &fakePgError{code: "23505"}, want: ClassUniqueViolation},
+		{name: "auto-detect mysql", driver: "", err: &fakeMySQLError{Number: 1451}, want: ClassForeignKeyViolation},
+		{name: "auto-detect sqlite", driver: "", err: sqliteUnique, want: ClassUniqueViolation},
+		{name: "driver mismatch", driver: "mysql", err: &fakePgError{code: "23505"}, want: ClassUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClassifyError(tt.driver, tt.err); got != tt.want {
+				t.Errorf("ClassifyError(%q, %v) = %v, want %v", tt.driver, tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package sqlkit
+
+import (
+	"database/sql"
+	"log"
+	"time"
+)
+
+// failoverState tracks leader failover; guarded by DB.healthMu.
+type failoverState struct {
+	leaderDownSince time.Time // When the configured leader was first seen unhealthy (zero if healthy)
+	active          bool      // Whether writes are routed to the failover follower
+}
+
+// selectLeader returns the connection that currently serves writes and its target name.
+// This is the configured leader unless HealthConfig.AllowLeaderFailover promoted a follower.
+func (db *DB) selectLeader() (*sql.DB, string) {
+	if !db.config.Health.AllowLeaderFailover {
+		return db.leader, TargetLeader
+	}
+
+	db.healthMu.RLock()
+	active := db.failover.active
+	db.healthMu.RUnlock()
+
+	if active {
+		idx := db.config.Health.FailoverFollower
+		return db.followers[idx], followerTarget(idx)
+	}
+	return db.leader, TargetLeader
+}
+
+// LeaderTarget returns the name of the connection currently serving writes:
+// "leader", or "follower-N" while failed over (see HealthConfig.AllowLeaderFailover).
+// Thread-safe.
+func (db *DB) LeaderTarget() string {
+	_, target := db.selectLeader()
+	return target
+}
+
+// updateFailover promotes or demotes the failover follower after a health check.
+// Must be called with healthMu held.
+// Promotes when the configured leader has been unhealthy for at least
+// HealthConfig.FailoverAfter and the failover follower is healthy.
+// Demotes as soon as the configured leader is healthy again, or when the
+// promoted follower becomes unhealthy.
+func (db *DB) updateFailover(now time.Time) {
+	cfg := db.config.Health
+	if !cfg.AllowLeaderFailover {
+		return
+	}
+	idx := cfg.FailoverFollower
+	followerHealthy := idx >= 0 && idx < len(db.followers) && db.followers[idx] != nil &&
+		db.followerHealthMap[idx].Healthy
+
+	if db.leaderHealth.Healthy {
+		db.failover.leaderDownSince = time.Time{}
+		if db.failover.active {
+			db.failover.active = false
+			log.Printf("sqlkit: leader recovered, routing writes back to leader")
+		}
+		return
+	}
+
+	if db.failover.leaderDownSince.IsZero() {
+		db.failover.leaderDownSince = now
+	}
+
+	switch {
+	case db.failover.active && !followerHealthy:
+		db.failover.active = false
+		log.Printf("sqlkit: warning: failover follower %d unhealthy, routing writes back to leader", idx)
+	case !db.failover.active && followerHealthy && now.Sub(db.failover.leaderDownSince) >= cfg.FailoverAfter:
+		db.failover.active = true
+		log.Printf("sqlkit: warning: leader unhealthy since %s, failing over writes to follower %d",
+			db.failover.leaderDownSince.Format(time.RFC3339), idx)
+	}
+}
@@ -0,0 +1,90 @@
+package sqlkit
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDB_LeaderFailover(t *testing.T) {
+	errDown := errors.New("leader down")
+
+	tests := []struct {
+		name          string
+		allow         bool
+		failoverAfter time.Duration
+		wantTarget    string
+	}{
+		{name: "disabled", allow: false, wantTarget: TargetLeader},
+		{name: "fails over", allow: true, wantTarget: "follower-0"},
+		{name: "waits for threshold", allow: true, failoverAfter: time.Hour, wantTarget: TargetLeader},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			leaderBackend, leader := newFakeDB(t)
+			_, follower := newFakeDB(t)
+			db := newTestDB(Config{Health: HealthConfig{
+				Timeout:             time.Second,
+				AllowLeaderFailover: tt.allow,
+				FailoverAfter:       tt.failoverAfter,
+			}}, leader, follower)
+
+			leaderBackend.mu.Lock()
+			leaderBackend.pingErr = errDown
+			leaderBackend.mu.Unlock()
+			db.checkHealth()
+
+			if got := db.LeaderTarget(); got != tt.wantTarget {
+				t.Errorf("LeaderTarget() = %q, want %q", got, tt.wantTarget)
+			}
+			if got := db.LeaderConn().Target(); got != tt.wantTarget {
+				t.Errorf("LeaderConn().Target() = %q, want %q", got, tt.wantTarget)
+			}
+			wantLeader := leader
+			if tt.wantTarget != TargetLeader {
+				wantLeader = follower
+			}
+			if db.Leader() != wantLeader {
+				t.Errorf("Leader() did not return the %s connection", tt.wantTarget)
+			}
+		})
+	}
+}
+
+func TestDB_LeaderFailover_Recovery(t *testing.T) {
+	leaderBackend, leader := newFakeDB(t)
+	followerBackend, follower := newFakeDB(t)
+	db := newTestDB(Config{Health: HealthConfig{Timeout: time.Second, AllowLeaderFailover: true}}, leader, follower)
+
+	setPingErr := func(b *fakeBackend, err error) {
+		b.mu.Lock()
+		b.pingErr = err
+		b.mu.Unlock()
+	}
+
+	setPingErr(leaderBackend, errors.New("leader down"))
+	db.checkHealth()
+	if got := db.LeaderTarget(); got != "follower-0" {
+		t.Fatalf("LeaderTarget() after leader failure = %q, want follower-0", got)
+	}
+
+	// Promoted follower goes down as well: writes go back to the (unhealthy) leader.
+	setPingErr(followerBackend, errors.New("follower down"))
+	db.checkHealth()
+	if got := db.LeaderTarget(); got != TargetLeader {
+		t.Fatalf("LeaderTarget() after follower failure = %q, want leader", got)
+	}
+
+	// Follower back: fail over again; then the leader recovers and is re-promoted.
+	setPingErr(followerBackend, nil)
+	db.checkHealth()
+	if got := db.LeaderTarget(); got != "follower-0" {
+		t.Fatalf("LeaderTarget() after follower recovery = %q, want follower-0", got)
+	}
+	setPingErr(leaderBackend, nil)
+	db.checkHealth()
+	if got := db.LeaderTarget(); got != TargetLeader {
+		t.Errorf("LeaderTarget() after leader recovery = %q, want leader", got)
+	}
+}
@@ -0,0 +1,239 @@
+package sqlkit
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeBackend is an in-memory database/sql driver used by sqlkit tests.
+// It records every statement and returns results from configurable funcs.
+type fakeBackend struct {
+	mu       sync.Mutex
+	queries  []string
+	execFn   func(query string, args []driver.NamedValue) (driver.Result, error)
+	queryFn  func(query string, args []driver.NamedValue) (driver.Rows, error)
+	ctxFn    func(ctx context.Context) error // Called with the statement context before execFn/queryFn
+	pingErr  error
+	pingHits int
+}
+
+// newFakeDB returns a *sql.DB backed by a new fakeBackend.
+// The connection pool is closed when the test finishes.
+func newFakeDB(t *testing.T) (*fakeBackend, *sql.DB) {
+	t.Helper()
+	backend := &fakeBackend{}
+	conn := sql.OpenDB(backend)
+	t.Cleanup(func() { _ = conn.Close() })
+	return backend, conn
+}
+
+// newTestDB builds a DB around existing connections without dialing, marking all of them healthy.
+func newTestDB(cfg Config, leader *sql.DB, followers ...*sql.DB) *DB {
+	ctx, cancel := context.WithCancel(context.Background())
+	db := &DB{
+		leader:            leader,
+		followers:         followers,
+		config:            cfg,
+		driver:            cfg.Leader.Driver,
+		followerHealthMap: make(map[int]ConnectionHealth),
+		namedHealth:       make(map[string]ConnectionHealth),
+		leaderHealth:      ConnectionHealth{Healthy: true},
+		ctx:               ctx,
+		cancel:            cancel,
+	}
+	for i := range followers {
+		db.followerHealthMap[i] = ConnectionHealth{Healthy: true}
+	}
+	return db
+}
+
+// Queries returns the statements executed so far.
+func (b *fakeBackend) Queries() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string(nil), b.queries...)
+}
+
+func (b *fakeBackend) record(query string) {
+	b.mu.Lock()
+	b.queries = append(b.queries, query)
+	b.mu.Unlock()
+}
+
+// Connect implements driver.Connector.
+func (b *fakeBackend) Connect(context.Context) (driver.Conn, error) {
+	return &fakeConn{backend: b}, nil
+}
+
+// Driver implements driver.Connector.
+func (b *fakeBackend) Driver() driver.Driver {
+	return fakeDriver{backend: b}
+}
+
+type fakeDriver struct {
+	backend *fakeBackend
+}
+
+func (d fakeDriver) Open(string) (driver.Conn, error) {
+	return &fakeConn{backend: d.backend}, nil
+}
+
+type fakeConn struct {
+	backend *fakeBackend
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	c.backend.record("PREPARE " + query)
+	return &fakeStmt{conn: c, query: query}, nil
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *fakeConn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	c.backend.record("BEGIN")
+	return fakeTx{backend: c.backend}, nil
+}
+
+func (c *fakeConn) Ping(context.Context) error {
+	c.backend.mu.Lock()
+	defer c.backend.mu.Unlock()
+	c.backend.pingHits++
+	return c.backend.pingErr
+}
+
+func (c *fakeConn) CheckNamedValue(*driver.NamedValue) error { return nil }
+
+func (c *fakeConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.backend.record(query)
+	if c.backend.ctxFn != nil {
+		if err := c.backend.ctxFn(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if c.backend.execFn != nil {
+		return c.backend.execFn(query, args)
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (c *fakeConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.backend.record(query)
+	if c.backend.ctxFn != nil {
+		if err := c.backend.ctxFn(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if c.backend.queryFn != nil {
+		return c.backend.queryFn(query, args)
+	}
+	return &fakeRows{}, nil
+}
+
+// fakeStmt executes its query through the owning connection on every Exec/Query.
+type fakeStmt struct {
+	conn  *fakeConn
+	query string
+}
+
+func (s *fakeStmt) Close() error { return nil }
+
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("fake: use ExecContext")
+}
+
+func (s *fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return nil, errors.New("fake: use QueryContext")
+}
+
+func (s *fakeStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	return s.conn.ExecContext(ctx, s.query, args)
+}
+
+func (s *fakeStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	return s.conn.QueryContext(ctx, s.query, args)
+}
+
+type fakeTx struct {
+	backend *fakeBackend
+}
+
+func (tx fakeTx) Commit() error {
+	tx.backend.record("COMMIT")
+	return nil
+}
+
+func (tx fakeTx) Rollback() error {
+	tx.backend.record("ROLLBACK")
+	return nil
+}
+
+// fakeRows is a static driver.Rows result set.
+type fakeRows struct {
+	columns []string
+	values  [][]driver.Value
+	pos     int
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+
+func (r *fakeRows) Close() error { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.values) {
+		return io.EOF
+	}
+	copy(dest, r.values[r.pos])
+	r.pos++
+	return nil
+}
+
+// memoryDriver stands in for an in-memory sqlite3 driver: every connection
+// opened with the same DSN shares one set of tables, as with cache=shared.
+// It understands "CREATE TABLE <name> ..." and answers every query with the
+// table names of its database, one per row in column "name".
+type memoryDriver struct {
+	mu     sync.Mutex
+	tables map[string][]string // by DSN
+}
+
+var sqliteMemory = &memoryDriver{tables: make(map[string][]string)}
+
+func init() {
+	sql.Register("sqlite3", sqliteMemory)
+}
+
+// Open implements driver.Driver with a fakeBackend bound to the tables of dsn.
+func (d *memoryDriver) Open(dsn string) (driver.Conn, error) {
+	backend := &fakeBackend{
+		execFn: func(query string, _ []driver.NamedValue) (driver.Result, error) {
+			if fields := strings.Fields(query); len(fields) > 2 && strings.EqualFold(fields[0]+" "+fields[1], "CREATE TABLE") {
+				d.mu.Lock()
+				d.tables[dsn] = append(d.tables[dsn], fields[2])
+				d.mu.Unlock()
+			}
+			return driver.RowsAffected(0), nil
+		},
+		queryFn: func(string, []driver.NamedValue) (driver.Rows, error) {
+			d.mu.Lock()
+			defer d.mu.Unlock()
+			rows := &fakeRows{columns: []string{"name"}}
+			for _, table := range d.tables[dsn] {
+				rows.values = append(rows.values, []driver.Value{table})
+			}
+			return rows, nil
+		},
+	}
+	return &fakeConn{backend: backend}, nil
+}
@@ -3,21 +3,26 @@ package sqlkit
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"maps"
 	"time"
 )
 
 // Health represents the overall health status of database connections.
 type Health struct {
-	Leader    ConnectionHealth   // Leader connection health
-	Followers []ConnectionHealth // Follower connections health
+	Leader    ConnectionHealth            // Leader connection health
+	Followers []ConnectionHealth          // Follower connections health
+	Named     map[string]ConnectionHealth // Named connections health, by name (nil if none)
 }
 
 // ConnectionHealth represents the health status of a single connection.
 type ConnectionHealth struct {
-	Healthy      bool          // Is connection healthy
-	LastCheck    time.Time     // Last health check timestamp
-	Error        string        // Error message if unhealthy (optional)
-	ResponseTime time.Duration // Last ping response time
+	Healthy              bool          // Is connection healthy
+	LastCheck            time.Time     // Last health check timestamp
+	Error                string        // Error message of the last failed check (optional)
+	ResponseTime         time.Duration // Last ping response time
+	ConsecutiveFailures  int           // Failed checks in a row (reset by a success)
+	ConsecutiveSuccesses int           // Successful checks in a row (reset by a failure)
 }
 
 // GetHealth returns current health status of all connections.
@@ -39,6 +44,9 @@ func (db *DB) GetHealth() Health {
 			health.Followers[i] = ConnectionHealth{Healthy: false}
 		}
 	}
+	if len(db.namedHealth) > 0 {
+		health.Named = maps.Clone(db.namedHealth)
+	}
 
 	return health
 }
@@ -51,6 +59,33 @@ func (db *DB) IsHealthy() bool {
 	return db.leaderHealth.Healthy
 }
 
+// Ping pings the leader, bounded by ctx and HealthConfig.Timeout, and returns a
+// descriptive error wrapping ErrLeaderUnhealthy and the driver error on failure.
+// Does not update the stored health state. Returns ErrClosed after Close.
+// Use case: Readiness probes, e.g. httpkit.Readiness(db.Ping).
+func (db *DB) Ping(ctx context.Context) error {
+	if db.closed.Load() {
+		return ErrClosed
+	}
+	ctx, cancel := db.healthTimeout(ctx)
+	defer cancel()
+
+	if err := db.ping(ctx, db.leader); err != nil {
+		return fmt.Errorf("%w: %w", ErrLeaderUnhealthy, err)
+	}
+	return nil
+}
+
+// PingAll performs an on-demand health check of the leader and all followers,
+// updates the stored health (as the background check would, including
+// thresholds and OnHealthChange) and returns the result.
+// Use case: Readiness probes that must reflect current reality rather than the
+// last background check.
+func (db *DB) PingAll(ctx context.Context) Health {
+	db.checkHealthContext(ctx)
+	return db.GetHealth()
+}
+
 // runHealthChecks is a background goroutine that performs periodic health checks.
 // Should be started as goroutine in New().
 // Must respect context cancellation.
@@ -68,73 +103,130 @@ func (db *DB) runHealthChecks() {
 	}
 }
 
+// healthChange records a Healthy transition for Config.Health.OnHealthChange.
+type healthChange struct {
+	target               string
+	oldHealth, newHealth ConnectionHealth
+}
+
 // checkHealth performs health check on all connections.
 // Uses PingContext with timeout.
-// Updates health atomically.
+// Pings run without holding healthMu; results are stored atomically afterwards.
+// Calls HealthConfig.OnHealthChange, outside the lock, for every connection whose
+// Healthy flag changed.
 func (db *DB) checkHealth() {
-	ctx, cancel := context.WithTimeout(db.ctx, db.config.Health.Timeout)
+	db.checkHealthContext(db.ctx)
+}
+
+// checkHealthContext implements checkHealth and PingAll, bounding the pings by
+// parent and HealthConfig.Timeout.
+func (db *DB) checkHealthContext(parent context.Context) {
+	ctx, cancel := db.healthTimeout(parent)
 	defer cancel()
 
 	now := time.Now()
 
-	// Check leader
-	start := time.Now()
-	leaderHealthy := db.ping(ctx, db.leader)
-	leaderResponseTime := time.Since(start)
+	// Check leader and followers
+	leaderProbe := db.probe(ctx, db.leader, now)
+	followerProbes := make([]ConnectionHealth, len(db.followers))
+	for i, follower := range db.followers {
+		followerProbes[i] = db.probe(ctx, follower, now)
+	}
 
-	var leaderError string
-	if !leaderHealthy {
-		leaderError = "ping failed"
+	namedProbes := make(map[string]ConnectionHealth, len(db.named))
+	for name, pool := range db.named {
+		namedProbes[name] = db.probe(ctx, pool.conn, now)
 	}
 
+	var changes []healthChange
+
 	db.healthMu.Lock()
-	db.leaderHealth = ConnectionHealth{
-		Healthy:      leaderHealthy,
-		LastCheck:    now,
-		Error:        leaderError,
-		ResponseTime: leaderResponseTime,
+	leaderHealth := db.config.Health.nextHealth(db.leaderHealth, leaderProbe)
+	if db.leaderHealth.Healthy != leaderHealth.Healthy {
+		changes = append(changes, healthChange{TargetLeader, db.leaderHealth, leaderHealth})
+	}
+	db.leaderHealth = leaderHealth
+	for i, probe := range followerProbes {
+		old := db.followerHealthMap[i]
+		health := db.config.Health.nextHealth(old, probe)
+		if old.Healthy != health.Healthy {
+			changes = append(changes, healthChange{followerTarget(i), old, health})
+		}
+		db.followerHealthMap[i] = health
 	}
+	for name, probe := range namedProbes {
+		old := db.namedHealth[name]
+		health := db.config.Health.nextHealth(old, probe)
+		if old.Healthy != health.Healthy {
+			changes = append(changes, healthChange{name, old, health})
+		}
+		db.namedHealth[name] = health
+	}
+	db.updateFailover(now)
 	db.healthMu.Unlock()
 
-	// Check followers
-	db.healthMu.Lock()
-	for i, follower := range db.followers {
-		if follower == nil {
-			db.followerHealthMap[i] = ConnectionHealth{
-				Healthy:   false,
-				LastCheck: now,
-				Error:     "connection is nil",
-			}
-			continue
+	if onChange := db.config.Health.OnHealthChange; onChange != nil {
+		for _, c := range changes {
+			onChange(c.target, c.oldHealth, c.newHealth)
 		}
+	}
+}
 
-		start := time.Now()
-		followerHealthy := db.ping(ctx, follower)
-		followerResponseTime := time.Since(start)
+// nextHealth combines the previous health with a new probe result, applying the
+// consecutive failure/success thresholds before flipping Healthy.
+func (c HealthConfig) nextHealth(prev, probe ConnectionHealth) ConnectionHealth {
+	next := probe
+	if probe.Healthy {
+		next.ConsecutiveSuccesses = prev.ConsecutiveSuccesses + 1
+	} else {
+		next.ConsecutiveFailures = prev.ConsecutiveFailures + 1
+	}
 
-		var followerError string
-		if !followerHealthy {
-			followerError = "ping failed"
-		}
+	switch {
+	case prev.Healthy && !probe.Healthy:
+		next.Healthy = next.ConsecutiveFailures < max(c.UnhealthyThreshold, 1)
+	case !prev.Healthy && probe.Healthy:
+		next.Healthy = next.ConsecutiveSuccesses >= max(c.HealthyThreshold, 1)
+	}
+	return next
+}
 
-		db.followerHealthMap[i] = ConnectionHealth{
-			Healthy:      followerHealthy,
-			LastCheck:    now,
-			Error:        followerError,
-			ResponseTime: followerResponseTime,
+// probe pings conn and returns the result of this single check.
+func (db *DB) probe(ctx context.Context, conn *sql.DB, now time.Time) ConnectionHealth {
+	if conn == nil {
+		return ConnectionHealth{
+			Healthy:   false,
+			LastCheck: now,
+			Error:     "connection is nil",
 		}
 	}
-	db.healthMu.Unlock()
+
+	start := time.Now()
+	err := db.ping(ctx, conn)
+	health := ConnectionHealth{
+		Healthy:      err == nil,
+		LastCheck:    now,
+		ResponseTime: time.Since(start),
+	}
+	if err != nil {
+		health.Error = err.Error()
+	}
+	return health
 }
 
 // ping pings a single connection to check health.
-// Returns true if ping succeeds, false otherwise.
-func (db *DB) ping(ctx context.Context, conn *sql.DB) bool {
+// Returns nil if ping succeeds, the driver error otherwise.
+func (db *DB) ping(ctx context.Context, conn *sql.DB) error {
 	if conn == nil {
-		return false
+		return ErrNoConnection
 	}
-	if err := conn.PingContext(ctx); err != nil {
-		return false
+	return conn.PingContext(ctx)
+}
+
+// healthTimeout derives a context bounded by HealthConfig.Timeout (if set).
+func (db *DB) healthTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if db.config.Health.Timeout > 0 {
+		return context.WithTimeout(ctx, db.config.Health.Timeout)
 	}
-	return true
+	return context.WithCancel(ctx)
 }
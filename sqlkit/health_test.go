@@ -0,0 +1,192 @@
+package sqlkit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDB_checkHealth_OnHealthChange(t *testing.T) {
+	_, leader := newFakeDB(t)
+	followerBackend, follower := newFakeDB(t)
+
+	type change struct {
+		target   string
+		old, new bool
+	}
+	var changes []change
+	var db *DB
+	cfg := Config{Health: HealthConfig{
+		Timeout: time.Second,
+		OnHealthChange: func(target string, oldHealth, newHealth ConnectionHealth) {
+			// Must not deadlock: the callback runs without healthMu held.
+			_ = db.GetHealth()
+			changes = append(changes, change{target, oldHealth.Healthy, newHealth.Healthy})
+		},
+	}}
+	db = newTestDB(cfg, leader, follower)
+	defer db.cancel()
+
+	// No transitions: everything starts healthy and stays healthy.
+	db.checkHealth()
+	if len(changes) != 0 {
+		t.Fatalf("changes = %v, want none", changes)
+	}
+
+	followerBackend.mu.Lock()
+	followerBackend.pingErr = errors.New("replica down")
+	followerBackend.mu.Unlock()
+	db.checkHealth()
+	db.checkHealth() // still down: no repeated callback
+
+	followerBackend.mu.Lock()
+	followerBackend.pingErr = nil
+	followerBackend.mu.Unlock()
+	db.checkHealth()
+
+	want := []change{
+		{target: "follower-0", old: true, new: false},
+		{target: "follower-0", old: false, new: true},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("changes = %v, want %v", changes, want)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("changes[%d] = %v, want %v", i, changes[i], want[i])
+		}
+	}
+}
+
+func TestDB_checkHealth_FollowerDownRemovedFromRotation(t *testing.T) {
+	_, leader := newFakeDB(t)
+	followerBackend, follower := newFakeDB(t)
+	followerBackend.pingErr = errors.New("replica down")
+
+	db := newTestDB(Config{Health: HealthConfig{Timeout: time.Second}}, leader, follower)
+	defer db.cancel()
+	db.checkHealth()
+
+	health := db.GetHealth()
+	if !health.Leader.Healthy {
+		t.Error("GetHealth().Leader.Healthy = false, want true")
+	}
+	if health.Followers[0].Healthy || health.Followers[0].Error == "" {
+		t.Errorf("GetHealth().Followers[0] = %+v, want unhealthy with error", health.Followers[0])
+	}
+	if got := db.Follower(); got != leader {
+		t.Error("Follower() did not fall back to the leader")
+	}
+}
+
+func TestHealthConfig_nextHealth_Thresholds(t *testing.T) {
+	tests := []struct {
+		name      string
+		unhealthy int
+		healthy   int
+		pings     []bool
+		want      []bool
+	}{
+		{
+			name:  "default thresholds flip immediately",
+			pings: []bool{false, true, false},
+			want:  []bool{false, true, false},
+		},
+		{
+			name:      "unhealthy after three consecutive failures",
+			unhealthy: 3,
+			pings:     []bool{false, false, true, false, false, false},
+			want:      []bool{true, true, true, true, true, false},
+		},
+		{
+			name:      "healthy again after two consecutive successes",
+			unhealthy: 1,
+			healthy:   2,
+			pings:     []bool{false, true, false, true, true},
+			want:      []bool{false, false, false, false, true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := HealthConfig{UnhealthyThreshold: tt.unhealthy, HealthyThreshold: tt.healthy}
+			health := ConnectionHealth{Healthy: true}
+			for i, ok := range tt.pings {
+				health = cfg.nextHealth(health, ConnectionHealth{Healthy: ok})
+				if health.Healthy != tt.want[i] {
+					t.Errorf("after ping %d (%v): Healthy = %v, want %v", i, ok, health.Healthy, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestHealthConfig_nextHealth_Counters(t *testing.T) {
+	cfg := HealthConfig{UnhealthyThreshold: 2}
+	health := ConnectionHealth{Healthy: true}
+
+	health = cfg.nextHealth(health, ConnectionHealth{Healthy: false, Error: "ping failed"})
+	if health.ConsecutiveFailures != 1 || health.ConsecutiveSuccesses != 0 {
+		t.Errorf("counters = (%d failures, %d successes), want (1, 0)",
+			health.ConsecutiveFailures, health.ConsecutiveSuccesses)
+	}
+	if !health.Healthy || health.Error != "ping failed" {
+		t.Errorf("health = %+v, want healthy with the last error recorded", health)
+	}
+
+	health = cfg.nextHealth(health, ConnectionHealth{Healthy: true})
+	if health.ConsecutiveFailures != 0 || health.ConsecutiveSuccesses != 1 {
+		t.Errorf("counters = (%d failures, %d successes), want (0, 1)",
+			health.ConsecutiveFailures, health.ConsecutiveSuccesses)
+	}
+}
+
+func TestDB_Ping(t *testing.T) {
+	errDown := errors.New("connection refused")
+
+	tests := []struct {
+		name    string
+		pingErr error
+		wantErr []error
+	}{
+		{name: "healthy leader", pingErr: nil},
+		{name: "unhealthy leader", pingErr: errDown, wantErr: []error{ErrLeaderUnhealthy, errDown}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend, leader := newFakeDB(t)
+			backend.pingErr = tt.pingErr
+			db := newTestDB(Config{Health: HealthConfig{Timeout: time.Second}}, leader)
+
+			err := db.Ping(context.Background())
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("Ping() error = %v, want nil", err)
+			}
+			for _, want := range tt.wantErr {
+				if !errors.Is(err, want) {
+					t.Errorf("Ping() error = %v, want it to wrap %v", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestDB_PingAll(t *testing.T) {
+	_, leader := newFakeDB(t)
+	followerBackend, follower := newFakeDB(t)
+	followerBackend.pingErr = errors.New("replica lagging")
+	db := newTestDB(Config{}, leader, follower)
+
+	health := db.PingAll(context.Background())
+	if !health.Leader.Healthy || health.Leader.LastCheck.IsZero() {
+		t.Errorf("PingAll().Leader = %+v, want healthy and checked", health.Leader)
+	}
+	if health.Followers[0].Healthy || health.Followers[0].Error != "replica lagging" {
+		t.Errorf("PingAll().Followers[0] = %+v, want unhealthy with driver error", health.Followers[0])
+	}
+	if db.GetHealth().Followers[0].Healthy {
+		t.Error("GetHealth() after PingAll() still reports the follower healthy")
+	}
+}
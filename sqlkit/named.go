@@ -0,0 +1,106 @@
+package sqlkit
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// namedPool is an auxiliary connection opened from Config.Named.
+type namedPool struct {
+	conn   *sql.DB // nil when the connection failed at startup
+	driver string
+}
+
+// namedConnectionName is the allowed form of a Config.Named key.
+var namedConnectionName = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+
+// validateNamedConnection checks a Config.Named entry. Names are lower-case letters,
+// digits, '_' and '-', starting with a letter, and must not collide with the leader
+// and follower target names used in Stats, GetHealth and QueryEvent.Target.
+func validateNamedConnection(name string, cfg *DBConfig) error {
+	if !namedConnectionName.MatchString(name) {
+		return fmt.Errorf("%w: named connection %q: name must match %s", ErrInvalidConfig, name, namedConnectionName)
+	}
+	if name == TargetLeader || strings.HasPrefix(name, "follower-") {
+		return fmt.Errorf("%w: named connection %q: name is reserved", ErrInvalidConfig, name)
+	}
+	if cfg.Database == "" {
+		return fmt.Errorf("%w: named connection %q: database is required", ErrInvalidConfig, name)
+	}
+	return nil
+}
+
+// Named returns the auxiliary connection configured under name in Config.Named,
+// e.g. a reporting warehouse kept apart from the OLTP followers. Named pools are
+// never used by Leader, Follower or transactions; callers opt in per query.
+// Returns an error wrapping ErrUnknownConnection when name is not configured, and
+// ErrNoConnection when it is configured but failed to connect at startup, and
+// ErrClosed after Close.
+// Health is tracked per pool (GetHealth().Named) but an unhealthy pool is still
+// returned, since there is nothing to fall back to.
+// Thread-safe.
+func (db *DB) Named(name string) (*sql.DB, error) {
+	if db.closed.Load() {
+		return nil, ErrClosed
+	}
+	pool, ok := db.named[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q (configured: %s)", ErrUnknownConnection, name, db.namedList())
+	}
+	if pool.conn == nil {
+		return nil, fmt.Errorf("%w: named connection %q failed to connect", ErrNoConnection, name)
+	}
+	return pool.conn, nil
+}
+
+// NamedConn is like Named but wraps the connection in a Conn so Config.OnQuery
+// fires, with name as QueryEvent.Target.
+// Thread-safe.
+func (db *DB) NamedConn(name string) (*Conn, error) {
+	conn, err := db.Named(name)
+	if err != nil {
+		return nil, err
+	}
+	c := db.newConn(conn, name)
+	c.driver = db.named[name].driver
+	return c, nil
+}
+
+// namedList returns the configured names, sorted and comma-separated, for error messages.
+func (db *DB) namedList() string {
+	if len(db.named) == 0 {
+		return "none"
+	}
+	return strings.Join(slices.Sorted(maps.Keys(db.named)), ", ")
+}
+
+// initNamed opens the connections of Config.Named.
+// Like followers, a connection that fails is logged and left unconnected instead of
+// failing New. A named config without a Driver uses the leader's.
+// Never returns error (named connections are optional).
+func (db *DB) initNamed() {
+	db.named = make(map[string]namedPool, len(db.config.Named))
+	for _, name := range slices.Sorted(maps.Keys(db.config.Named)) {
+		cfg := db.config.Named[name]
+		if cfg.Driver == "" {
+			cfg.Driver = db.driver
+		}
+		conn, err := db.connect(&cfg)
+		health := ConnectionHealth{Healthy: err == nil, LastCheck: time.Now()}
+		if err != nil {
+			log.Printf("sqlkit: warning: failed to connect to named connection %q: %v", name, err)
+			health.Error = err.Error()
+		}
+		db.named[name] = namedPool{conn: conn, driver: cfg.Driver}
+
+		db.healthMu.Lock()
+		db.namedHealth[name] = health
+		db.healthMu.Unlock()
+	}
+}
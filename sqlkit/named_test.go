@@ -0,0 +1,111 @@
+package sqlkit
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestConfig_Validate_Named(t *testing.T) {
+	tests := []struct {
+		name    string
+		named   map[string]DBConfig
+		wantErr bool
+	}{
+		{name: "none", named: nil},
+		{name: "valid", named: map[string]DBConfig{"analytics": {Database: "dw"}, "audit_log-2": {Database: "audit"}}},
+		{name: "missing database", named: map[string]DBConfig{"analytics": {}}, wantErr: true},
+		{name: "upper case", named: map[string]DBConfig{"Analytics": {Database: "dw"}}, wantErr: true},
+		{name: "empty name", named: map[string]DBConfig{"": {Database: "dw"}}, wantErr: true},
+		{name: "reserved leader", named: map[string]DBConfig{"leader": {Database: "dw"}}, wantErr: true},
+		{name: "reserved follower", named: map[string]DBConfig{"follower-0": {Database: "dw"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			leader := DBConfig{Driver: "postgres", Host: "localhost", Database: "app"}
+			cfg := Config{Leader: leader, Named: tt.named}
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidConfig) {
+				t.Errorf("Validate() error = %v, want ErrInvalidConfig", err)
+			}
+		})
+	}
+}
+
+func TestDB_Named(t *testing.T) {
+	_, leader := newFakeDB(t)
+	_, analytics := newFakeDB(t)
+	db := newTestDB(Config{Leader: DBConfig{Driver: "postgres"}}, leader)
+	defer db.cancel()
+	db.named = map[string]namedPool{
+		"analytics": {conn: analytics, driver: "mysql"},
+		"archive":   {driver: "postgres"}, // failed at startup
+	}
+
+	got, err := db.Named("analytics")
+	if err != nil {
+		t.Fatalf("Named(analytics) error = %v", err)
+	}
+	if got != analytics {
+		t.Error("Named(analytics) did not return the analytics pool")
+	}
+
+	if _, err := db.Named("reporting"); !errors.Is(err, ErrUnknownConnection) {
+		t.Errorf("Named(reporting) error = %v, want ErrUnknownConnection", err)
+	}
+	if _, err := db.Named("archive"); !errors.Is(err, ErrNoConnection) {
+		t.Errorf("Named(archive) error = %v, want ErrNoConnection", err)
+	}
+
+	conn, err := db.NamedConn("analytics")
+	if err != nil {
+		t.Fatalf("NamedConn(analytics) error = %v", err)
+	}
+	if conn.Target() != "analytics" {
+		t.Errorf("NamedConn(analytics).Target() = %q, want %q", conn.Target(), "analytics")
+	}
+	if conn.driver != "mysql" {
+		t.Errorf("NamedConn(analytics).driver = %q, want %q", conn.driver, "mysql")
+	}
+}
+
+func TestDB_Named_HealthAndStats(t *testing.T) {
+	_, leader := newFakeDB(t)
+	analyticsBackend, analytics := newFakeDB(t)
+
+	var targets []string
+	cfg := Config{Health: HealthConfig{
+		Timeout: time.Second,
+		OnHealthChange: func(target string, _, _ ConnectionHealth) {
+			targets = append(targets, target)
+		},
+	}}
+	db := newTestDB(cfg, leader)
+	defer db.cancel()
+	db.named = map[string]namedPool{"analytics": {conn: analytics}}
+	db.namedHealth["analytics"] = ConnectionHealth{Healthy: true}
+
+	analyticsBackend.mu.Lock()
+	analyticsBackend.pingErr = errors.New("warehouse down")
+	analyticsBackend.mu.Unlock()
+	db.checkHealth()
+
+	health := db.GetHealth()
+	if health.Named["analytics"].Healthy {
+		t.Error("GetHealth().Named[analytics].Healthy = true, want false")
+	}
+	if !health.Leader.Healthy {
+		t.Error("GetHealth().Leader.Healthy = false, want true")
+	}
+	if len(targets) != 1 || targets[0] != "analytics" {
+		t.Errorf("OnHealthChange targets = %v, want [analytics]", targets)
+	}
+
+	stats := db.Stats()
+	if _, ok := stats["analytics"]; !ok {
+		t.Error("Stats() missing \"analytics\"")
+	}
+}
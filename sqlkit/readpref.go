@@ -0,0 +1,82 @@
+package sqlkit
+
+import (
+	"context"
+	"database/sql"
+)
+
+// ReadPreference controls which connection serves reads made via Reader and ReaderConn.
+type ReadPreference int
+
+const (
+	// PreferFollower reads from a healthy follower, falling back to the leader (default).
+	PreferFollower ReadPreference = iota
+	// PreferLeader reads from the leader while it is healthy, otherwise from a follower.
+	PreferLeader
+	// LeaderOnly always reads from the leader, regardless of health.
+	LeaderOnly
+)
+
+// String returns the preference name.
+func (p ReadPreference) String() string {
+	switch p {
+	case PreferFollower:
+		return "prefer_follower"
+	case PreferLeader:
+		return "prefer_leader"
+	case LeaderOnly:
+		return "leader_only"
+	default:
+		return "unknown"
+	}
+}
+
+// readPreferenceKey is an empty struct used as context key for read preference.
+type readPreferenceKey struct{}
+
+// WithReadPreference returns a context that routes reads according to pref.
+// Use case: Force read-your-writes consistency for specific queries without a transaction:
+//
+//	ctx = sqlkit.WithReadPreference(ctx, sqlkit.PreferLeader)
+//	user, err := repo.GetByID(ctx, id)
+func WithReadPreference(ctx context.Context, pref ReadPreference) context.Context {
+	return context.WithValue(ctx, readPreferenceKey{}, pref)
+}
+
+// ReadPreferenceFromContext returns the read preference stored in ctx, or PreferFollower if none.
+func ReadPreferenceFromContext(ctx context.Context) ReadPreference {
+	if pref, ok := ctx.Value(readPreferenceKey{}).(ReadPreference); ok {
+		return pref
+	}
+	return PreferFollower
+}
+
+// Reader returns the connection for a read, honoring the read preference in ctx.
+// Without a preference it behaves like Follower.
+// Thread-safe.
+func (db *DB) Reader(ctx context.Context) *sql.DB {
+	conn, _ := db.selectReader(ctx)
+	return conn
+}
+
+// ReaderConn is like Reader but wraps the connection in a Conn so Config.OnQuery fires.
+// Thread-safe.
+func (db *DB) ReaderConn(ctx context.Context) *Conn {
+	conn, target := db.selectReader(ctx)
+	return db.newConn(conn, target)
+}
+
+// selectReader picks the read connection and its target name according to the preference in ctx.
+func (db *DB) selectReader(ctx context.Context) (*sql.DB, string) {
+	switch ReadPreferenceFromContext(ctx) {
+	case LeaderOnly:
+		return db.selectLeader()
+	case PreferLeader:
+		if db.IsHealthy() {
+			return db.selectLeader()
+		}
+		return db.selectFollower()
+	default:
+		return db.selectFollower()
+	}
+}
@@ -0,0 +1,84 @@
+package sqlkit
+
+import (
+	"context"
+	"testing"
+)
+
+func TestReadPreferenceFromContext(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want ReadPreference
+	}{
+		{name: "default", ctx: context.Background(), want: PreferFollower},
+		{name: "prefer leader", ctx: WithReadPreference(context.Background(), PreferLeader), want: PreferLeader},
+		{name: "leader only", ctx: WithReadPreference(context.Background(), LeaderOnly), want: LeaderOnly},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ReadPreferenceFromContext(tt.ctx); got != tt.want {
+				t.Errorf("ReadPreferenceFromContext() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDB_Reader(t *testing.T) {
+	tests := []struct {
+		name            string
+		pref            ReadPreference
+		leaderHealthy   bool
+		followerHealthy bool
+		wantTarget      string
+	}{
+		{name: "prefer follower", pref: PreferFollower, leaderHealthy: true, followerHealthy: true, wantTarget: "follower-0"},
+		{name: "prefer follower, follower down", pref: PreferFollower, leaderHealthy: true, wantTarget: TargetLeader},
+		{name: "prefer leader", pref: PreferLeader, leaderHealthy: true, followerHealthy: true, wantTarget: TargetLeader},
+		{name: "prefer leader, leader down", pref: PreferLeader, followerHealthy: true, wantTarget: "follower-0"},
+		{name: "leader only, leader down", pref: LeaderOnly, followerHealthy: true, wantTarget: TargetLeader},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, leader := newFakeDB(t)
+			_, follower := newFakeDB(t)
+			db := newTestDB(Config{}, leader, follower)
+			db.leaderHealth = ConnectionHealth{Healthy: tt.leaderHealthy}
+			db.followerHealthMap[0] = ConnectionHealth{Healthy: tt.followerHealthy}
+
+			ctx := WithReadPreference(context.Background(), tt.pref)
+			conn := db.ReaderConn(ctx)
+			if conn.Target() != tt.wantTarget {
+				t.Errorf("ReaderConn().Target() = %q, want %q", conn.Target(), tt.wantTarget)
+			}
+
+			wantDB := leader
+			if tt.wantTarget != TargetLeader {
+				wantDB = follower
+			}
+			if got := db.Reader(ctx); got != wantDB {
+				t.Errorf("Reader() did not return the %s connection", tt.wantTarget)
+			}
+		})
+	}
+}
+
+func TestDB_WithReadOnlyTransaction_ReadPreference(t *testing.T) {
+	leaderBackend, leader := newFakeDB(t)
+	followerBackend, follower := newFakeDB(t)
+	db := newTestDB(Config{}, leader, follower)
+
+	ctx := WithReadPreference(context.Background(), LeaderOnly)
+	if err := db.WithReadOnlyTransaction(ctx, func(context.Context) error { return nil }); err != nil {
+		t.Fatalf("WithReadOnlyTransaction() error = %v", err)
+	}
+
+	if got := len(leaderBackend.Queries()); got != 2 {
+		t.Errorf("leader statements = %d, want 2 (BEGIN, COMMIT)", got)
+	}
+	if got := len(followerBackend.Queries()); got != 0 {
+		t.Errorf("follower statements = %d, want 0", got)
+	}
+}
@@ -0,0 +1,197 @@
+package sqlkit
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net"
+	"reflect"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ExecWithRetry executes a statement on the leader, retrying transient errors
+// (see IsTransientError) with exponential backoff and jitter per Config.Retry.
+// Non-transient errors such as constraint violations are returned immediately.
+// Each attempt is reported to Config.OnQuery.
+// Not transaction-aware: statements always run on the leader pool, never on a
+// transaction in ctx (a failed statement aborts the transaction anyway).
+// Only use for idempotent statements: a connection error may hide a committed write.
+func (db *DB) ExecWithRetry(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	var result sql.Result
+	err := db.retry(ctx, func() error {
+		var err error
+		result, err = db.LeaderConn().ExecContext(ctx, query, args...)
+		return err
+	})
+	return result, err
+}
+
+// QueryWithRetry executes a query on the read connection selected by Reader(ctx),
+// retrying transient errors like ExecWithRetry. Each attempt re-selects the
+// connection, so a retry may be served by a different follower.
+func (db *DB) QueryWithRetry(ctx context.Context, query string, args ...any) (*Rows, error) {
+	var rows *Rows
+	err := db.retry(ctx, func() error {
+		var err error
+		rows, err = db.ReaderConn(ctx).QueryContext(ctx, query, args...)
+		return err
+	})
+	return rows, err
+}
+
+// retry runs fn until it succeeds, returns a non-transient error, or attempts are exhausted.
+func (db *DB) retry(ctx context.Context, fn func() error) error {
+	isTransient := db.config.Retry.IsTransient
+	if isTransient == nil {
+		isTransient = func(err error) bool { return IsTransientError(db.driver, err) }
+	}
+	return db.retryWhen(ctx, db.config.Retry.MaxAttempts, isTransient, fn)
+}
+
+// retryWhen runs fn up to attempts times (at least once), retrying while retryable
+// reports true, with the Config.Retry backoff between attempts.
+func (db *DB) retryWhen(ctx context.Context, attempts int, retryable func(error) bool, fn func() error) error {
+	cfg := db.config.Retry
+	attempts = max(attempts, 1)
+
+	var err error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if err = fn(); err == nil || !retryable(err) {
+			return err
+		}
+		if attempt == attempts-1 {
+			break
+		}
+
+		timer := time.NewTimer(cfg.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.Join(err, ctx.Err())
+		case <-timer.C:
+		}
+	}
+
+	return fmt.Errorf("sqlkit: giving up after %d attempts: %w", attempts, err)
+}
+
+// backoff returns the delay before retry n (0-based).
+func (c RetryConfig) backoff(n int) time.Duration {
+	multiplier := math.Max(c.Multiplier, 1)
+	delay := float64(c.InitialDelay) * math.Pow(multiplier, float64(n))
+	if c.MaxDelay > 0 && delay > float64(c.MaxDelay) {
+		delay = float64(c.MaxDelay)
+	}
+	if c.Jitter > 0 {
+		jitter := math.Min(c.Jitter, 1)
+		delay *= 1 - jitter + 2*jitter*rand.Float64() //nolint:gosec // jitter does not need a CSPRNG
+	}
+	return time.Duration(delay)
+}
+
+// Transient PostgreSQL SQLSTATE codes (class 08, connection exceptions, is matched separately).
+var transientSQLStates = map[string]bool{
+	"40001": true, // serialization_failure
+	"40P01": true, // deadlock_detected
+	"53300": true, // too_many_connections
+	"57P01": true, // admin_shutdown
+	"57P02": true, // crash_shutdown
+	"57P03": true, // cannot_connect_now
+}
+
+// Transient MySQL error numbers.
+var transientMySQLErrors = map[int64]bool{
+	1040: true, // ER_CON_COUNT_ERROR (too many connections)
+	1205: true, // ER_LOCK_WAIT_TIMEOUT
+	1213: true, // ER_LOCK_DEADLOCK
+	2006: true, // CR_SERVER_GONE_ERROR
+	2013: true, // CR_SERVER_LOST
+}
+
+// Transient SQLite result codes.
+var transientSQLiteErrors = map[int64]bool{
+	5: true, // SQLITE_BUSY
+	6: true, // SQLITE_LOCKED
+}
+
+// IsTransientError reports whether err, returned by the given driver, is worth retrying.
+// For every driver: driver.ErrBadConn, unexpected EOF, connection reset/refused/broken
+// pipe and network timeouts. Context cancellation and deadlines are never transient.
+// Per driver:
+//   - postgres/pgx: SQLSTATE class 08 and 40001, 40P01, 53300, 57P01-57P03, read from
+//     errors exposing SQLState() string (lib/pq, pgconn)
+//   - mysql: error numbers 1040, 1205, 1213, 2006, 2013, read from the Number field
+//     (go-sql-driver/mysql MySQLError)
+//   - sqlite3: SQLITE_BUSY and SQLITE_LOCKED, read from the Code field (mattn/go-sqlite3)
+//
+// Other drivers fall back to the SQLSTATE check.
+func IsTransientError(driverName string, err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if isConnectionError(err) {
+		return true
+	}
+
+	switch driverName {
+	case "mysql":
+		n, ok := errorCodeField(err, "Number")
+		return ok && transientMySQLErrors[n]
+	case "sqlite3":
+		n, ok := errorCodeField(err, "Code")
+		return ok && transientSQLiteErrors[n]
+	default:
+		var stateErr interface{ SQLState() string }
+		if !errors.As(err, &stateErr) {
+			return false
+		}
+		state := stateErr.SQLState()
+		return strings.HasPrefix(state, "08") || transientSQLStates[state]
+	}
+}
+
+// isConnectionError reports whether err indicates a broken or unreachable connection.
+func isConnectionError(err error) bool {
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// errorCodeField returns the integer field name of the first error in err's chain
+// that is a struct (or pointer to struct) with such a field. It lets sqlkit classify
+// driver errors without importing the drivers.
+func errorCodeField(err error, name string) (int64, bool) {
+	for ; err != nil; err = errors.Unwrap(err) {
+		v := reflect.ValueOf(err)
+		if v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				continue
+			}
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct {
+			continue
+		}
+		f := v.FieldByName(name)
+		switch {
+		case !f.IsValid():
+			continue
+		case f.CanInt():
+			return f.Int(), true
+		case f.CanUint():
+			return int64(f.Uint()), true //nolint:gosec // driver error codes fit in int64
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,157 @@
+package sqlkit
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// fakePgError mimics lib/pq and pgconn errors, which expose SQLState().
+type fakePgError struct{ code string }
+
+func (e *fakePgError) Error() string    { return "pq: " + e.code }
+func (e *fakePgError) SQLState() string { return e.code }
+
+// fakeMySQLError mimics go-sql-driver/mysql MySQLError.
+type fakeMySQLError struct{ Number uint16 }
+
+func (e *fakeMySQLError) Error() string { return fmt.Sprintf("Error %d", e.Number) }
+
+// fakeSQLiteError mimics mattn/go-sqlite3 Error, whose Code is an int-based type.
+type fakeSQLiteError struct{ Code fakeErrNo }
+
+type fakeErrNo int
+
+func (e fakeSQLiteError) Error() string { return fmt.Sprintf("sqlite error %d", e.Code) }
+
+func TestIsTransientError(t *testing.T) {
+	tests := []struct {
+		name   string
+		driver string
+		err    error
+		want   bool
+	}{
+		{name: "nil", driver: "postgres", err: nil, want: false},
+		{name: "plain error", driver: "postgres", err: errors.New("boom"), want: false},
+		{name: "context canceled", driver: "postgres", err: context.Canceled, want: false},
+		{name: "bad conn", driver: "postgres", err: driver.ErrBadConn, want: true},
+		{name: "wrapped conn reset", driver: "mysql", err: fmt.Errorf("read: %w", syscall.ECONNRESET), want: true},
+		{name: "postgres deadlock", driver: "postgres", err: &fakePgError{code: "40P01"}, want: true},
+		{name: "postgres serialization", driver: "pgx", err: &fakePgError{code: "40001"}, want: true},
+		{name: "postgres connection failure", driver: "postgres", err: &fakePgError{code: "08006"}, want: true},
+		{name: "postgres unique violation", driver: "postgres", err: &fakePgError{code: "23505"}, want: false},
+		{name: "mysql deadlock", driver: "mysql", err: fmt.Errorf("exec: %w", &fakeMySQLError{Number: 1213}), want: true},
+		{name: "mysql duplicate entry", driver: "mysql", err: &fakeMySQLError{Number: 1062}, want: false},
+		{name: "sqlite busy", driver: "sqlite3", err: fakeSQLiteError{Code: 5}, want: true},
+		{name: "sqlite constraint", driver: "sqlite3", err: fakeSQLiteError{Code: 19}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsTransientError(tt.driver, tt.err); got != tt.want {
+				t.Errorf("IsTransientError(%q, %v) = %v, want %v", tt.driver, tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryConfig_backoff(t *testing.T) {
+	cfg := RetryConfig{InitialDelay: 100 * time.Millisecond, MaxDelay: time.Second, Multiplier: 2}
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond,
+		800 * time.Millisecond, time.Second, time.Second}
+	for n, w := range want {
+		if got := cfg.backoff(n); got != w {
+			t.Errorf("backoff(%d) = %v, want %v", n, got, w)
+		}
+	}
+
+	cfg.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		got := cfg.backoff(0)
+		if got < 50*time.Millisecond || got > 150*time.Millisecond {
+			t.Fatalf("backoff(0) with jitter = %v, want within [50ms, 150ms]", got)
+		}
+	}
+}
+
+func TestDB_ExecWithRetry(t *testing.T) {
+	deadlock := &fakePgError{code: "40P01"}
+	unique := &fakePgError{code: "23505"}
+
+	tests := []struct {
+		name         string
+		failures     []error
+		wantAttempts int
+		wantErr      error
+	}{
+		{name: "success", wantAttempts: 1},
+		{name: "transient then success", failures: []error{deadlock}, wantAttempts: 2},
+		{name: "non-transient not retried", failures: []error{unique}, wantAttempts: 1, wantErr: unique},
+		{name: "gives up", failures: []error{deadlock, deadlock, deadlock, deadlock}, wantAttempts: 3, wantErr: deadlock},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend, leader := newFakeDB(t)
+			calls := 0
+			backend.execFn = func(string, []driver.NamedValue) (driver.Result, error) {
+				calls++
+				if calls <= len(tt.failures) {
+					return nil, tt.failures[calls-1]
+				}
+				return driver.RowsAffected(1), nil
+			}
+			retry := RetryConfig{MaxAttempts: 3, InitialDelay: time.Millisecond, Multiplier: 2}
+			db := newTestDB(Config{Leader: DBConfig{Driver: "postgres"}, Retry: retry}, leader)
+
+			_, err := db.ExecWithRetry(context.Background(), "UPDATE t SET x = 1")
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("ExecWithRetry() error = %v, want %v", err, tt.wantErr)
+			}
+			if calls != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", calls, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestDB_QueryWithRetry_ContextCanceled(t *testing.T) {
+	backend, leader := newFakeDB(t)
+	backend.queryFn = func(string, []driver.NamedValue) (driver.Rows, error) {
+		return nil, &fakePgError{code: "40001"}
+	}
+	retry := RetryConfig{MaxAttempts: 5, InitialDelay: time.Hour}
+	db := newTestDB(Config{Leader: DBConfig{Driver: "postgres"}, Retry: retry}, leader)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := db.QueryWithRetry(ctx, "SELECT 1")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("QueryWithRetry() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if got := len(backend.Queries()); got != 1 {
+		t.Errorf("attempts = %d, want 1", got)
+	}
+}
+
+func TestDB_RetryCustomClassifier(t *testing.T) {
+	errCustom := errors.New("custom transient")
+	backend, leader := newFakeDB(t)
+	backend.execFn = func(string, []driver.NamedValue) (driver.Result, error) {
+		return nil, errCustom
+	}
+	retry := RetryConfig{MaxAttempts: 2, IsTransient: func(err error) bool { return errors.Is(err, errCustom) }}
+	db := newTestDB(Config{Leader: DBConfig{Driver: "postgres"}, Retry: retry}, leader)
+
+	if _, err := db.ExecWithRetry(context.Background(), "DELETE FROM t"); !errors.Is(err, errCustom) {
+		t.Errorf("ExecWithRetry() error = %v, want %v", err, errCustom)
+	}
+	if got := len(backend.Queries()); got != 2 {
+		t.Errorf("attempts = %d, want 2", got)
+	}
+}
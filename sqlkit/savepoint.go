@@ -0,0 +1,99 @@
+package sqlkit
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync/atomic"
+)
+
+// savepointSeq makes savepoint names unique within the process.
+var savepointSeq atomic.Uint64
+
+// savepointSyntax holds the statement formats (taking the savepoint name) for a driver.
+// release is empty when the database has no RELEASE statement.
+type savepointSyntax struct {
+	create   string
+	rollback string
+	release  string
+}
+
+// savepointSyntaxFor returns the savepoint statements for the given driver.
+// PostgreSQL, MySQL and SQLite use the SQL standard syntax; SQL Server uses
+// SAVE/ROLLBACK TRANSACTION and Oracle has no RELEASE SAVEPOINT.
+func savepointSyntaxFor(driverName string) savepointSyntax {
+	switch driverName {
+	case "sqlserver", "mssql":
+		return savepointSyntax{
+			create:   "SAVE TRANSACTION %s",
+			rollback: "ROLLBACK TRANSACTION %s",
+		}
+	case "oracle", "godror", "oci8":
+		return savepointSyntax{
+			create:   "SAVEPOINT %s",
+			rollback: "ROLLBACK TO SAVEPOINT %s",
+		}
+	default:
+		return savepointSyntax{
+			create:   "SAVEPOINT %s",
+			rollback: "ROLLBACK TO SAVEPOINT %s",
+			release:  "RELEASE SAVEPOINT %s",
+		}
+	}
+}
+
+// WithNestedTransaction executes a function within a transaction, nesting via a
+// savepoint when the context already carries one.
+// Without a transaction in context: behaves like WithTransaction.
+// With a transaction in context: creates a uniquely named SAVEPOINT on it.
+// If function returns error or panics: rolls back to the savepoint only, leaving
+// the outer transaction usable, and returns the error (or re-panics).
+// If function succeeds: releases the savepoint; the outer transaction decides the
+// final commit or rollback.
+// Use case: Service methods that each need a transaction and may call each other.
+func (db *DB) WithNestedTransaction(ctx context.Context, fn TxFunc) error {
+	tx, ok := ExtractTx(ctx)
+	if !ok {
+		return db.WithTransaction(ctx, fn)
+	}
+	return db.withSavepoint(ctx, tx, fn)
+}
+
+// withSavepoint runs fn inside a savepoint on tx.
+func (db *DB) withSavepoint(ctx context.Context, tx *sql.Tx, fn TxFunc) error {
+	syntax := savepointSyntaxFor(db.driver)
+	name := fmt.Sprintf("sqlkit_sp_%d", savepointSeq.Add(1))
+
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf(syntax.create, name)); err != nil {
+		return fmt.Errorf("sqlkit: failed to create savepoint: %w", err)
+	}
+
+	// Execute function with panic recovery
+	var fnErr error
+	panicked := true
+	defer func() {
+		switch {
+		case panicked:
+			// Roll back to savepoint on panic; the panic keeps propagating
+			if _, rbErr := tx.ExecContext(ctx, fmt.Sprintf(syntax.rollback, name)); rbErr != nil {
+				panic(fmt.Errorf("sqlkit: savepoint panic and rollback failed: %w", rbErr))
+			}
+		case fnErr != nil:
+			// Roll back to savepoint on function error
+			if _, rbErr := tx.ExecContext(ctx, fmt.Sprintf(syntax.rollback, name)); rbErr != nil {
+				fnErr = fmt.Errorf("sqlkit: savepoint error: %w, rollback error: %w", fnErr, rbErr)
+			}
+		case syntax.release != "":
+			// Release savepoint on success
+			if _, relErr := tx.ExecContext(ctx, fmt.Sprintf(syntax.release, name)); relErr != nil {
+				fnErr = fmt.Errorf("sqlkit: failed to release savepoint: %w", relErr)
+			}
+		}
+	}()
+
+	// Execute function
+	fnErr = fn(ctx)
+	panicked = false
+
+	return fnErr
+}
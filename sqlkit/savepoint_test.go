@@ -0,0 +1,140 @@
+package sqlkit
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"slices"
+	"testing"
+)
+
+var savepointName = regexp.MustCompile(`sqlkit_sp_\d+`)
+
+// normalizedQueries returns the recorded statements with savepoint names replaced by "sp".
+func normalizedQueries(b *fakeBackend) []string {
+	queries := b.Queries()
+	for i, q := range queries {
+		queries[i] = savepointName.ReplaceAllString(q, "sp")
+	}
+	return queries
+}
+
+func TestDB_WithNestedTransaction(t *testing.T) {
+	errInner := errors.New("inner failed")
+
+	tests := []struct {
+		name    string
+		outer   bool
+		inner   error
+		wantErr error
+		want    []string
+	}{
+		{
+			name: "without outer transaction begins one",
+			want: []string{"BEGIN", "INSERT", "COMMIT"},
+		},
+		{
+			name:  "nested success releases savepoint",
+			outer: true,
+			want:  []string{"BEGIN", "SAVEPOINT sp", "INSERT", "RELEASE SAVEPOINT sp", "COMMIT"},
+		},
+		{
+			name:    "nested failure rolls back to savepoint only",
+			outer:   true,
+			inner:   errInner,
+			wantErr: errInner,
+			want:    []string{"BEGIN", "SAVEPOINT sp", "INSERT", "ROLLBACK TO SAVEPOINT sp", "COMMIT"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend, leader := newFakeDB(t)
+			db := newTestDB(Config{Leader: DBConfig{Driver: "postgres"}}, leader)
+			ctx := context.Background()
+
+			inner := func(ctx context.Context) error {
+				tx, _ := ExtractTx(ctx)
+				if _, err := tx.ExecContext(ctx, "INSERT"); err != nil {
+					return err
+				}
+				return tt.inner
+			}
+
+			var innerErr error
+			if tt.outer {
+				err := db.WithTransaction(ctx, func(ctx context.Context) error {
+					innerErr = db.WithNestedTransaction(ctx, inner)
+					return nil // outer transaction decides to commit anyway
+				})
+				if err != nil {
+					t.Fatalf("WithTransaction() error = %v", err)
+				}
+			} else {
+				innerErr = db.WithNestedTransaction(ctx, inner)
+			}
+
+			if !errors.Is(innerErr, tt.wantErr) || (tt.wantErr == nil && innerErr != nil) {
+				t.Errorf("WithNestedTransaction() error = %v, want %v", innerErr, tt.wantErr)
+			}
+			if got := normalizedQueries(backend); !slices.Equal(got, tt.want) {
+				t.Errorf("statements = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDB_WithNestedTransaction_Panic(t *testing.T) {
+	backend, leader := newFakeDB(t)
+	db := newTestDB(Config{Leader: DBConfig{Driver: "postgres"}}, leader)
+
+	func() {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Errorf("recover() = %v, want boom", r)
+			}
+		}()
+		_ = db.WithTransaction(context.Background(), func(ctx context.Context) error {
+			return db.WithNestedTransaction(ctx, func(context.Context) error {
+				panic("boom")
+			})
+		})
+	}()
+
+	want := []string{"BEGIN", "SAVEPOINT sp", "ROLLBACK TO SAVEPOINT sp", "ROLLBACK"}
+	if got := normalizedQueries(backend); !slices.Equal(got, want) {
+		t.Errorf("statements = %v, want %v", got, want)
+	}
+}
+
+func TestDB_WithTransaction_RejectsNested(t *testing.T) {
+	_, leader := newFakeDB(t)
+	db := newTestDB(Config{Leader: DBConfig{Driver: "postgres"}}, leader)
+
+	var innerErr error
+	_ = db.WithTransaction(context.Background(), func(ctx context.Context) error {
+		innerErr = db.WithTransaction(ctx, func(context.Context) error { return nil })
+		return nil
+	})
+	if !errors.Is(innerErr, ErrNestedTransaction) {
+		t.Errorf("nested WithTransaction() error = %v, want %v", innerErr, ErrNestedTransaction)
+	}
+}
+
+func TestSavepointSyntaxFor(t *testing.T) {
+	tests := []struct {
+		driver string
+		want   savepointSyntax
+	}{
+		{driver: "postgres", want: savepointSyntax{"SAVEPOINT %s", "ROLLBACK TO SAVEPOINT %s", "RELEASE SAVEPOINT %s"}},
+		{driver: "mysql", want: savepointSyntax{"SAVEPOINT %s", "ROLLBACK TO SAVEPOINT %s", "RELEASE SAVEPOINT %s"}},
+		{driver: "sqlserver", want: savepointSyntax{"SAVE TRANSACTION %s", "ROLLBACK TRANSACTION %s", ""}},
+		{driver: "godror", want: savepointSyntax{"SAVEPOINT %s", "ROLLBACK TO SAVEPOINT %s", ""}},
+	}
+
+	for _, tt := range tests {
+		if got := savepointSyntaxFor(tt.driver); got != tt.want {
+			t.Errorf("savepointSyntaxFor(%q) = %+v, want %+v", tt.driver, got, tt.want)
+		}
+	}
+}
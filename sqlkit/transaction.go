@@ -38,10 +38,15 @@ func (db *DB) WithTransaction(ctx context.Context, fn TxFunc) error {
 
 // WithTransactionOptions executes a function within a transaction with custom options.
 // Same as WithTransaction but uses provided options.
+// Returns ErrNestedTransaction if ctx already carries a transaction; use
+// WithNestedTransaction to nest via a savepoint instead. Returns ErrClosed after Close.
 func (db *DB) WithTransactionOptions(ctx context.Context, opts *sql.TxOptions, fn TxFunc) error {
 	// Check if already in a transaction
 	if _, ok := ExtractTx(ctx); ok {
-		return fmt.Errorf("sqlkit: nested transaction detected")
+		return ErrNestedTransaction
+	}
+	if db.closed.Load() {
+		return ErrClosed
 	}
 
 	// Begin transaction on leader
@@ -85,8 +90,39 @@ func (db *DB) WithTransactionOptions(ctx context.Context, opts *sql.TxOptions, f
 	return fnErr
 }
 
+// WithTransactionRetry runs fn in a leader transaction like WithTransactionOptions,
+// re-running the whole transaction when it fails with a serialization failure or a
+// deadlock (see ClassifyError), which SERIALIZABLE and REPEATABLE READ transactions
+// must expect under contention. Attempts are separated by the Config.Retry backoff;
+// maxAttempts <= 0 uses Config.Retry.MaxAttempts. Any other error, including
+// ErrNestedTransaction, is returned without retrying.
+// fn must be safe to retry: every attempt starts a new transaction, so its database
+// work is rolled back, but side effects outside the transaction (HTTP calls,
+// messages, in-memory state) happen once per attempt.
+// WithTransaction and WithTransactionOptions never retry.
+func (db *DB) WithTransactionRetry(ctx context.Context, opts *sql.TxOptions, maxAttempts int, fn TxFunc) error {
+	if maxAttempts <= 0 {
+		maxAttempts = db.config.Retry.MaxAttempts
+	}
+	return db.retryWhen(ctx, maxAttempts, db.isTxConflict, func() error {
+		return db.WithTransactionOptions(ctx, opts, fn)
+	})
+}
+
+// isTxConflict reports whether err means the transaction lost a race with a
+// concurrent one and can be re-run from the start.
+func (db *DB) isTxConflict(err error) bool {
+	switch ClassifyError(db.driver, err) {
+	case ClassSerializationFailure, ClassDeadlock:
+		return true
+	default:
+		return false
+	}
+}
+
 // WithReadOnlyTransaction executes a read-only transaction on a follower.
-// Uses follower, not leader.
+// Uses follower, not leader, unless the context carries a leader read preference
+// (see WithReadPreference).
 // Still requires commit (even for read-only).
 // Automatically falls back to leader if no healthy followers.
 func (db *DB) WithReadOnlyTransaction(ctx context.Context, fn TxFunc) error {
@@ -96,11 +132,14 @@ func (db *DB) WithReadOnlyTransaction(ctx context.Context, fn TxFunc) error {
 
 	// Check if already in a transaction
 	if _, ok := ExtractTx(ctx); ok {
-		return fmt.Errorf("sqlkit: nested transaction detected")
+		return ErrNestedTransaction
+	}
+	if db.closed.Load() {
+		return ErrClosed
 	}
 
 	// Begin transaction on follower (falls back to leader if no healthy followers)
-	followerDB := db.Follower()
+	followerDB := db.Reader(ctx)
 	tx, err := followerDB.BeginTx(ctx, opts)
 	if err != nil {
 		return fmt.Errorf("sqlkit: failed to begin read-only transaction: %w", err)
@@ -0,0 +1,103 @@
+package sqlkit
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestDB_WithTransactionRetry(t *testing.T) {
+	serialization := &fakePgError{code: "40001"}
+	unique := &fakePgError{code: "23505"}
+
+	tests := []struct {
+		name         string
+		failures     []error
+		maxAttempts  int
+		wantAttempts int
+		wantErr      error
+		want         []string
+	}{
+		{
+			name:         "success",
+			maxAttempts:  3,
+			wantAttempts: 1,
+			want:         []string{"BEGIN", "UPDATE", "COMMIT"},
+		},
+		{
+			name:         "serialization failure then success",
+			failures:     []error{serialization},
+			maxAttempts:  3,
+			wantAttempts: 2,
+			want:         []string{"BEGIN", "UPDATE", "ROLLBACK", "BEGIN", "UPDATE", "COMMIT"},
+		},
+		{
+			name:         "non-retryable not retried",
+			failures:     []error{unique},
+			maxAttempts:  3,
+			wantAttempts: 1,
+			wantErr:      unique,
+			want:         []string{"BEGIN", "UPDATE", "ROLLBACK"},
+		},
+		{
+			name:         "gives up",
+			failures:     []error{serialization, serialization, serialization},
+			maxAttempts:  2,
+			wantAttempts: 2,
+			wantErr:      serialization,
+			want:         []string{"BEGIN", "UPDATE", "ROLLBACK", "BEGIN", "UPDATE", "ROLLBACK"},
+		},
+		{
+			name:         "zero attempts uses Config.Retry",
+			failures:     []error{serialization, serialization, serialization, serialization},
+			wantAttempts: 3,
+			wantErr:      serialization,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend, leader := newFakeDB(t)
+			calls := 0
+			backend.execFn = func(string, []driver.NamedValue) (driver.Result, error) {
+				if calls <= len(tt.failures) {
+					return nil, tt.failures[calls-1]
+				}
+				return driver.RowsAffected(1), nil
+			}
+			retry := RetryConfig{MaxAttempts: 3, InitialDelay: time.Millisecond, Multiplier: 2}
+			db := newTestDB(Config{Leader: DBConfig{Driver: "postgres"}, Retry: retry}, leader)
+
+			err := db.WithTransactionRetry(context.Background(), nil, tt.maxAttempts, func(ctx context.Context) error {
+				calls++
+				tx, _ := ExtractTx(ctx)
+				_, err := tx.ExecContext(ctx, "UPDATE")
+				return err
+			})
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("WithTransactionRetry() error = %v, want %v", err, tt.wantErr)
+			}
+			if calls != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", calls, tt.wantAttempts)
+			}
+			if got := backend.Queries(); tt.want != nil && !slices.Equal(got, tt.want) {
+				t.Errorf("statements = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDB_WithTransactionRetry_Nested(t *testing.T) {
+	_, leader := newFakeDB(t)
+	db := newTestDB(Config{Leader: DBConfig{Driver: "postgres"}}, leader)
+
+	err := db.WithTransaction(context.Background(), func(ctx context.Context) error {
+		return db.WithTransactionRetry(ctx, nil, 3, func(context.Context) error { return nil })
+	})
+	if !errors.Is(err, ErrNestedTransaction) {
+		t.Errorf("WithTransactionRetry() error = %v, want %v", err, ErrNestedTransaction)
+	}
+}